@@ -9,3 +9,46 @@ var (
 		Usage: "path to config",
 	}
 )
+
+var (
+	// ApiKeyName defines the name of the api key holder
+	ApiKeyName = cli.StringFlag{
+		Name:     "name",
+		Usage:    "name of the api key holder",
+		Required: true,
+	}
+
+	// ApiKeyRoles defines the roles granted to the api key holder
+	ApiKeyRoles = cli.StringSliceFlag{
+		Name:  "role",
+		Usage: "role granted to the api key holder, can be repeated",
+	}
+)
+
+var (
+	// FaucetIp defines the ip address of the faucet applicant
+	FaucetIp = cli.StringFlag{
+		Name:  "ip",
+		Usage: "ip address of the faucet applicant",
+	}
+
+	// FaucetAddress defines the address receiving the faucet claims
+	FaucetAddress = cli.StringFlag{
+		Name:  "address",
+		Usage: "address receiving the faucet claims",
+	}
+
+	// FaucetLimit defines the maximal number of the listed faucet claims
+	FaucetLimit = cli.Int64Flag{
+		Name:  "limit",
+		Usage: "maximal number of the listed claims",
+		Value: 50,
+	}
+
+	// FaucetTo defines the address receiving the drained faucet funds
+	FaucetTo = cli.StringFlag{
+		Name:     "to",
+		Usage:    "address receiving the whole balance of the faucet wallet",
+		Required: true,
+	}
+)
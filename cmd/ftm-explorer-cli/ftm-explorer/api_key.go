@@ -0,0 +1,136 @@
+package ftm_explorer
+
+import (
+	"context"
+	"fmt"
+	"ftm-explorer/cmd/ftm-explorer-cli/flags"
+	"ftm-explorer/internal/auth"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository/db"
+	"ftm-explorer/internal/types"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// kApiKeyCmdTimeout is the timeout of the api key database operations.
+const kApiKeyCmdTimeout = 10 * time.Second
+
+// CmdApiKey defines a CLI command for managing the api keys.
+var CmdApiKey = cli.Command{
+	Name:  "apikey",
+	Usage: `Manages the API keys.`,
+	Subcommands: []*cli.Command{
+		{
+			Action: createApiKey,
+			Name:   "create",
+			Usage:  `Creates a new API key and prints it. The key can not be retrieved later.`,
+			Flags: []cli.Flag{
+				&flags.Cfg,
+				&flags.ApiKeyName,
+				&flags.ApiKeyRoles,
+			},
+		},
+		{
+			Action: listApiKeys,
+			Name:   "list",
+			Usage:  `Lists the API keys and their usage.`,
+			Flags: []cli.Flag{
+				&flags.Cfg,
+			},
+		},
+		{
+			Action: revokeApiKey,
+			Name:   "revoke",
+			Usage:  `Revokes the API key of the given holder.`,
+			Flags: []cli.Flag{
+				&flags.Cfg,
+				&flags.ApiKeyName,
+			},
+		},
+	},
+}
+
+// createApiKey creates a new api key.
+func createApiKey(ctx *cli.Context) error {
+	roles := ctx.StringSlice(flags.ApiKeyRoles.Name)
+	for _, role := range roles {
+		if role != auth.RoleAdmin {
+			return fmt.Errorf("unknown role: %s", role)
+		}
+	}
+
+	key, err := auth.GenerateApiKey()
+	if err != nil {
+		return err
+	}
+
+	return withDatabase(ctx, func(c context.Context, database *db.MongoDb) error {
+		err := database.AddApiKey(c, &types.ApiKey{
+			Name:      ctx.String(flags.ApiKeyName.Name),
+			Hash:      auth.HashApiKey(key),
+			Roles:     roles,
+			CreatedAt: time.Now().Unix(),
+		})
+		if err != nil {
+			return fmt.Errorf("can not create api key: %v", err)
+		}
+		_, err = fmt.Fprintln(ctx.App.Writer, key)
+		return err
+	})
+}
+
+// listApiKeys prints the api keys.
+func listApiKeys(ctx *cli.Context) error {
+	return withDatabase(ctx, func(c context.Context, database *db.MongoDb) error {
+		keys, err := database.ApiKeys(c)
+		if err != nil {
+			return fmt.Errorf("can not list api keys: %v", err)
+		}
+
+		w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tROLES\tCREATED\tLAST USED\tUSAGE\tREVOKED")
+		for _, key := range keys {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+				key.Name, strings.Join(key.Roles, ","), formatUnix(&key.CreatedAt), formatUnix(key.LastUsedAt), key.Usage, formatUnix(key.RevokedAt))
+		}
+		return w.Flush()
+	})
+}
+
+// revokeApiKey revokes the api key of the given holder.
+func revokeApiKey(ctx *cli.Context) error {
+	return withDatabase(ctx, func(c context.Context, database *db.MongoDb) error {
+		if err := database.RevokeApiKey(c, ctx.String(flags.ApiKeyName.Name)); err != nil {
+			return fmt.Errorf("can not revoke api key: %v", err)
+		}
+		return nil
+	})
+}
+
+// withDatabase connects the database configured for the given command and runs the given function.
+func withDatabase(ctx *cli.Context, fn func(context.Context, *db.MongoDb) error) error {
+	cfg := config.Load(ctx.String(flags.Cfg.Name))
+	log := logger.New(ctx.App.ErrWriter, &cfg.Logger)
+
+	database, err := db.NewMongoDb(&cfg.MongoDb, log)
+	if err != nil {
+		return fmt.Errorf("can not create database connection: %v", err)
+	}
+	defer database.Close()
+
+	c, cancel := context.WithTimeout(context.Background(), kApiKeyCmdTimeout)
+	defer cancel()
+	return fn(c, database)
+}
+
+// formatUnix formats the given unix timestamp, nil timestamps are printed as a dash.
+func formatUnix(ts *int64) string {
+	if ts == nil {
+		return "-"
+	}
+	return time.Unix(*ts, 0).UTC().Format(time.RFC3339)
+}
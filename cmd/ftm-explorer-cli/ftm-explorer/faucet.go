@@ -0,0 +1,214 @@
+package ftm_explorer
+
+import (
+	"context"
+	"fmt"
+	"ftm-explorer/cmd/ftm-explorer-cli/flags"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/faucet"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository/db"
+	"ftm-explorer/internal/types"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// kFaucetResetWindow is the window of the claims marked as reset by the faucet reset,
+// it covers the daily claims limit of the applicant.
+const kFaucetResetWindow = 24 * time.Hour
+
+// CmdFaucet defines a CLI command for administering the faucet.
+var CmdFaucet = cli.Command{
+	Name:  "faucet",
+	Usage: `Administers the faucet.`,
+	Subcommands: []*cli.Command{
+		{
+			Action: faucetStatus,
+			Name:   "status",
+			Usage:  `Prints the balances of the faucet wallets and the pending claims.`,
+			Flags: []cli.Flag{
+				&flags.Cfg,
+			},
+		},
+		{
+			Action: faucetHistory,
+			Name:   "history",
+			Usage:  `Lists the latest claims of the given ip address or receiver address.`,
+			Flags: []cli.Flag{
+				&flags.Cfg,
+				&flags.FaucetIp,
+				&flags.FaucetAddress,
+				&flags.FaucetLimit,
+			},
+		},
+		{
+			Action: faucetReset,
+			Name:   "reset",
+			Usage:  `Marks the mined claims of the given ip address made in the last 24 hours as reset, so it can claim again.`,
+			Flags: []cli.Flag{
+				&flags.Cfg,
+				&flags.FaucetIp,
+			},
+		},
+		{
+			Action: faucetDrain,
+			Name:   "drain",
+			Usage:  `Sends the whole balance of the faucet wallet left after paying the fee to the given address.`,
+			Flags: []cli.Flag{
+				&flags.Cfg,
+				&flags.FaucetTo,
+			},
+		},
+	},
+}
+
+// faucetStatus prints the balances of the faucet wallets and the number of pending claims.
+func faucetStatus(ctx *cli.Context) error {
+	cfg := config.Load(ctx.String(flags.Cfg.Name))
+	log := logger.New(ctx.App.ErrWriter, &cfg.Logger)
+
+	repo, err := createRepository(cfg, log)
+	if err != nil {
+		return err
+	}
+	wallet, err := faucet.NewWallet(repo, log, cfg.Faucet.WalletPrivateKey)
+	if err != nil {
+		return fmt.Errorf("can not create faucet wallet: %v", err)
+	}
+	erc20s, err := faucet.NewFaucetErc20s(&cfg.Faucet, repo, log)
+	if err != nil {
+		return fmt.Errorf("can not create faucet erc20s: %v", err)
+	}
+	monitor, err := faucet.NewMonitor(&cfg.Faucet, repo, log, wallet, erc20s)
+	if err != nil {
+		return fmt.Errorf("can not create faucet monitor: %v", err)
+	}
+	monitor.CheckBalances()
+
+	tokens := []*common.Address{nil}
+	for _, erc20 := range cfg.Faucet.Erc20s {
+		address := common.HexToAddress(erc20.Address)
+		tokens = append(tokens, &address)
+	}
+
+	w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TOKEN\tWALLET\tBALANCE\tMIN BALANCE\tENABLED")
+	for _, token := range tokens {
+		status, err := monitor.Status(token)
+		if err != nil {
+			return fmt.Errorf("can not get faucet status: %v", err)
+		}
+		balance := "-"
+		if status.Balance != nil {
+			balance = status.Balance.String()
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%v\n",
+			formatToken(token), status.Address.Hex(), balance, status.MinBalance.String(), status.Enabled)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	pending, err := repo.GetPendingTokensRequests()
+	if err != nil {
+		return fmt.Errorf("can not get pending claims: %v", err)
+	}
+	_, err = fmt.Fprintf(ctx.App.Writer, "\npending claims: %d\n", len(pending))
+	return err
+}
+
+// faucetHistory prints the latest claims of the given ip address or receiver address.
+func faucetHistory(ctx *cli.Context) error {
+	ip := ctx.String(flags.FaucetIp.Name)
+	address := ctx.String(flags.FaucetAddress.Name)
+	if (ip == "") == (address == "") {
+		return fmt.Errorf("exactly one of --%s and --%s must be given", flags.FaucetIp.Name, flags.FaucetAddress.Name)
+	}
+	if address != "" && !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid address: %s", address)
+	}
+	limit := ctx.Int64(flags.FaucetLimit.Name)
+
+	return withDatabase(ctx, func(c context.Context, database *db.MongoDb) error {
+		var claims []types.TokensRequest
+		var err error
+		if ip != "" {
+			claims, err = database.TokensRequestClaims(c, ip, limit)
+		} else {
+			claims, err = database.ReceiverTokensRequestClaims(c, common.HexToAddress(address), limit)
+		}
+		if err != nil {
+			return fmt.Errorf("can not list faucet claims: %v", err)
+		}
+
+		w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "IP\tRECEIVER\tTOKEN\tSTATUS\tTX\tCLAIMED")
+		for _, claim := range claims {
+			receiver, tx := "-", "-"
+			if claim.Receiver != nil {
+				receiver = claim.Receiver.Hex()
+			}
+			if claim.TxHash != nil {
+				tx = claim.TxHash.Hex()
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				claim.IpAddress, receiver, formatToken(claim.Erc20), claim.Status, tx, formatUnix(claim.ClaimedAt))
+		}
+		return w.Flush()
+	})
+}
+
+// faucetReset marks the recent mined claims of the given ip address as reset, so it can claim again.
+func faucetReset(ctx *cli.Context) error {
+	ip := ctx.String(flags.FaucetIp.Name)
+	if ip == "" {
+		return fmt.Errorf("--%s must be given", flags.FaucetIp.Name)
+	}
+
+	return withDatabase(ctx, func(c context.Context, database *db.MongoDb) error {
+		reset, err := database.ResetTokensRequests(c, ip, time.Now().Add(-kFaucetResetWindow).Unix())
+		if err != nil {
+			return fmt.Errorf("can not reset faucet claims: %v", err)
+		}
+		_, err = fmt.Fprintf(ctx.App.Writer, "reset %d claims of %s\n", reset, ip)
+		return err
+	})
+}
+
+// faucetDrain sends the whole balance of the faucet wallet to the given address.
+func faucetDrain(ctx *cli.Context) error {
+	to := ctx.String(flags.FaucetTo.Name)
+	if !common.IsHexAddress(to) {
+		return fmt.Errorf("invalid address: %s", to)
+	}
+
+	cfg := config.Load(ctx.String(flags.Cfg.Name))
+	log := logger.New(ctx.App.ErrWriter, &cfg.Logger)
+
+	repo, err := createRepository(cfg, log)
+	if err != nil {
+		return err
+	}
+	wallet, err := faucet.NewWallet(repo, log, cfg.Faucet.WalletPrivateKey)
+	if err != nil {
+		return fmt.Errorf("can not create faucet wallet: %v", err)
+	}
+
+	hash, err := wallet.Drain(common.HexToAddress(to))
+	if err != nil {
+		return fmt.Errorf("can not drain faucet wallet: %v", err)
+	}
+	_, err = fmt.Fprintln(ctx.App.Writer, hash.Hex())
+	return err
+}
+
+// formatToken formats the given erc20 token address, the native token is printed as FTM.
+func formatToken(erc20 *common.Address) string {
+	if erc20 == nil {
+		return "FTM"
+	}
+	return erc20.Hex()
+}
@@ -12,6 +12,7 @@ import (
 	"ftm-explorer/internal/repository/db"
 	"ftm-explorer/internal/repository/meta_fetcher"
 	"ftm-explorer/internal/repository/rpc"
+	"ftm-explorer/internal/siwe"
 	"ftm-explorer/internal/svc"
 
 	"github.com/urfave/cli/v2"
@@ -54,11 +55,14 @@ func run(ctx *cli.Context) error {
 	// create maze if enabled
 	var m maze.IMaze
 	if cfg.Maze != nil {
-		m = maze.NewMaze(cfg.Maze)
+		m = maze.NewMaze(cfg.Maze, repo)
 	}
 
+	// create sign-in with ethereum sessions
+	s := siwe.NewSiwe(&cfg.Siwe, repo)
+
 	// create api server
-	apiServer := api.NewApiServer(cfg, repo, fct, m, log)
+	apiServer := api.NewApiServer(cfg, repo, fct, m, s, log)
 
 	// run api server
 	apiServer.Start()
@@ -84,7 +88,7 @@ func createRepository(cfg *config.Config, log logger.ILogger) (*repository.Repos
 	metaFetcher := meta_fetcher.NewMetaFetcher(&cfg.MetaFetcher, log)
 
 	// create repository
-	return repository.NewRepository(cfg.Explorer.BlockBufferSize, operaRpc, database, metaFetcher), nil
+	return repository.NewRepository(cfg.Explorer.BlockBufferSize, cfg.Explorer.TrxCacheSize, operaRpc, database, metaFetcher), nil
 }
 
 // createFaucet creates a new faucet instance.
@@ -97,7 +101,16 @@ func createFaucet(cfg *config.Config, repo *repository.Repository, log logger.IL
 	if err != nil {
 		return nil, fmt.Errorf("can not create faucet erc20s: %v", err)
 	}
-	f, err := faucet.NewFaucet(&cfg.Faucet, faucet.NewPhraseGenerator(), wallet, erc20s, repo)
+	monitor, err := faucet.NewMonitor(&cfg.Faucet, repo, log, wallet, erc20s)
+	if err != nil {
+		return nil, fmt.Errorf("can not create faucet monitor: %v", err)
+	}
+	monitor.Start()
+	gate, err := faucet.NewGate(&cfg.Faucet.Gate, repo)
+	if err != nil {
+		return nil, fmt.Errorf("can not create faucet gate: %v", err)
+	}
+	f, err := faucet.NewFaucet(&cfg.Faucet, faucet.NewPhraseGenerator(), wallet, erc20s, monitor, gate, repo)
 	if err != nil {
 		return nil, fmt.Errorf("can not create faucet: %v", err)
 	}
@@ -8,7 +8,10 @@ import (
 	"ftm-explorer/internal/faucet"
 	"ftm-explorer/internal/logger"
 	"ftm-explorer/internal/maze"
+	"ftm-explorer/internal/ratelimit"
 	"ftm-explorer/internal/repository"
+	"ftm-explorer/internal/siwe"
+	"ftm-explorer/internal/types"
 	"net/http"
 	"time"
 )
@@ -24,10 +27,10 @@ type ApiServer struct {
 }
 
 // NewApiServer creates a new GraphQL API server.
-func NewApiServer(cfg *config.Config, repo repository.IRepository, faucet faucet.IFaucet, maze maze.IMaze, log logger.ILogger) *ApiServer {
+func NewApiServer(cfg *config.Config, repo repository.IRepository, faucet faucet.IFaucet, maze maze.IMaze, siwe siwe.ISiwe, log logger.ILogger) *ApiServer {
 	apiLogger := log.ModuleLogger("api")
 	server := &ApiServer{
-		resolver: resolvers.NewResolver(repo, apiLogger, faucet, maze, cfg.Explorer.IsPersisted),
+		resolver: resolvers.NewResolver(repo, apiLogger, faucet, maze, siwe, cfg.Explorer.IsPersisted),
 		cfg:      &cfg.Api,
 		log:      apiLogger.ModuleLogger("api"),
 		repo:     repo,
@@ -50,9 +53,22 @@ func (api *ApiServer) makeHttpServer() {
 	// create request MUXer
 	srvMux := http.NewServeMux()
 
+	proxies, err := middlewares.ParseTrustedProxies(api.cfg.TrustedProxies)
+	if err != nil {
+		api.log.Fatalf("failed to parse trusted proxies; %v", err)
+	}
+	ipHeader, err := middlewares.ParseClientIpHeader(api.cfg.ClientIpHeader)
+	if err != nil {
+		api.log.Fatalf("failed to parse client ip header; %v", err)
+	}
+
 	h := http.TimeoutHandler(
-		middlewares.AuthMiddleware(
-			handlers.ApiHandler(api.cfg.CorsOrigin, api.resolver, api.log),
+		middlewares.AuthMiddleware(proxies, ipHeader,
+			middlewares.ApiKeyMiddleware(api.repo, api.log,
+				middlewares.RateLimitMiddleware(api.rateLimiter(), api.log,
+					handlers.ApiHandler(api.cfg, api.resolver, api.log),
+				),
+			),
 		),
 		time.Second*time.Duration(api.cfg.ResolverTimeout),
 		"Service timeout.",
@@ -78,3 +94,25 @@ func (api *ApiServer) makeHttpServer() {
 		Handler:           srvMux,
 	}
 }
+
+// rateLimiter creates the rate limiter of API clients based on the configuration.
+func (api *ApiServer) rateLimiter() *ratelimit.Limiter {
+	var store ratelimit.IStore
+	switch api.cfg.RateLimit.Store {
+	case "mongodb":
+		store = ratelimit.NewRepositoryStore(api.repo)
+	case "memory", "":
+		store = ratelimit.NewMemoryStore()
+	default:
+		api.log.Fatalf("unknown rate limit store: %s", api.cfg.RateLimit.Store)
+	}
+
+	budget := func(b config.RateLimitBudget) types.RateLimit {
+		return types.RateLimit{Rate: b.Rate, Burst: b.Burst}
+	}
+	return ratelimit.NewLimiter(store, map[ratelimit.Budget]types.RateLimit{
+		ratelimit.BudgetQuery:        budget(api.cfg.RateLimit.Queries),
+		ratelimit.BudgetMutation:     budget(api.cfg.RateLimit.Mutations),
+		ratelimit.BudgetSubscription: budget(api.cfg.RateLimit.Subscriptions),
+	})
+}
@@ -8,8 +8,10 @@ import (
 	"ftm-explorer/internal/api/handlers"
 	"ftm-explorer/internal/api/middlewares"
 	"ftm-explorer/internal/auth"
+	"ftm-explorer/internal/config"
 	"ftm-explorer/internal/faucet"
 	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/ratelimit"
 	"ftm-explorer/internal/repository"
 	"ftm-explorer/internal/types"
 	"ftm-explorer/internal/utils"
@@ -18,11 +20,13 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
 )
 
 // apiTestCase represents a test case for the API server.
@@ -42,8 +46,13 @@ func TestApiServer_Run(t *testing.T) {
 	mockLogger := logger.NewMockLogger()
 
 	// initialize test server
-	handler := middlewares.AuthMiddleware(
-		handlers.ApiHandler([]string{"*"}, resolvers.NewResolver(mockRepository, mockLogger, mockFaucet, nil, false), mockLogger),
+	handler := middlewares.AuthMiddleware(testTrustedProxies(t), middlewares.ClientIpHeaderForwardedFor,
+		handlers.ApiHandler(&config.ApiServer{
+			CorsOrigin:    []string{"*"},
+			MaxQueryDepth: 6,
+			MaxQueryCost:  1_000,
+			FieldCosts:    map[string]map[string]int{"query": {"recentBlocks": 50}, "block": {"fullTransactions": 10}},
+		}, resolvers.NewResolver(mockRepository, mockLogger, mockFaucet, nil, nil, false), mockLogger),
 	)
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -51,20 +60,30 @@ func TestApiServer_Run(t *testing.T) {
 	// use table-driven testing to test multiple cases
 	testCases := []apiTestCase{
 		getTransactionTestCase(t),
+		getBlockFetchesDeduplicatedTestCase(t),
 		getBlockTestCase(t),
 		getRecentBlocksTestCase(t),
+		getQueryDepthExceededTestCase(t),
+		getQueryCostExceededTestCase(t),
 		getCurrentBlockHeightTestCase(t),
 		getBlockTimestampTxsCountAggregationsTestCase(t),
 		getBlockTimestampGasUsedAggregationsTestCase(t),
 		getNumberOfAccountsTestCase(t),
 		getNumberOfTransactionsTestCase(t),
 		getNumberOfValidatorsTestCase(t),
+		getValidatorsTestCase(t),
+		getValidatorNotFoundTestCase(t),
+		getEpochTestCase(t),
+		getEpochsTestCase(t),
+		getAccountDelegationsTestCase(t),
 		getDiskSizePer100MTxsTestCase(t),
 		getDiskSizePrunedPer100MTxsTestCase(t),
 		getTimeToFinalityTestCase(t),
 		getIsIdleTestCase(t),
 		getIsIdleOverrideTestCase(t),
 		getTimeToBlockTestCase(t),
+		getGasPriceTestCase(t),
+		getTopGasConsumersTestCase(t),
 		getCurrentStateTestCase(t),
 		getRequestTokensTestCase(t),
 		getClaimTokensTestCase(t),
@@ -95,6 +114,372 @@ func TestApiServer_Run(t *testing.T) {
 	}
 }
 
+// Test that the cost budget applies to operations received over websocket.
+func TestApiServer_WebsocketQueryCost(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	handler := handlers.ApiHandler(&config.ApiServer{
+		CorsOrigin:   []string{"*"},
+		MaxQueryCost: 1_000,
+		FieldCosts:   map[string]map[string]int{"query": {"recentBlocks": 50}, "block": {"fullTransactions": 10}},
+	}, resolvers.NewResolver(mockRepository, mockLogger, nil, nil, nil, false), mockLogger)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-ws"}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	// the expensive query is rejected without being executed
+	for _, msg := range []string{
+		`{"type": "connection_init"}`,
+		`{"type": "start", "id": "1", "payload": {"query": "query { recentBlocks(limit: 50) { fullTransactions { hash, from, to } } }"}}`,
+	} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatalf("failed to write message: %v", err)
+		}
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("failed to set read deadline: %v", err)
+	}
+	for {
+		var msg struct {
+			Type    string      `json:"type"`
+			ID      string      `json:"id"`
+			Payload apiResponse `json:"payload"`
+		}
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("failed to read message: %v", err)
+		}
+		if msg.Type != "data" {
+			continue
+		}
+		if len(msg.Payload.Errors) == 0 || msg.Payload.Errors[0].Message != "query cost 1500 exceeds the maximum allowed cost 1000" {
+			t.Fatalf("expected query cost error, got %+v", msg.Payload)
+		}
+		return
+	}
+}
+
+// Test that too large websocket messages close the connection.
+func TestApiServer_WebsocketMessageTooLarge(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	handler := handlers.ApiHandler(&config.ApiServer{CorsOrigin: []string{"*"}, MaxQueryCost: 1_000},
+		resolvers.NewResolver(mockRepository, mockLogger, nil, nil, nil, false), mockLogger)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-ws"}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	msg := `{"type": "start", "id": "1", "payload": {"query": "query { numberOfAccounts }", "operationName": "` + strings.Repeat("x", 2<<20) + `"}}`
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatalf("failed to write message: %v", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("failed to set read deadline: %v", err)
+	}
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue
+		}
+		if !websocket.IsCloseError(err, websocket.CloseMessageTooBig) {
+			t.Fatalf("expected connection closed for too large message, got %v", err)
+		}
+		return
+	}
+}
+
+// Test that too large request bodies are rejected.
+func TestApiServer_RequestBodyTooLarge(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	handler := handlers.ApiHandler(&config.ApiServer{CorsOrigin: []string{"*"}, MaxQueryCost: 1_000},
+		resolvers.NewResolver(mockRepository, mockLogger, nil, nil, nil, false), mockLogger)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := `{"query": "query { numberOfAccounts }", "operationName": "` + strings.Repeat("x", 2<<20) + `"}`
+	resp, err := server.Client().Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to make request: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status Bad Request, got %v", resp.Status)
+	}
+}
+
+// Test that the cost of queries sent in the body of requests of any method is limited.
+func TestApiServer_QueryCostAnyMethod(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	handler := handlers.ApiHandler(&config.ApiServer{
+		CorsOrigin:   []string{"*"},
+		MaxQueryCost: 1_000,
+		FieldCosts:   map[string]map[string]int{"query": {"recentBlocks": 50}, "block": {"fullTransactions": 10}},
+	}, resolvers.NewResolver(mockRepository, mockLogger, nil, nil, nil, false), mockLogger)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := `{"query": "query { recentBlocks(limit: 50) { fullTransactions { hash, from, to } } }"}`
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		req, err := http.NewRequest(method, server.URL, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatalf("failed to make request: %v", err)
+		}
+		apiRes := decodeResponse(t, resp)
+		if len(apiRes.Errors) == 0 || apiRes.Errors[0].Message != "query cost 1500 exceeds the maximum allowed cost 1000" {
+			t.Errorf("expected query cost error for method %s, got %+v", method, apiRes.Errors)
+		}
+	}
+}
+
+// Test that clients are rate limited by separate budgets.
+func TestApiServer_RateLimit(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockFaucet := faucet.NewMockFaucet(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	// initialize test server, queries have budget of 2 requests, mutations 1 request
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), map[ratelimit.Budget]types.RateLimit{
+		ratelimit.BudgetQuery:    {Rate: 0.01, Burst: 2},
+		ratelimit.BudgetMutation: {Rate: 0.01, Burst: 1},
+	})
+	handler := middlewares.AuthMiddleware(testTrustedProxies(t), middlewares.ClientIpHeaderForwardedFor,
+		middlewares.RateLimitMiddleware(limiter, mockLogger,
+			handlers.ApiHandler(&config.ApiServer{CorsOrigin: []string{"*"}, MaxQueryCost: 1_000}, resolvers.NewResolver(mockRepository, mockLogger, mockFaucet, nil, nil, false), mockLogger),
+		),
+	)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	mockRepository.EXPECT().GetNumberOfAccounts().Return(uint64(10)).AnyTimes()
+	mockFaucet.EXPECT().RequestTokens(gomock.Any(), gomock.Any(), gomock.Any()).Return("phrase", nil).AnyTimes()
+
+	post := func(client string, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", client)
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatalf("failed to make request: %v", err)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+	query := `{"query": "query { numberOfAccounts }"}`
+	mutation := `{"query": "mutation { requestTokens }"}`
+
+	// the query budget is exhausted after 2 requests
+	for i := 0; i < 2; i++ {
+		if resp := post("10.0.0.1", query); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status OK, got %v", resp.Status)
+		}
+	}
+	resp := post("10.0.0.1", query)
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status Too Many Requests, got %v", resp.Status)
+	}
+	if resp.Header.Get("Retry-After") != "100" {
+		t.Errorf("expected Retry-After 100, got %s", resp.Header.Get("Retry-After"))
+	}
+
+	// mutations have their own budget
+	if resp := post("10.0.0.1", mutation); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status OK, got %v", resp.Status)
+	}
+	if resp := post("10.0.0.1", mutation); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status Too Many Requests, got %v", resp.Status)
+	}
+
+	// other clients have their own budget
+	if resp := post("10.0.0.2", query); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status OK, got %v", resp.Status)
+	}
+
+	// mutations sent with other methods are charged to the mutation budget as well
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(mutation))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	if resp, err := server.Client().Do(req); err != nil {
+		t.Fatalf("failed to make request: %v", err)
+	} else if _ = resp.Body.Close(); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status Too Many Requests, got %v", resp.Status)
+	}
+
+	// too large requests are rejected before they are charged
+	large := `{"query": "query { numberOfAccounts }", "operationName": "` + strings.Repeat("x", 2<<20) + `"}`
+	if resp := post("10.0.0.3", large); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status Bad Request, got %v", resp.Status)
+	}
+}
+
+// Test that each operation received over websocket is charged to the rate limit budget of its type.
+func TestApiServer_WebsocketRateLimit(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	// initialize test server, queries have budget of 2 operations
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), map[ratelimit.Budget]types.RateLimit{
+		ratelimit.BudgetQuery: {Rate: 0.01, Burst: 2},
+	})
+	handler := middlewares.AuthMiddleware(testTrustedProxies(t), middlewares.ClientIpHeaderForwardedFor,
+		middlewares.RateLimitMiddleware(limiter, mockLogger,
+			handlers.ApiHandler(&config.ApiServer{CorsOrigin: []string{"*"}}, resolvers.NewResolver(mockRepository, mockLogger, nil, nil, nil, false), mockLogger),
+		),
+	)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-ws"}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), http.Header{"X-Forwarded-For": []string{"10.0.0.1"}})
+	if err != nil {
+		t.Fatalf("failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"type": "connection_init", "payload": {}}`)); err != nil {
+		t.Fatalf("failed to write message: %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		msg := fmt.Sprintf(`{"type": "start", "id": "%d", "payload": {"query": "query { numberOfAccounts }"}}`, i)
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatalf("failed to write message: %v", err)
+		}
+	}
+
+	// operations are handled concurrently, one of them exceeds the budget
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("failed to set read deadline: %v", err)
+	}
+	limited, completed := 0, 0
+	for completed < 3 {
+		var msg struct {
+			Type    string      `json:"type"`
+			ID      string      `json:"id"`
+			Payload apiResponse `json:"payload"`
+		}
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("failed to read message: %v", err)
+		}
+		switch msg.Type {
+		case "data":
+			if len(msg.Payload.Errors) > 0 && msg.Payload.Errors[0].Message == "rate limit exceeded" {
+				limited++
+			}
+		case "complete":
+			completed++
+		}
+	}
+	if limited != 1 {
+		t.Errorf("expected 1 rate limited query, got %d", limited)
+	}
+}
+
+// Test that admin operations require an api key with the admin role.
+func TestApiServer_AdminAccess(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockFaucet := faucet.NewMockFaucet(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	// initialize test server
+	handler := middlewares.AuthMiddleware(testTrustedProxies(t), middlewares.ClientIpHeaderForwardedFor,
+		middlewares.ApiKeyMiddleware(mockRepository, mockLogger,
+			handlers.ApiHandler(&config.ApiServer{CorsOrigin: []string{"*"}}, resolvers.NewResolver(mockRepository, mockLogger, mockFaucet, nil, nil, false), mockLogger),
+		),
+	)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	mockRepository.EXPECT().UseApiKey(auth.HashApiKey("admin-key")).Return(&types.ApiKey{Name: "admin", Roles: []string{auth.RoleAdmin}}, nil).AnyTimes()
+	mockRepository.EXPECT().UseApiKey(auth.HashApiKey("user-key")).Return(&types.ApiKey{Name: "user"}, nil).AnyTimes()
+	mockRepository.EXPECT().UseApiKey(auth.HashApiKey("revoked-key")).Return(nil, nil).AnyTimes()
+	mockRepository.EXPECT().SetIsIdleOverride(true).Times(1)
+
+	post := func(key string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"query": "mutation { setIdleOverride(idle: true) }"}`))
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatalf("failed to make request: %v", err)
+		}
+		t.Cleanup(func() {
+			_ = resp.Body.Close()
+		})
+		return resp
+	}
+
+	// anonymous and non admin clients are rejected
+	for _, key := range []string{"", "user-key"} {
+		resp := post(key)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status OK, got %v", resp.Status)
+		}
+		if res := decodeResponse(t, resp); len(res.Errors) == 0 {
+			t.Errorf("expected error for key %q", key)
+		}
+	}
+
+	// unknown keys are rejected before reaching the api
+	if resp := post("revoked-key"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status Unauthorized, got %v", resp.Status)
+	}
+
+	// admins can override the idle state
+	resp := post("admin-key")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status OK, got %v", resp.Status)
+	}
+	if res := decodeResponse(t, resp); len(res.Errors) != 0 {
+		t.Errorf("unexpected errors: %v", res.Errors)
+	}
+}
+
 // getTransactionTestCase returns a test case for a transaction not found error.
 func getTransactionTestCase(t *testing.T) apiTestCase {
 	trx := getTestTransaction(t)
@@ -175,6 +560,52 @@ func getBlockTestCase(t *testing.T) apiTestCase {
 	}
 }
 
+// getBlockFetchesDeduplicatedTestCase returns a test case for a block query requesting the same data multiple times.
+func getBlockFetchesDeduplicatedTestCase(t *testing.T) apiTestCase {
+	block := getTestBlock(t)
+	trx := getTestTransaction(t)
+	return apiTestCase{
+		testName:    "GetBlockFetchesDeduplicated",
+		requestBody: fmt.Sprintf(`{"query": "query { block(number: \"%s\") { a: fullTransactions { hash, block { number } }, b: fullTransactions { hash } }}"}`, block.Number.String()),
+		buildStubs: func(mockRepository *repository.MockRepository, _ *faucet.MockFaucet) {
+			// every piece of data is fetched exactly once within the request
+			mockRepository.EXPECT().GetBlockByNumber(gomock.Eq(uint64(block.Number))).Return(&block, nil).Times(1)
+			for _, hash := range block.Transactions {
+				mockRepository.EXPECT().GetTransactionByHash(gomock.Eq(hash)).Return(&trx, nil).Times(1)
+			}
+			mockRepository.EXPECT().GetBlockByNumber(gomock.Eq(uint64(*trx.BlockNumber))).Return(&types.Block{Number: *trx.BlockNumber}, nil).Times(1)
+		},
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) != 0 {
+				t.Errorf("expected no errors, got: %s", apiRes.Errors[0].Message)
+			}
+			blockRes := struct {
+				Block struct {
+					A []struct {
+						Hash  common.Hash `json:"hash"`
+						Block struct {
+							Number hexutil.Uint64 `json:"number"`
+						} `json:"block"`
+					} `json:"a"`
+					B []types.Transaction `json:"b"`
+				} `json:"block"`
+			}{}
+			if err := json.Unmarshal(apiRes.Data, &blockRes); err != nil {
+				t.Errorf("failed to unmarshall data: %v", err)
+			}
+			if len(blockRes.Block.A) != len(block.Transactions) || len(blockRes.Block.B) != len(block.Transactions) {
+				t.Fatalf("expected %d transactions, got %d and %d", len(block.Transactions), len(blockRes.Block.A), len(blockRes.Block.B))
+			}
+			for _, tx := range blockRes.Block.A {
+				if tx.Block.Number != *trx.BlockNumber {
+					t.Errorf("expected block number %d, got %d", *trx.BlockNumber, tx.Block.Number)
+				}
+			}
+		},
+	}
+}
+
 // getRecentBlocksTestCase returns a test case for a recent blocks query.
 func getRecentBlocksTestCase(_ *testing.T) apiTestCase {
 	blocks := []*types.Block{
@@ -210,6 +641,40 @@ func getRecentBlocksTestCase(_ *testing.T) apiTestCase {
 	}
 }
 
+// getQueryDepthExceededTestCase returns a test case for a query nested deeper than allowed.
+func getQueryDepthExceededTestCase(_ *testing.T) apiTestCase {
+	return apiTestCase{
+		testName:    "QueryDepthExceeded",
+		requestBody: `{"query": "query { block(number: \"0x1\") { fullTransactions { block { fullTransactions { block { fullTransactions { hash } } } } } } }"}`,
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) == 0 {
+				t.Fatalf("expected max depth error, got none")
+			}
+			if !strings.Contains(apiRes.Errors[0].Message, "exceeds max depth 6") {
+				t.Errorf("expected max depth error, got: %s", apiRes.Errors[0].Message)
+			}
+		},
+	}
+}
+
+// getQueryCostExceededTestCase returns a test case for a query exceeding the cost budget.
+func getQueryCostExceededTestCase(_ *testing.T) apiTestCase {
+	return apiTestCase{
+		testName:    "QueryCostExceeded",
+		requestBody: `{"query": "query { recentBlocks(limit: 50) { fullTransactions { hash, from, to } } }"}`,
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) == 0 {
+				t.Fatalf("expected query cost error, got none")
+			}
+			if apiRes.Errors[0].Message != "query cost 1500 exceeds the maximum allowed cost 1000" {
+				t.Errorf("expected query cost error, got: %s", apiRes.Errors[0].Message)
+			}
+		},
+	}
+}
+
 // getRecentBlocksTestCase returns a test case for a recent blocks query.
 func getCurrentBlockHeightTestCase(_ *testing.T) apiTestCase {
 	var blockHeight uint64 = 100_000
@@ -389,6 +854,255 @@ func getNumberOfValidatorsTestCase(_ *testing.T) apiTestCase {
 	}
 }
 
+// getValidatorsTestCase returns a test case for validators query.
+func getValidatorsTestCase(_ *testing.T) apiTestCase {
+	validators := []types.Validator{
+		{
+			Id:             1,
+			Auth:           common.HexToAddress("0x1"),
+			Status:         types.ValidatorStatusActive,
+			SelfStake:      hexutil.Big(*big.NewInt(500_000)),
+			DelegatedStake: hexutil.Big(*big.NewInt(1_500_000)),
+			Uptime:         300,
+		},
+		{
+			Id:               2,
+			Auth:             common.HexToAddress("0x2"),
+			Status:           types.ValidatorStatusOffline,
+			SelfStake:        hexutil.Big(*big.NewInt(500_000)),
+			DeactivatedEpoch: 10,
+		},
+	}
+	return apiTestCase{
+		testName:    "GetValidators",
+		requestBody: `{"query": "query { validators { id, auth, status, selfStake, delegatedStake, totalStake, deactivatedEpoch, uptime } }"}`,
+		buildStubs: func(mockRepository *repository.MockRepository, _ *faucet.MockFaucet) {
+			mockRepository.EXPECT().GetValidators().Return(validators, nil)
+		},
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) != 0 {
+				t.Errorf("expected no errors, got: %s", apiRes.Errors[0].Message)
+			}
+			// decode raw data into response
+			validatorsRes := struct {
+				Validators []struct {
+					types.Validator
+					TotalStake hexutil.Big `json:"totalStake"`
+				} `json:"validators"`
+			}{}
+			if err := json.Unmarshal(apiRes.Data, &validatorsRes); err != nil {
+				t.Errorf("failed to unmarshall data: %v", err)
+			}
+			// validate validators
+			if len(validatorsRes.Validators) != len(validators) {
+				t.Fatalf("expected %d validators, got %d", len(validators), len(validatorsRes.Validators))
+			}
+			for i, res := range validatorsRes.Validators {
+				expected := validators[i]
+				if res.Id != expected.Id || res.Auth != expected.Auth || res.Status != expected.Status ||
+					res.DeactivatedEpoch != expected.DeactivatedEpoch || res.Uptime != expected.Uptime {
+					t.Errorf("expected validator %+v, got %+v", expected, res.Validator)
+				}
+				total := new(big.Int).Add(expected.SelfStake.ToInt(), expected.DelegatedStake.ToInt())
+				if res.TotalStake.ToInt().Cmp(total) != 0 {
+					t.Errorf("expected total stake %s, got %s", total, res.TotalStake.ToInt())
+				}
+			}
+		},
+	}
+}
+
+// getValidatorNotFoundTestCase returns a test case for validator query of unknown validator.
+func getValidatorNotFoundTestCase(_ *testing.T) apiTestCase {
+	return apiTestCase{
+		testName:    "GetValidatorNotFound",
+		requestBody: `{"query": "query { validator(id: 1000) { id, auth } }"}`,
+		buildStubs: func(mockRepository *repository.MockRepository, _ *faucet.MockFaucet) {
+			mockRepository.EXPECT().GetValidator(uint64(1000)).Return(nil, nil)
+		},
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) != 0 {
+				t.Errorf("expected no errors, got: %s", apiRes.Errors[0].Message)
+			}
+			if string(apiRes.Data) != `{"validator":null}` {
+				t.Errorf("expected null validator, got %s", apiRes.Data)
+			}
+		},
+	}
+}
+
+// getEpochTestCase returns a test case for epoch query.
+func getEpochTestCase(_ *testing.T) apiTestCase {
+	epoch := types.Epoch{
+		Number:       12_345,
+		EndTime:      1_700_000_000,
+		Duration:     310,
+		EpochFee:     hexutil.Big(*big.NewInt(7_000)),
+		TotalStake:   hexutil.Big(*big.NewInt(2_000_000)),
+		TotalSupply:  hexutil.Big(*big.NewInt(3_000_000)),
+		ValidatorIds: []hexutil.Uint64{1, 2, 3},
+	}
+	return apiTestCase{
+		testName:    "GetEpoch",
+		requestBody: `{"query": "query { epoch(number: 12345) { number, endTime, duration, epochFee, baseRewardPerSecond, totalStake, totalSupply, validatorIds } }"}`,
+		buildStubs: func(mockRepository *repository.MockRepository, _ *faucet.MockFaucet) {
+			number := uint64(12_345)
+			mockRepository.EXPECT().GetEpoch(&number).Return(&epoch, nil)
+		},
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) != 0 {
+				t.Errorf("expected no errors, got: %s", apiRes.Errors[0].Message)
+			}
+			// decode raw data into response
+			epochRes := struct {
+				Epoch types.Epoch `json:"epoch"`
+			}{}
+			if err := json.Unmarshal(apiRes.Data, &epochRes); err != nil {
+				t.Errorf("failed to unmarshall data: %v", err)
+			}
+			// validate epoch
+			res := epochRes.Epoch
+			if res.Number != epoch.Number || res.EndTime != epoch.EndTime || res.Duration != epoch.Duration ||
+				res.EpochFee.ToInt().Cmp(epoch.EpochFee.ToInt()) != 0 || len(res.ValidatorIds) != len(epoch.ValidatorIds) {
+				t.Errorf("expected epoch %+v, got %+v", epoch, res)
+			}
+		},
+	}
+}
+
+// getEpochsTestCase returns a test case for epochs query.
+func getEpochsTestCase(_ *testing.T) apiTestCase {
+	stats := []types.EpochStats{
+		{Epoch: 99, Blocks: 300, Transactions: 1_200, GasUsed: 25_000_000, StartTime: 1_700_000_300, EndTime: 1_700_000_598, Duration: 300, AvgTtf: 1.234},
+		{Epoch: 98, Blocks: 310, Transactions: 1_100, GasUsed: 24_000_000, StartTime: 1_700_000_001, EndTime: 1_700_000_298, Duration: 298},
+	}
+	return apiTestCase{
+		testName:    "GetEpochs",
+		requestBody: `{"query": "query { epochs(cursor: \"0x64\", count: 2) { items { epoch, blocks, transactions, gasUsed, startTime, endTime, duration, avgTimeToFinality }, next } }"}`,
+		buildStubs: func(mockRepository *repository.MockRepository, _ *faucet.MockFaucet) {
+			before := uint64(100)
+			mockRepository.EXPECT().GetEpochStats(&before, uint(2)).Return(stats, nil)
+		},
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) != 0 {
+				t.Errorf("expected no errors, got: %s", apiRes.Errors[0].Message)
+			}
+			// decode raw data into response
+			epochsRes := struct {
+				Epochs struct {
+					Items []struct {
+						types.EpochStats
+						AvgTimeToFinality float64 `json:"avgTimeToFinality"`
+					} `json:"items"`
+					Next *string `json:"next"`
+				} `json:"epochs"`
+			}{}
+			if err := json.Unmarshal(apiRes.Data, &epochsRes); err != nil {
+				t.Errorf("failed to unmarshall data: %v", err)
+			}
+			// validate epochs
+			res := epochsRes.Epochs
+			if len(res.Items) != len(stats) {
+				t.Fatalf("expected %d epochs, got %d", len(stats), len(res.Items))
+			}
+			for i, item := range res.Items {
+				expected := stats[i]
+				if item.Epoch != expected.Epoch || item.Blocks != expected.Blocks || item.Transactions != expected.Transactions ||
+					item.GasUsed != expected.GasUsed || item.Duration != expected.Duration {
+					t.Errorf("expected epoch stats %+v, got %+v", expected, item.EpochStats)
+				}
+			}
+			if res.Items[0].AvgTimeToFinality != 1.23 {
+				t.Errorf("expected average time to finality 1.23, got %v", res.Items[0].AvgTimeToFinality)
+			}
+			// the full page is followed by the older epochs
+			if res.Next == nil || *res.Next != "0x62" {
+				t.Errorf("expected next cursor 0x62, got %v", res.Next)
+			}
+		},
+	}
+}
+
+// getAccountDelegationsTestCase returns a test case for account delegations query.
+func getAccountDelegationsTestCase(_ *testing.T) apiTestCase {
+	addr := common.HexToAddress("0x5")
+	delegations := []types.Delegation{
+		{
+			Delegator:      addr,
+			ValidatorId:    1,
+			Amount:         hexutil.Big(*big.NewInt(1_000_000)),
+			PendingRewards: hexutil.Big(*big.NewInt(1_500)),
+			Lockup: &types.Lockup{
+				LockedStake: hexutil.Big(*big.NewInt(500_000)),
+				FromEpoch:   100,
+				EndTime:     1_700_000_000,
+				Duration:    86_400,
+			},
+		},
+		{
+			Delegator:      addr,
+			ValidatorId:    4,
+			PendingRewards: hexutil.Big(*big.NewInt(300)),
+			StashedRewards: hexutil.Big(*big.NewInt(300)),
+		},
+	}
+	rewards := types.StakingRewards{
+		Pending: hexutil.Big(*big.NewInt(1_800)),
+		Stashed: hexutil.Big(*big.NewInt(300)),
+	}
+	return apiTestCase{
+		testName: "GetAccountDelegations",
+		requestBody: `{"query": "query { account(address: \"0x0000000000000000000000000000000000000005\") { ` +
+			`delegations { validatorId, amount, pendingRewards, stashedRewards, lockup { lockedStake, fromEpoch, endTime, duration } }, ` +
+			`stakingRewards { pending, stashed } } }"}`,
+		buildStubs: func(mockRepository *repository.MockRepository, _ *faucet.MockFaucet) {
+			mockRepository.EXPECT().GetDelegations(addr).Return(delegations, nil)
+			mockRepository.EXPECT().GetStakingRewards(addr).Return(&rewards, nil)
+		},
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) != 0 {
+				t.Errorf("expected no errors, got: %s", apiRes.Errors[0].Message)
+			}
+			// decode raw data into response
+			accountRes := struct {
+				Account struct {
+					Delegations    []types.Delegation   `json:"delegations"`
+					StakingRewards types.StakingRewards `json:"stakingRewards"`
+				} `json:"account"`
+			}{}
+			if err := json.Unmarshal(apiRes.Data, &accountRes); err != nil {
+				t.Errorf("failed to unmarshall data: %v", err)
+			}
+			// validate delegations
+			res := accountRes.Account
+			if len(res.Delegations) != len(delegations) {
+				t.Fatalf("expected %d delegations, got %d", len(delegations), len(res.Delegations))
+			}
+			for i, d := range res.Delegations {
+				expected := delegations[i]
+				if d.ValidatorId != expected.ValidatorId || d.Amount.ToInt().Cmp(expected.Amount.ToInt()) != 0 ||
+					d.PendingRewards.ToInt().Cmp(expected.PendingRewards.ToInt()) != 0 {
+					t.Errorf("expected delegation %+v, got %+v", expected, d)
+				}
+				if (d.Lockup == nil) != (expected.Lockup == nil) || (d.Lockup != nil &&
+					(d.Lockup.LockedStake.ToInt().Cmp(expected.Lockup.LockedStake.ToInt()) != 0 || d.Lockup.EndTime != expected.Lockup.EndTime)) {
+					t.Errorf("expected lockup %+v, got %+v", expected.Lockup, d.Lockup)
+				}
+			}
+			// validate staking rewards
+			if res.StakingRewards.Pending.ToInt().Cmp(rewards.Pending.ToInt()) != 0 ||
+				res.StakingRewards.Stashed.ToInt().Cmp(rewards.Stashed.ToInt()) != 0 {
+				t.Errorf("expected staking rewards %+v, got %+v", rewards, res.StakingRewards)
+			}
+		},
+	}
+}
+
 // getDiskSizePer100MTxsTestCase returns a test case for a disk size per 100M transactions query.
 func getDiskSizePer100MTxsTestCase(_ *testing.T) apiTestCase {
 	var number uint64 = 54_494_722_457
@@ -564,6 +1278,89 @@ func getIsIdleOverrideTestCase(_ *testing.T) apiTestCase {
 	}
 }
 
+// getGasPriceTestCase returns a test case for gas price query.
+func getGasPriceTestCase(_ *testing.T) apiTestCase {
+	gp := types.GasPrice{
+		SafeLow:     hexutil.Big(*big.NewInt(1_000)),
+		Average:     hexutil.Big(*big.NewInt(2_000)),
+		Fast:        hexutil.Big(*big.NewInt(3_000)),
+		Fastest:     hexutil.Big(*big.NewInt(4_000)),
+		BaseFee:     (*hexutil.Big)(big.NewInt(900)),
+		BlockNumber: 120,
+	}
+	return apiTestCase{
+		testName:    "GetGasPrice",
+		requestBody: `{"query": "query { gasPrice { safeLow, average, fast, fastest, baseFee, priorityFee, blockNumber } }"}`,
+		buildStubs: func(mockRepository *repository.MockRepository, _ *faucet.MockFaucet) {
+			mockRepository.EXPECT().GetGasPrice().Return(&gp, nil)
+		},
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) != 0 {
+				t.Errorf("expected no errors, got: %s", apiRes.Errors[0].Message)
+			}
+			// decode raw data into response
+			gasRes := struct {
+				GasPrice types.GasPrice `json:"gasPrice"`
+			}{}
+			if err := json.Unmarshal(apiRes.Data, &gasRes); err != nil {
+				t.Errorf("failed to unmarshall data: %v", err)
+			}
+			// validate gas price
+			res := gasRes.GasPrice
+			if res.SafeLow.ToInt().Cmp(gp.SafeLow.ToInt()) != 0 || res.Fastest.ToInt().Cmp(gp.Fastest.ToInt()) != 0 {
+				t.Errorf("expected gas prices %+v, got %+v", gp, res)
+			}
+			if res.BaseFee == nil || res.BaseFee.ToInt().Cmp(gp.BaseFee.ToInt()) != 0 || res.PriorityFee != nil || res.BlockNumber != gp.BlockNumber {
+				t.Errorf("expected base fee %s without priority fee, got %+v", gp.BaseFee, res)
+			}
+		},
+	}
+}
+
+// getTopGasConsumersTestCase returns a test case for top gas consumers query.
+func getTopGasConsumersTestCase(_ *testing.T) apiTestCase {
+	usage := []types.GasUsage{
+		{Contract: common.HexToAddress("0x1"), Selector: "0xa9059cbb", GasUsed: 1_200_000, Calls: 24},
+		{Contract: common.HexToAddress("0x2"), Selector: "0x", GasUsed: 420_000, Calls: 20},
+	}
+	return apiTestCase{
+		testName:    "GetTopGasConsumers",
+		requestBody: `{"query": "query { topGasConsumers(resolution: HOUR, window: 24, limit: 10) { contract, selector, gasUsed, calls } }"}`,
+		buildStubs: func(mockRepository *repository.MockRepository, _ *faucet.MockFaucet) {
+			mockRepository.EXPECT().GetTopGasConsumers(types.AggResolutionHour, uint(24), uint(10)).Return(usage, nil)
+		},
+		checkResponse: func(t *testing.T, resp *http.Response) {
+			apiRes := decodeResponse(t, resp)
+			if len(apiRes.Errors) != 0 {
+				t.Errorf("expected no errors, got: %s", apiRes.Errors[0].Message)
+			}
+			// decode raw data into response
+			consumersRes := struct {
+				TopGasConsumers []struct {
+					Contract common.Address `json:"contract"`
+					Selector string         `json:"selector"`
+					GasUsed  hexutil.Uint64 `json:"gasUsed"`
+					Calls    hexutil.Uint64 `json:"calls"`
+				} `json:"topGasConsumers"`
+			}{}
+			if err := json.Unmarshal(apiRes.Data, &consumersRes); err != nil {
+				t.Errorf("failed to unmarshall data: %v", err)
+			}
+			// validate gas consumers
+			if len(consumersRes.TopGasConsumers) != len(usage) {
+				t.Fatalf("expected %d gas consumers, got %d", len(usage), len(consumersRes.TopGasConsumers))
+			}
+			for i, res := range consumersRes.TopGasConsumers {
+				if res.Contract != usage[i].Contract || res.Selector != usage[i].Selector ||
+					uint64(res.GasUsed) != usage[i].GasUsed || uint64(res.Calls) != usage[i].Calls {
+					t.Errorf("expected gas consumer %+v, got %+v", usage[i], res)
+				}
+			}
+		},
+	}
+}
+
 // getNumberOfTransactionsTestCase returns a test case for a number of transactions query.
 func getNumberOfTransactionsTestCase(_ *testing.T) apiTestCase {
 	var number uint64 = 12_852_456
@@ -604,9 +1401,10 @@ func getCurrentStateTestCase(_ *testing.T) apiTestCase {
 	var timeToFinality = 1.2
 	var timeToBlock = 2.2
 	var isIdle = false
+	cacheStats := types.CacheStats{Hits: 120, Misses: 30, Size: 95}
 	return apiTestCase{
 		testName:    "GetCurrentState",
-		requestBody: `{"query": "query { state { currentBlockHeight, numberOfAccounts, numberOfTransactions, numberOfValidators, diskSizePer100MTxs, diskSizePrunedPer100MTxs, timeToFinality, timeToBlock, isIdle } }"}`,
+		requestBody: `{"query": "query { state { currentBlockHeight, numberOfAccounts, numberOfTransactions, numberOfValidators, diskSizePer100MTxs, diskSizePrunedPer100MTxs, timeToFinality, timeToBlock, isIdle, transactionCache { hits, misses, size } } }"}`,
 		buildStubs: func(mockRepository *repository.MockRepository, _ *faucet.MockFaucet) {
 			mockRepository.EXPECT().GetLatestObservedBlock().Return(&types.Block{Number: hexutil.Uint64(blockHeight)})
 			mockRepository.EXPECT().GetNumberOfAccounts().Return(numberOfAccounts)
@@ -618,6 +1416,7 @@ func getCurrentStateTestCase(_ *testing.T) apiTestCase {
 			mockRepository.EXPECT().IsIdleOverride().Return(false)
 			mockRepository.EXPECT().GetDiskSizePer100MTxs().Return(diskSizePer100MTxs)
 			mockRepository.EXPECT().GetDiskSizePrunedPer100MTxs().Return(diskSizePrunedPer100MTxs)
+			mockRepository.EXPECT().GetTransactionCacheStats().Return(cacheStats)
 		},
 		checkResponse: func(t *testing.T, resp *http.Response) {
 			apiRes := decodeResponse(t, resp)
@@ -636,6 +1435,11 @@ func getCurrentStateTestCase(_ *testing.T) apiTestCase {
 					TimeToFinality           float64        `json:"timeToFinality"`
 					TimeToBlock              float64        `json:"timeToBlock"`
 					IsIdle                   bool           `json:"isIdle"`
+					TransactionCache         struct {
+						Hits   hexutil.Uint64 `json:"hits"`
+						Misses hexutil.Uint64 `json:"misses"`
+						Size   hexutil.Uint64 `json:"size"`
+					} `json:"transactionCache"`
 				}
 			}{}
 			if err := json.Unmarshal(apiRes.Data, &stateRes); err != nil {
@@ -669,6 +1473,10 @@ func getCurrentStateTestCase(_ *testing.T) apiTestCase {
 			if stateRes.State.IsIdle != isIdle {
 				t.Errorf("expected is idle %v, got %v", isIdle, stateRes.State.IsIdle)
 			}
+			cache := stateRes.State.TransactionCache
+			if uint64(cache.Hits) != cacheStats.Hits || uint64(cache.Misses) != cacheStats.Misses || uint(cache.Size) != cacheStats.Size {
+				t.Errorf("expected transaction cache stats %+v, got %+v", cacheStats, cache)
+			}
 		},
 	}
 }
@@ -680,7 +1488,7 @@ func getRequestTokensTestCase(_ *testing.T) apiTestCase {
 		testName:    "RequestTokens",
 		requestBody: `{"query": "mutation { requestTokens }"}`,
 		buildStubs: func(_ *repository.MockRepository, mockFaucet *faucet.MockFaucet) {
-			mockFaucet.EXPECT().RequestTokens(gomock.Any(), gomock.Nil()).Return(phrase, nil)
+			mockFaucet.EXPECT().RequestTokens(gomock.Any(), gomock.Nil(), "").Return(phrase, nil)
 		},
 		checkResponse: func(t *testing.T, resp *http.Response) {
 			apiRes := decodeResponse(t, resp)
@@ -745,6 +1553,16 @@ type apiResponse struct {
 	} `json:"errors"`
 }
 
+// testTrustedProxies returns trusted proxies of the test server, which is accessed from loopback.
+func testTrustedProxies(t *testing.T) middlewares.TrustedProxies {
+	t.Helper()
+	proxies, err := middlewares.ParseTrustedProxies([]string{"127.0.0.1/32", "::1/128"})
+	if err != nil {
+		t.Fatalf("failed to parse trusted proxies: %v", err)
+	}
+	return proxies
+}
+
 // decodeResponse decodes the response body into the given value.
 // If the response contains errors, they will be returned as a slice of strings.
 func decodeResponse(t *testing.T, resp *http.Response) apiResponse {
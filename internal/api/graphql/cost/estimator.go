@@ -0,0 +1,185 @@
+package cost
+
+import (
+	"math"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// kDefaultFieldCost represents the cost of a field without configured weight.
+const kDefaultFieldCost = 1
+
+// Estimator estimates the cost of GraphQL queries before they are executed.
+//
+// The cost of a field is its weight multiplied by the cost of its selection set
+// (at least 1), so the weight of a list field applies to every nested field
+// resolved for each of its items. The cost of a selection set is the sum of the
+// costs of its fields. Introspection fields are free.
+type Estimator struct {
+	schema *types.Schema
+	// weights maps lower-cased "type.field" to the field weight
+	weights map[string]int
+}
+
+// NewEstimator creates a new cost estimator for the given schema.
+// Field weights are keyed by the type name and then by the field name,
+// e.g. weights["Block"]["fullTransactions"]. Names are case-insensitive;
+// fields without weight cost 1.
+func NewEstimator(schema *types.Schema, weights map[string]map[string]int) *Estimator {
+	e := &Estimator{
+		schema:  schema,
+		weights: make(map[string]int),
+	}
+	for typeName, fields := range weights {
+		for fieldName, weight := range fields {
+			if weight < 0 {
+				weight = 0
+			}
+			e.weights[strings.ToLower(typeName+"."+fieldName)] = weight
+		}
+	}
+	return e
+}
+
+// Estimate returns the cost of the given parsed query. If the operation name is empty,
+// the cost of the most expensive operation in the query is returned.
+func (e *Estimator) Estimate(doc *Document, operationName string) int {
+	est := estimation{
+		estimator: e,
+		doc:       doc,
+		fragments: make(map[string]int),
+		visiting:  make(map[string]bool),
+	}
+
+	total := 0
+	for _, op := range doc.operations {
+		if operationName != "" && op.name != operationName {
+			continue
+		}
+		rootType := ""
+		if t, ok := e.schema.EntryPoints[op.kind]; ok {
+			rootType = t.TypeName()
+		}
+		if c := est.selectionsCost(op.selections, rootType); c > total {
+			total = c
+		}
+	}
+
+	return total
+}
+
+// weight returns the weight of the given field of the given type.
+func (e *Estimator) weight(typeName string, fieldName string) int {
+	if w, ok := e.weights[strings.ToLower(typeName+"."+fieldName)]; ok {
+		return w
+	}
+	return kDefaultFieldCost
+}
+
+// fieldType returns the name of the type of the given field of the given type.
+// It returns an empty string if the field is not known.
+func (e *Estimator) fieldType(typeName string, fieldName string) string {
+	var fields types.FieldsDefinition
+	switch t := e.schema.Types[typeName].(type) {
+	case *types.ObjectTypeDefinition:
+		fields = t.Fields
+	case *types.InterfaceTypeDefinition:
+		fields = t.Fields
+	default:
+		return ""
+	}
+
+	field := fields.Get(fieldName)
+	if field == nil {
+		return ""
+	}
+
+	// unwrap list and non-null types
+	ft := field.Type
+	for {
+		switch t := ft.(type) {
+		case *types.List:
+			ft = t.OfType
+		case *types.NonNull:
+			ft = t.OfType
+		case types.NamedType:
+			return t.TypeName()
+		default:
+			return ""
+		}
+	}
+}
+
+// estimation represents a state of a single query cost estimation.
+type estimation struct {
+	estimator *Estimator
+	doc       *Document
+	// fragments caches costs of already estimated fragments
+	fragments map[string]int
+	// visiting marks fragments being estimated to break fragment cycles
+	visiting map[string]bool
+}
+
+// selectionsCost returns the cost of the given selections of the given type.
+func (est *estimation) selectionsCost(sels []selection, typeName string) int {
+	total := 0
+	for _, sel := range sels {
+		switch {
+		case sel.isSpread:
+			total = addCost(total, est.fragmentCost(sel.name))
+		case sel.isInline:
+			inlineType := typeName
+			if sel.typeCondition != "" {
+				inlineType = sel.typeCondition
+			}
+			total = addCost(total, est.selectionsCost(sel.selections, inlineType))
+		case strings.HasPrefix(sel.name, "__"):
+			// introspection is resolved from the schema, it does not touch any data
+			continue
+		default:
+			children := est.selectionsCost(sel.selections, est.estimator.fieldType(typeName, sel.name))
+			if children < 1 {
+				children = 1
+			}
+			total = addCost(total, mulCost(est.estimator.weight(typeName, sel.name), children))
+		}
+	}
+	return total
+}
+
+// fragmentCost returns the cost of the fragment with the given name.
+func (est *estimation) fragmentCost(name string) int {
+	if c, ok := est.fragments[name]; ok {
+		return c
+	}
+
+	// unknown and cyclic fragments are rejected by the query validation
+	frag, ok := est.doc.fragments[name]
+	if !ok || est.visiting[name] {
+		return 0
+	}
+
+	est.visiting[name] = true
+	c := est.selectionsCost(frag.selections, frag.typeCondition)
+	delete(est.visiting, name)
+
+	est.fragments[name] = c
+	return c
+}
+
+// addCost adds two costs, saturating at the maximum int value.
+func addCost(a int, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// mulCost multiplies two non-negative costs, saturating at the maximum int value.
+func mulCost(a int, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
@@ -0,0 +1,82 @@
+package cost
+
+import (
+	"ftm-explorer/internal/api/graphql/schema"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Test that query costs are estimated using field weights.
+func TestEstimator_Estimate(t *testing.T) {
+	est := createEstimator(t)
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{"LeafFields", `{ numberOfAccounts timeToFinality }`, 2},
+		{"NestedFields", `query { block(number: "0x1") { number, hash } }`, 2},
+		{"WeightedList", `{ block(number: "0x1") { fullTransactions { hash, from } } }`, 100},
+		{"NestedFanOut", `{ recentBlocks(limit: 10) { fullTransactions { hash, block { fullTransactions { hash } } } } }`, 10 * 50 * (1 + 50)},
+		{"Aliases", `{ a: block(number: "0x1") { number } b: block(number: "0x2") { number } }`, 2},
+		{"Fragments", `{ block(number: "0x1") { ...blk } } fragment blk on Block { fullTransactions { hash } }`, 50},
+		{"InlineFragments", `{ block(number: "0x1") { ... on Block { fullTransactions { hash } } } }`, 50},
+		{"Introspection", `{ __schema { types { name fields { name } } } __typename }`, 0},
+		{"Arguments", `query q($n: Long! = "0x1") @skip(if: false) { block(number: $n) @include(if: true) { number } }`, 1},
+		{"Strings", `mutation { claimTokens(address: "0x0", challenge: """a "} b""", signature: "\"}") }`, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if cost := est.Estimate(mustParse(t, tc.query), ""); cost != tc.expected {
+				t.Errorf("expected cost %d, got %d", tc.expected, cost)
+			}
+		})
+	}
+}
+
+// Test that the cost of the selected operation is returned.
+func TestEstimator_EstimateOperation(t *testing.T) {
+	est := createEstimator(t)
+	query := `query cheap { numberOfAccounts } query expensive { block(number: "0x1") { fullTransactions { hash } } }`
+
+	doc := mustParse(t, query)
+
+	if cost := est.Estimate(doc, "cheap"); cost != 1 {
+		t.Errorf("expected cost 1, got %d", cost)
+	}
+	if cost := est.Estimate(doc, ""); cost != 50 {
+		t.Errorf("expected cost 50, got %d", cost)
+	}
+}
+
+// Test that invalid queries are rejected by the parser.
+func TestParse_InvalidQuery(t *testing.T) {
+	for _, query := range []string{`{ block(number: "0x1") { number }`, `{ block(number: "0x1 }`, `{ ? }`, `fragment on { a }`} {
+		if _, err := Parse(query); err == nil {
+			t.Errorf("expected error for query %s", query)
+		}
+	}
+}
+
+// mustParse parses the given query or fails the test.
+func mustParse(t *testing.T, query string) *Document {
+	t.Helper()
+	doc, err := Parse(query)
+	if err != nil {
+		t.Fatalf("failed to parse query %s: %v", query, err)
+	}
+	return doc
+}
+
+// createEstimator creates an estimator for the API schema with test weights.
+func createEstimator(t *testing.T) *Estimator {
+	t.Helper()
+	s := graphql.MustParseSchema(schema.Schema(), nil, graphql.UseFieldResolvers())
+	return NewEstimator(s.ASTSchema(), map[string]map[string]int{
+		"query": {"recentblocks": 10},
+		"Block": {"fullTransactions": 50},
+	})
+}
@@ -0,0 +1,122 @@
+package cost
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind represents a kind of GraphQL lexical token.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokValue
+)
+
+// token represents a GraphQL lexical token.
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// is returns true if the token is of the given kind and value.
+func (t token) is(kind tokenKind, value string) bool {
+	return t.kind == kind && t.value == value
+}
+
+// tokenize splits the given GraphQL query into tokens.
+// Whitespaces, commas and comments are skipped, the last token is always EOF.
+func tokenize(src string) ([]token, error) {
+	tokens := make([]token, 0)
+	pos := 0
+
+	for pos < len(src) {
+		c := src[pos]
+		switch {
+		// insignificant characters
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			pos++
+		case strings.HasPrefix(src[pos:], "\ufeff"):
+			pos += len("\ufeff")
+		case c == '#':
+			for pos < len(src) && src[pos] != '\n' && src[pos] != '\r' {
+				pos++
+			}
+
+		// punctuators
+		case strings.HasPrefix(src[pos:], "..."):
+			tokens = append(tokens, token{kind: tokPunct, value: "..."})
+			pos += 3
+		case strings.IndexByte("!$&():=@[]{|}", c) >= 0:
+			tokens = append(tokens, token{kind: tokPunct, value: string(c)})
+			pos++
+
+		// names
+		case isNameStart(c):
+			start := pos
+			for pos < len(src) && (isNameStart(src[pos]) || isDigit(src[pos])) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokName, value: src[start:pos]})
+
+		// numbers
+		case c == '-' || isDigit(c):
+			start := pos
+			pos++
+			for pos < len(src) && (isDigit(src[pos]) || strings.IndexByte(".eE+-", src[pos]) >= 0) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokValue, value: src[start:pos]})
+
+		// block strings
+		case strings.HasPrefix(src[pos:], `"""`):
+			end := pos + 3
+			for {
+				ix := strings.Index(src[end:], `"""`)
+				if ix < 0 {
+					return nil, fmt.Errorf("unterminated string")
+				}
+				end += ix
+				// escaped triple quote does not terminate the string
+				if src[end-1] != '\\' {
+					break
+				}
+				end += 3
+			}
+			tokens = append(tokens, token{kind: tokValue, value: src[pos : end+3]})
+			pos = end + 3
+
+		// strings
+		case c == '"':
+			end := pos + 1
+			for end < len(src) && src[end] != '"' {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(src) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokValue, value: src[pos : end+1]})
+			pos = end + 1
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF}), nil
+}
+
+// isNameStart returns true if the given character can start a GraphQL name.
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isDigit returns true if the given character is a decimal digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
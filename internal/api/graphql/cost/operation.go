@@ -0,0 +1,24 @@
+package cost
+
+import (
+	"fmt"
+)
+
+// OperationType returns the type of the operation to be executed by the given parsed query,
+// i.e. "query", "mutation" or "subscription". If the operation name is empty,
+// the query must contain a single operation.
+func OperationType(doc *Document, operationName string) (string, error) {
+	if operationName == "" {
+		if len(doc.operations) != 1 {
+			return "", fmt.Errorf("operation name is required for %d operations", len(doc.operations))
+		}
+		return doc.operations[0].kind, nil
+	}
+
+	for _, op := range doc.operations {
+		if op.name == operationName {
+			return op.kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown operation %q", operationName)
+}
@@ -0,0 +1,40 @@
+package cost
+
+import (
+	"testing"
+)
+
+// Test that the type of the executed operation is recognized.
+func TestOperationType(t *testing.T) {
+	testCases := []struct {
+		query         string
+		operationName string
+		expected      string
+	}{
+		{`{ numberOfAccounts }`, "", "query"},
+		{`query { numberOfAccounts }`, "", "query"},
+		{`# comment
+		mutation { requestTokens }`, "", "mutation"},
+		{`query a { numberOfAccounts } mutation b { requestTokens }`, "b", "mutation"},
+		{`subscription s { onBlock { number } }`, "s", "subscription"},
+	}
+
+	for _, tc := range testCases {
+		kind, err := OperationType(mustParse(t, tc.query), tc.operationName)
+		if err != nil {
+			t.Errorf("unexpected error for query %s: %v", tc.query, err)
+			continue
+		}
+		if kind != tc.expected {
+			t.Errorf("expected %s, got %s for query %s", tc.expected, kind, tc.query)
+		}
+	}
+
+	// ambiguous and unknown operations are rejected
+	if _, err := OperationType(mustParse(t, `query a { x } mutation b { y }`), ""); err == nil {
+		t.Errorf("expected error for ambiguous operation")
+	}
+	if _, err := OperationType(mustParse(t, `query a { x }`), "b"); err == nil {
+		t.Errorf("expected error for unknown operation")
+	}
+}
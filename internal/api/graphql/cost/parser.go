@@ -0,0 +1,286 @@
+package cost
+
+import (
+	"fmt"
+)
+
+// kMaxNesting represents the maximum nesting of selection sets the parser accepts.
+const kMaxNesting = 256
+
+// Document represents the parts of a GraphQL executable document relevant for the cost estimation.
+type Document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// operation represents a GraphQL operation definition.
+type operation struct {
+	kind       string
+	name       string
+	selections []selection
+}
+
+// fragment represents a GraphQL fragment definition.
+type fragment struct {
+	name          string
+	typeCondition string
+	selections    []selection
+}
+
+// selection represents a field, an inline fragment or a fragment spread.
+type selection struct {
+	// name is the name of the field or of the spread fragment
+	name string
+	// typeCondition is the type condition of an inline fragment
+	typeCondition string
+	// isSpread is true if the selection is a fragment spread
+	isSpread bool
+	// isInline is true if the selection is an inline fragment
+	isInline bool
+	// selections are the nested selections of a field or an inline fragment
+	selections []selection
+}
+
+// parser represents a minimal GraphQL query parser. It only keeps the structure of
+// the selection sets, arguments, variables and directives are skipped. The query
+// is validated by the GraphQL engine itself before it is executed.
+type parser struct {
+	tokens  []token
+	pos     int
+	nesting int
+}
+
+// Parse parses the given GraphQL query into a document.
+func Parse(query string) (*Document, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	doc := &Document{fragments: make(map[string]*fragment)}
+	for p.peek().kind != tokEOF {
+		switch t := p.peek(); {
+		case t.is(tokPunct, "{"):
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: sels})
+		case t.is(tokName, "query"), t.is(tokName, "mutation"), t.is(tokName, "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case t.is(tokName, "fragment"):
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, fmt.Errorf("unexpected %q", t.value)
+		}
+	}
+
+	return doc, nil
+}
+
+// operation parses an operation definition.
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: p.advance().value}
+	if p.peek().kind == tokName {
+		op.name = p.advance().value
+	}
+	if p.peek().is(tokPunct, "(") {
+		if err := p.skipBalanced("(", ")"); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = sels
+	return op, nil
+}
+
+// fragment parses a fragment definition.
+func (p *parser) fragment() (*fragment, error) {
+	p.advance()
+	name, err := p.expect(tokName, "")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokName, "on"); err != nil {
+		return nil, err
+	}
+	typeCondition, err := p.expect(tokName, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &fragment{name: name, typeCondition: typeCondition, selections: sels}, nil
+}
+
+// selectionSet parses a selection set enclosed in braces.
+func (p *parser) selectionSet() ([]selection, error) {
+	if _, err := p.expect(tokPunct, "{"); err != nil {
+		return nil, err
+	}
+
+	p.nesting++
+	defer func() { p.nesting-- }()
+	if p.nesting > kMaxNesting {
+		return nil, fmt.Errorf("query is nested too deeply")
+	}
+
+	sels := make([]selection, 0)
+	for !p.peek().is(tokPunct, "}") {
+		if p.peek().kind == tokEOF {
+			return nil, fmt.Errorf("unexpected end of query")
+		}
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	p.advance()
+
+	return sels, nil
+}
+
+// selection parses a single field, inline fragment or fragment spread.
+func (p *parser) selection() (selection, error) {
+	var sel selection
+
+	// fragment spread or inline fragment
+	if p.peek().is(tokPunct, "...") {
+		p.advance()
+		if p.peek().kind == tokName && !p.peek().is(tokName, "on") {
+			sel.isSpread = true
+			sel.name = p.advance().value
+			return sel, p.skipDirectives()
+		}
+
+		sel.isInline = true
+		if p.peek().is(tokName, "on") {
+			p.advance()
+			typeCondition, err := p.expect(tokName, "")
+			if err != nil {
+				return sel, err
+			}
+			sel.typeCondition = typeCondition
+		}
+		if err := p.skipDirectives(); err != nil {
+			return sel, err
+		}
+		sels, err := p.selectionSet()
+		sel.selections = sels
+		return sel, err
+	}
+
+	// field with an optional alias
+	name, err := p.expect(tokName, "")
+	if err != nil {
+		return sel, err
+	}
+	if p.peek().is(tokPunct, ":") {
+		p.advance()
+		if name, err = p.expect(tokName, ""); err != nil {
+			return sel, err
+		}
+	}
+	sel.name = name
+
+	if p.peek().is(tokPunct, "(") {
+		if err := p.skipBalanced("(", ")"); err != nil {
+			return sel, err
+		}
+	}
+	if err := p.skipDirectives(); err != nil {
+		return sel, err
+	}
+	if p.peek().is(tokPunct, "{") {
+		sels, err := p.selectionSet()
+		sel.selections = sels
+		return sel, err
+	}
+
+	return sel, nil
+}
+
+// skipDirectives skips any directives at the current position.
+func (p *parser) skipDirectives() error {
+	for p.peek().is(tokPunct, "@") {
+		p.advance()
+		if _, err := p.expect(tokName, ""); err != nil {
+			return err
+		}
+		if p.peek().is(tokPunct, "(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// skipBalanced skips tokens enclosed in the given balanced punctuators.
+func (p *parser) skipBalanced(open string, close string) error {
+	depth := 0
+	for {
+		t := p.advance()
+		switch {
+		case t.kind == tokEOF:
+			return fmt.Errorf("unexpected end of query")
+		case t.is(tokPunct, open):
+			depth++
+		case t.is(tokPunct, close):
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// expect consumes the next token if it is of the given kind and value.
+// An empty value matches any token of the given kind. It returns the token value.
+func (p *parser) expect(kind tokenKind, value string) (string, error) {
+	t := p.peek()
+	if t.kind != kind || (value != "" && t.value != value) {
+		if t.kind == tokEOF {
+			return "", fmt.Errorf("unexpected end of query")
+		}
+		return "", fmt.Errorf("unexpected %q", t.value)
+	}
+	return p.advance().value, nil
+}
+
+// peek returns the current token without consuming it.
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// advance consumes and returns the current token. The EOF token is never consumed.
+func (p *parser) advance() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
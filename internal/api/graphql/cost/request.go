@@ -0,0 +1,53 @@
+package cost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// MaxRequestSize is the maximum size of the body of a GraphQL request in bytes.
+const MaxRequestSize = 1 << 20
+
+// requestCtxKey is the key of the parsed GraphQL request in the request context.
+type requestCtxKey struct{}
+
+// Request represents a GraphQL request decoded from the body of an HTTP request.
+type Request struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName"`
+
+	// Doc is the parsed query, it is nil if the query can not be parsed.
+	Doc *Document `json:"-"`
+	// ParseErr is the error of parsing a non-empty query, if any.
+	ParseErr error `json:"-"`
+}
+
+// ReadRequest returns the GraphQL request of the given HTTP request together with the HTTP request
+// carrying it in its context, so the body is read and parsed only once by the handlers chain.
+// The body is read regardless of the method, as the GraphQL handler executes the body of any request.
+// It is limited to MaxRequestSize and restored, so it can be read by the GraphQL handler.
+// Requests which are not valid JSON are returned without the query, they are left to be rejected
+// by the GraphQL handler.
+func ReadRequest(w http.ResponseWriter, r *http.Request) (*Request, *http.Request, error) {
+	if req, ok := r.Context().Value(requestCtxKey{}).(*Request); ok {
+		return req, r, nil
+	}
+
+	req := &Request{}
+	if r.Body != nil {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxRequestSize))
+		if err != nil {
+			return nil, r, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err := json.Unmarshal(body, req); err == nil && req.Query != "" {
+			req.Doc, req.ParseErr = Parse(req.Query)
+		}
+	}
+
+	return req, r.WithContext(context.WithValue(r.Context(), requestCtxKey{}, req)), nil
+}
@@ -0,0 +1,84 @@
+package cost
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test that the GraphQL request is read and parsed only once by the handlers chain.
+func TestReadRequest(t *testing.T) {
+	body := `{"query": "query q { numberOfAccounts }", "operationName": "q"}`
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	req, r, err := ReadRequest(w, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Query != "query q { numberOfAccounts }" || req.OperationName != "q" || req.Doc == nil || len(req.Doc.operations) != 1 {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+
+	// the body is restored for the GraphQL handler
+	restored, err := io.ReadAll(r.Body)
+	if err != nil || string(restored) != body {
+		t.Fatalf("expected restored body %s, got %s; %v", body, restored, err)
+	}
+
+	// the next handler gets the request from the context without reading the body again
+	next, _, err := ReadRequest(w, r)
+	if err != nil || next != req {
+		t.Fatalf("expected the request from the context, got %+v; %v", next, err)
+	}
+}
+
+// Test that the GraphQL request is read from the body of requests of any method.
+func TestReadRequest_AnyMethod(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		r := httptest.NewRequest(method, "/", strings.NewReader(`{"query": "mutation { requestTokens }"}`))
+		req, _, err := ReadRequest(httptest.NewRecorder(), r)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if req.Doc == nil {
+			t.Errorf("expected parsed query for method %s", method)
+		}
+	}
+}
+
+// Test that malformed requests are returned without the parsed query.
+func TestReadRequest_Malformed(t *testing.T) {
+	testCases := []struct {
+		body     string
+		parseErr bool
+	}{
+		{`not json`, false},
+		{`{"query": "{ numberOfAccounts"}`, true},
+	}
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+		req, _, err := ReadRequest(httptest.NewRecorder(), r)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if req.Doc != nil {
+			t.Errorf("expected no parsed query for body %s", tc.body)
+		}
+		if (req.ParseErr != nil) != tc.parseErr {
+			t.Errorf("expected parse error %v for body %s, got %v", tc.parseErr, tc.body, req.ParseErr)
+		}
+	}
+}
+
+// Test that too large request bodies are rejected.
+func TestReadRequest_TooLarge(t *testing.T) {
+	body := `{"query": "` + strings.Repeat(" ", MaxRequestSize) + `{ numberOfAccounts }"}`
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if _, _, err := ReadRequest(httptest.NewRecorder(), r); err == nil {
+		t.Fatalf("expected error for too large body")
+	}
+}
@@ -1,6 +1,8 @@
 package resolvers
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -17,8 +19,8 @@ func (rs *RootResolver) Account(args *struct{ Address common.Address }) Account
 }
 
 // Balance returns the balance of the account.
-func (acc Account) Balance() (hexutil.Big, error) {
-	val, err := acc.rs.repository.AccountBalance(acc.Address)
+func (acc Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	val, err := acc.rs.loaders(ctx).balances.load(acc.Address)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -26,19 +28,25 @@ func (acc Account) Balance() (hexutil.Big, error) {
 }
 
 // Transactions returns the transactions of the account.
-func (acc Account) Transactions() ([]*Transaction, error) {
-	// fetch 500 latest transactions
+func (acc Account) Transactions(ctx context.Context) ([]*Transaction, error) {
+	// fetch 250 latest transactions
 	txs, err := acc.rs.repository.GetLastTransactionsWhereAddress(acc.Address, 250)
 	if err != nil {
 		return nil, err
 	}
 
-	rv := make([]*Transaction, len(txs))
+	// load full transactions concurrently
+	hashes := make([]common.Hash, len(txs))
 	for i, tx := range txs {
-		t, err := acc.rs.repository.GetTransactionByHash(tx.Hash)
-		if err != nil {
-			return nil, err
-		}
+		hashes[i] = tx.Hash
+	}
+	trxs, err := acc.rs.loaders(ctx).transactions.loadMany(hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := make([]*Transaction, len(trxs))
+	for i, t := range trxs {
 		rv[i] = &Transaction{Transaction: *t, rs: acc.rs}
 	}
 
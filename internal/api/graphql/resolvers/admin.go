@@ -0,0 +1,19 @@
+package resolvers
+
+import (
+	"context"
+	"ftm-explorer/internal/auth"
+)
+
+// SetIdleOverride overrides the idle state of the blockchain. It requires the admin role.
+func (rs *RootResolver) SetIdleOverride(ctx context.Context, args struct {
+	Idle bool
+}) (bool, error) {
+	if err := auth.RequireRole(ctx, auth.RoleAdmin); err != nil {
+		return false, err
+	}
+
+	rs.log.Noticef("idle override set to %v by %s", args.Idle, auth.GetPrincipal(ctx).Name)
+	rs.repository.SetIsIdleOverride(args.Idle)
+	return args.Idle, nil
+}
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fmt"
 	"ftm-explorer/internal/types"
 
@@ -70,8 +71,8 @@ func (rs *RootResolver) CurrentBlockHeight() (*hexutil.Uint64, error) {
 }
 
 // Block resolves block by number.
-func (rs *RootResolver) Block(args *struct{ Number hexutil.Uint64 }) (*Block, error) {
-	block, err := rs.repository.GetBlockByNumber(uint64(args.Number))
+func (rs *RootResolver) Block(ctx context.Context, args *struct{ Number hexutil.Uint64 }) (*Block, error) {
+	block, err := rs.loaders(ctx).blocks.load(uint64(args.Number))
 	if err != nil {
 		rs.log.Warningf("Failed to get block by number [%d]; %v", args.Number, err)
 		return nil, err
@@ -89,7 +90,7 @@ func (blk *Block) TransactionsCount() int32 {
 }
 
 // FullTransactions resolves full transactions in the block.
-func (blk *Block) FullTransactions() ([]*Transaction, error) {
+func (blk *Block) FullTransactions(ctx context.Context) ([]*Transaction, error) {
 	result := make([]*Transaction, 0)
 
 	// we will use this slice to store indexes of transactions that will be on top of the list
@@ -98,8 +99,14 @@ func (blk *Block) FullTransactions() ([]*Transaction, error) {
 	// we will use this slice to store indexes of transactions those were marked as expensive
 	expensiveIndexes := make([]int, 0)
 
-	// fetch transactions
-	for ix, hash := range blk.Transactions {
+	// load transactions concurrently
+	trxs, err := blk.rs.loaders(ctx).transactions.loadMany(blk.Transactions)
+	if err != nil {
+		blk.rs.log.Warningf("Failed to get transactions of block [%d]; %v", blk.Number, err)
+		return nil, err
+	}
+
+	for ix, trx := range trxs {
 		// we use a "hack" to put very expensive transactions on top of the list
 		if ix == 0 {
 			// we use block number as seed for random number generator
@@ -116,12 +123,6 @@ func (blk *Block) FullTransactions() ([]*Transaction, error) {
 			}
 		}
 
-		trx, err := blk.rs.repository.GetTransactionByHash(hash)
-		if err != nil {
-			blk.rs.log.Warningf("Failed to get transaction by hash [%s]; %v", hash.Hex(), err)
-			return nil, err
-		}
-
 		// if gas used is greater or equal 1_000_000, we consider it expensive and put into reserved slot
 		if trx.GasUsed != nil && *trx.GasUsed >= 1_000_000 {
 			// mark index of expensive transaction
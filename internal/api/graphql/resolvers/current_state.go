@@ -11,6 +11,11 @@ type CurrentState struct {
 	rs *RootResolver
 }
 
+// CacheStats represents resolvable usage statistics of an in-memory cache.
+type CacheStats struct {
+	stats types.CacheStats
+}
+
 // TtfTick represents a time to finality tick.
 type TtfTick types.FloatTick
 
@@ -79,6 +84,11 @@ func (rs *RootResolver) IsIdle() bool {
 	return rs.repository.IsIdleOverride() || rs.repository.IsIdle()
 }
 
+// TransactionCache resolves usage statistics of the transactions cache.
+func (rs *RootResolver) TransactionCache() CacheStats {
+	return CacheStats{rs.repository.GetTransactionCacheStats()}
+}
+
 // CurrentBlockHeight resolves the current block height.
 func (cs CurrentState) CurrentBlockHeight() (*hexutil.Uint64, error) {
 	return cs.rs.CurrentBlockHeight()
@@ -124,6 +134,26 @@ func (cs CurrentState) IsIdle() bool {
 	return cs.rs.IsIdle()
 }
 
+// TransactionCache resolves usage statistics of the transactions cache.
+func (cs CurrentState) TransactionCache() CacheStats {
+	return cs.rs.TransactionCache()
+}
+
+// Hits resolves the number of lookups served from the cache.
+func (c CacheStats) Hits() hexutil.Uint64 {
+	return hexutil.Uint64(c.stats.Hits)
+}
+
+// Misses resolves the number of lookups not found in the cache.
+func (c CacheStats) Misses() hexutil.Uint64 {
+	return hexutil.Uint64(c.stats.Misses)
+}
+
+// Size resolves the number of entries in the cache.
+func (c CacheStats) Size() hexutil.Uint64 {
+	return hexutil.Uint64(c.stats.Size)
+}
+
 // Timestamp resolves tick timestamp.
 func (t TtfTick) Timestamp() int32 {
 	return int32(t.Time)
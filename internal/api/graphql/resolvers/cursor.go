@@ -0,0 +1,22 @@
+package resolvers
+
+import "fmt"
+
+// Cursor represents a position in a sequential list of edges.
+type Cursor string
+
+// ImplementsGraphQLType returns true if Cursor implements the specified GraphQL type.
+func (Cursor) ImplementsGraphQLType(name string) bool {
+	return name == "Cursor"
+}
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (c *Cursor) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		*c = Cursor(input)
+		return nil
+	default:
+		return fmt.Errorf("unexpected type %T for Cursor", input)
+	}
+}
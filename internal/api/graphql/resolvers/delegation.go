@@ -0,0 +1,111 @@
+package resolvers
+
+import (
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Delegation represents resolvable stake delegated by an account to a validator.
+type Delegation struct {
+	delegation types.Delegation
+	rs         *RootResolver
+}
+
+// Lockup represents resolvable locked part of a delegation.
+type Lockup struct {
+	lockup types.Lockup
+}
+
+// StakingRewards represents resolvable rewards of all the delegations of an account.
+type StakingRewards struct {
+	rewards types.StakingRewards
+}
+
+// Delegations resolves the delegations of the account to all the validators.
+func (acc Account) Delegations() ([]*Delegation, error) {
+	delegations, err := acc.rs.repository.GetDelegations(acc.Address)
+	if err != nil {
+		acc.rs.log.Errorf("failed to get delegations of %s: %v", acc.Address.Hex(), err)
+		return nil, err
+	}
+
+	rv := make([]*Delegation, len(delegations))
+	for i, d := range delegations {
+		rv[i] = &Delegation{delegation: d, rs: acc.rs}
+	}
+	return rv, nil
+}
+
+// StakingRewards resolves the rewards of all the delegations of the account.
+func (acc Account) StakingRewards() (*StakingRewards, error) {
+	rewards, err := acc.rs.repository.GetStakingRewards(acc.Address)
+	if err != nil {
+		acc.rs.log.Errorf("failed to get staking rewards of %s: %v", acc.Address.Hex(), err)
+		return nil, err
+	}
+	return &StakingRewards{*rewards}, nil
+}
+
+// ValidatorId resolves the id of the validator the stake is delegated to.
+func (d *Delegation) ValidatorId() hexutil.Uint64 {
+	return d.delegation.ValidatorId
+}
+
+// Validator resolves the validator the stake is delegated to.
+func (d *Delegation) Validator() (*Validator, error) {
+	return d.rs.Validator(struct{ Id hexutil.Uint64 }{Id: d.delegation.ValidatorId})
+}
+
+// Amount resolves the delegated amount.
+func (d *Delegation) Amount() hexutil.Big {
+	return d.delegation.Amount
+}
+
+// PendingRewards resolves the rewards which can be claimed.
+func (d *Delegation) PendingRewards() hexutil.Big {
+	return d.delegation.PendingRewards
+}
+
+// StashedRewards resolves the rewards already moved to the stash.
+func (d *Delegation) StashedRewards() hexutil.Big {
+	return d.delegation.StashedRewards
+}
+
+// Lockup resolves the lockup of the delegated stake.
+func (d *Delegation) Lockup() *Lockup {
+	if d.delegation.Lockup == nil {
+		return nil
+	}
+	return &Lockup{*d.delegation.Lockup}
+}
+
+// LockedStake resolves the locked amount.
+func (l *Lockup) LockedStake() hexutil.Big {
+	return l.lockup.LockedStake
+}
+
+// FromEpoch resolves the epoch the stake was locked in.
+func (l *Lockup) FromEpoch() hexutil.Uint64 {
+	return l.lockup.FromEpoch
+}
+
+// EndTime resolves the unix timestamp the lockup ends at.
+func (l *Lockup) EndTime() hexutil.Uint64 {
+	return l.lockup.EndTime
+}
+
+// Duration resolves the duration of the lockup in seconds.
+func (l *Lockup) Duration() hexutil.Uint64 {
+	return l.lockup.Duration
+}
+
+// Pending resolves the rewards which can be claimed.
+func (sr *StakingRewards) Pending() hexutil.Big {
+	return sr.rewards.Pending
+}
+
+// Stashed resolves the rewards already moved to the stash.
+func (sr *StakingRewards) Stashed() hexutil.Big {
+	return sr.rewards.Stashed
+}
@@ -0,0 +1,71 @@
+package resolvers
+
+import (
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Epoch represents resolvable sealed epoch.
+type Epoch struct {
+	epoch types.Epoch
+}
+
+// Epoch resolves the sealed epoch with the given number, or the latest sealed epoch.
+func (rs *RootResolver) Epoch(args struct{ Number *hexutil.Uint64 }) (*Epoch, error) {
+	var number *uint64
+	if args.Number != nil {
+		n := uint64(*args.Number)
+		number = &n
+	}
+
+	epoch, err := rs.repository.GetEpoch(number)
+	if err != nil {
+		rs.log.Errorf("failed to get epoch: %v", err)
+		return nil, err
+	}
+	if epoch == nil {
+		return nil, nil
+	}
+	return &Epoch{*epoch}, nil
+}
+
+// Number resolves the number of the epoch.
+func (e *Epoch) Number() hexutil.Uint64 {
+	return e.epoch.Number
+}
+
+// EndTime resolves the unix timestamp the epoch was sealed at.
+func (e *Epoch) EndTime() hexutil.Uint64 {
+	return e.epoch.EndTime
+}
+
+// Duration resolves the duration of the epoch in seconds.
+func (e *Epoch) Duration() hexutil.Uint64 {
+	return e.epoch.Duration
+}
+
+// EpochFee resolves the total fee collected in the epoch.
+func (e *Epoch) EpochFee() hexutil.Big {
+	return e.epoch.EpochFee
+}
+
+// BaseRewardPerSecond resolves the base reward per second paid to the validators in the epoch.
+func (e *Epoch) BaseRewardPerSecond() hexutil.Big {
+	return e.epoch.BaseRewardPerSecond
+}
+
+// TotalStake resolves the total stake of the validators of the epoch.
+func (e *Epoch) TotalStake() hexutil.Big {
+	return e.epoch.TotalStake
+}
+
+// TotalSupply resolves the total supply of the native tokens at the end of the epoch.
+func (e *Epoch) TotalSupply() hexutil.Big {
+	return e.epoch.TotalSupply
+}
+
+// ValidatorIds resolves the ids of the validators of the epoch.
+func (e *Epoch) ValidatorIds() []hexutil.Uint64 {
+	return e.epoch.ValidatorIds
+}
@@ -0,0 +1,110 @@
+package resolvers
+
+import (
+	"fmt"
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// kEpochsMaxCount is the maximal number of epochs returned in a single page.
+const kEpochsMaxCount = 100
+
+// EpochStats represents resolvable statistics of a sealed epoch.
+type EpochStats struct {
+	stats types.EpochStats
+}
+
+// EpochStatsList represents resolvable page of the statistics of sealed epochs.
+type EpochStatsList struct {
+	items []*EpochStats
+	next  *Cursor
+}
+
+// Epochs resolves the statistics of the sealed epochs ordered from the newest one.
+// The cursor is the number of the last epoch of the previous page.
+func (rs *RootResolver) Epochs(args struct {
+	Cursor *Cursor
+	Count  int32
+}) (*EpochStatsList, error) {
+	if args.Count <= 0 || args.Count > kEpochsMaxCount {
+		return nil, fmt.Errorf("invalid count value, must be between 1 and %d", kEpochsMaxCount)
+	}
+
+	var before *uint64
+	if args.Cursor != nil {
+		number, err := hexutil.DecodeUint64(string(*args.Cursor))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor value")
+		}
+		before = &number
+	}
+
+	stats, err := rs.repository.GetEpochStats(before, uint(args.Count))
+	if err != nil {
+		rs.log.Errorf("failed to get epoch stats: %v", err)
+		return nil, err
+	}
+
+	list := EpochStatsList{items: make([]*EpochStats, len(stats))}
+	for i, s := range stats {
+		list.items[i] = &EpochStats{s}
+	}
+	// a full page may be followed by older epochs
+	if len(stats) == int(args.Count) && stats[len(stats)-1].Epoch > 0 {
+		next := Cursor(stats[len(stats)-1].Epoch.String())
+		list.next = &next
+	}
+	return &list, nil
+}
+
+// Items resolves the statistics of the epochs of the page.
+func (l *EpochStatsList) Items() []*EpochStats {
+	return l.items
+}
+
+// Next resolves the cursor of the next page.
+func (l *EpochStatsList) Next() *Cursor {
+	return l.next
+}
+
+// Epoch resolves the number of the epoch.
+func (es *EpochStats) Epoch() hexutil.Uint64 {
+	return es.stats.Epoch
+}
+
+// Blocks resolves the number of blocks of the epoch.
+func (es *EpochStats) Blocks() hexutil.Uint64 {
+	return es.stats.Blocks
+}
+
+// Transactions resolves the number of transactions of the epoch.
+func (es *EpochStats) Transactions() hexutil.Uint64 {
+	return es.stats.Transactions
+}
+
+// GasUsed resolves the total gas used by the blocks of the epoch.
+func (es *EpochStats) GasUsed() hexutil.Uint64 {
+	return es.stats.GasUsed
+}
+
+// StartTime resolves the unix timestamp of the first block of the epoch.
+func (es *EpochStats) StartTime() hexutil.Uint64 {
+	return es.stats.StartTime
+}
+
+// EndTime resolves the unix timestamp of the last block of the epoch.
+func (es *EpochStats) EndTime() hexutil.Uint64 {
+	return es.stats.EndTime
+}
+
+// Duration resolves the duration of the epoch in seconds.
+func (es *EpochStats) Duration() hexutil.Uint64 {
+	return es.stats.Duration
+}
+
+// AvgTimeToFinality resolves the average time to finality measured during the epoch,
+// rounded to 2 decimal places.
+func (es *EpochStats) AvgTimeToFinality() float64 {
+	return float64(int(es.stats.AvgTtf*100)) / 100
+}
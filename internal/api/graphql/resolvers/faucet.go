@@ -4,14 +4,19 @@ import (
 	"context"
 	"fmt"
 	"ftm-explorer/internal/auth"
+	"ftm-explorer/internal/faucet"
+	"ftm-explorer/internal/types"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // RequestTokens initiates the request for tokens from faucet.
 func (rs *RootResolver) RequestTokens(ctx context.Context, args struct {
-	Symbol *string
+	Symbol    *string
+	TypedData *bool
+	Proof     *string
 }) (string, error) {
 	// get the ip address from the context
 	ip, err := auth.GetIpOrErr(ctx)
@@ -22,13 +27,17 @@ func (rs *RootResolver) RequestTokens(ctx context.Context, args struct {
 	// run the faucet request as singleflight group to prevent multiple requests from the same ip
 	key := fmt.Sprintf("request_tokens_%s", ip)
 	phrase, err, _ := rs.sfg.Do(key, func() (interface{}, error) {
-		return rs.faucet.RequestTokens(ip, args.Symbol)
+		var proof string
+		if args.Proof != nil {
+			proof = *args.Proof
+		}
+		return rs.faucet.RequestTokens(ip, args.Symbol, proof)
 	})
 	if err != nil {
 		return "", err
 	}
 
-	return phrase.(string), nil
+	return rs.challenge(phrase.(string), args.TypedData)
 }
 
 // ClaimTokens claims the tokens from faucet. It requires the user to sign the challenge.
@@ -47,18 +56,13 @@ func (rs *RootResolver) ClaimTokens(ctx context.Context, args struct {
 	// run the faucet request as singleflight group to prevent multiple claims from the same ip
 	key := fmt.Sprintf("claim_tokens_%s", ip)
 	_, err, _ = rs.sfg.Do(key, func() (interface{}, error) {
-		// decode signature
-		decodedSignature, err := hexutil.Decode(args.Signature)
-		if err != nil {
-			return "", fmt.Errorf("signature hex decoding failed; %s", err)
-		}
 		// verify signature
-		_, err = auth.VerifySignature(args.Challenge, args.Address, decodedSignature)
+		phrase, err := rs.verifySignature(args.Challenge, args.Address, args.Signature)
 		if err != nil {
-			return "", fmt.Errorf("signature verification failed; %s", err)
+			return "", err
 		}
 		// claim tokens
-		err = rs.faucet.ClaimTokens(ip, args.Challenge, args.Address, args.Erc20Address)
+		err = rs.faucet.ClaimTokens(ip, phrase, args.Address, args.Erc20Address)
 		return "", err
 	})
 	if err != nil {
@@ -67,3 +71,193 @@ func (rs *RootResolver) ClaimTokens(ctx context.Context, args struct {
 
 	return true, nil
 }
+
+// ClaimTokensWithSession claims the tokens from faucet to the address of the sign-in session.
+func (rs *RootResolver) ClaimTokensWithSession(ctx context.Context, args struct {
+	Session      string
+	Erc20Address *common.Address
+	Proof        *string
+}) (bool, error) {
+	// get the ip address from the context
+	ip, err := auth.GetIpOrErr(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	session, err := rs.session(args.Session)
+	if err != nil {
+		return false, err
+	}
+
+	// run the faucet request as singleflight group to prevent multiple claims from the same ip
+	key := fmt.Sprintf("claim_tokens_%s", ip)
+	_, err, _ = rs.sfg.Do(key, func() (interface{}, error) {
+		var proof string
+		if args.Proof != nil {
+			proof = *args.Proof
+		}
+		return "", rs.faucet.ClaimTokensToAddress(ip, session.Address, args.Erc20Address, proof)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// kMyFaucetClaimsLimit is the number of the latest claims returned to the caller.
+const kMyFaucetClaimsLimit = 20
+
+// kFaucetClaimStatusUnknown is the status of claims made before the status tracking was introduced.
+const kFaucetClaimStatusUnknown = "unknown"
+
+// FaucetClaim represents resolvable faucet claim.
+type FaucetClaim struct {
+	tr types.TokensRequest
+}
+
+// FaucetClaim resolves the faucet claim by its id.
+func (rs *RootResolver) FaucetClaim(args struct {
+	Id string
+}) (*FaucetClaim, error) {
+	id, err := primitive.ObjectIDFromHex(args.Id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim id")
+	}
+	tr, err := rs.repository.GetTokensRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	// requests which have not been claimed are not claims
+	if tr == nil || tr.ClaimedAt == nil || tr.Receiver == nil {
+		return nil, nil
+	}
+	return &FaucetClaim{tr: *tr}, nil
+}
+
+// MyFaucetClaims resolves the latest faucet claims made from the ip address of the caller.
+func (rs *RootResolver) MyFaucetClaims(ctx context.Context) ([]*FaucetClaim, error) {
+	// get the ip address from the context
+	ip, err := auth.GetIpOrErr(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := rs.repository.GetTokensRequestClaims(ip, kMyFaucetClaimsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := make([]*FaucetClaim, 0, len(claims))
+	for _, tr := range claims {
+		if tr.ClaimedAt == nil || tr.Receiver == nil {
+			continue
+		}
+		rv = append(rv, &FaucetClaim{tr: tr})
+	}
+	return rv, nil
+}
+
+// Id resolves the id of the claim.
+func (fc *FaucetClaim) Id() string {
+	return fc.tr.Id.Hex()
+}
+
+// Receiver resolves the address the tokens are sent to.
+func (fc *FaucetClaim) Receiver() common.Address {
+	return *fc.tr.Receiver
+}
+
+// Erc20Address resolves the address of the claimed erc20 token.
+func (fc *FaucetClaim) Erc20Address() *common.Address {
+	return fc.tr.Erc20
+}
+
+// TxHash resolves the hash of the claim transaction.
+func (fc *FaucetClaim) TxHash() *common.Hash {
+	return fc.tr.TxHash
+}
+
+// Status resolves the status of the claim transaction.
+func (fc *FaucetClaim) Status() string {
+	if fc.tr.Status == "" {
+		return kFaucetClaimStatusUnknown
+	}
+	return fc.tr.Status
+}
+
+// ClaimedAt resolves the time of the claim.
+func (fc *FaucetClaim) ClaimedAt() hexutil.Uint64 {
+	return hexutil.Uint64(*fc.tr.ClaimedAt)
+}
+
+// FaucetStatus represents resolvable funding status of the faucet.
+type FaucetStatus struct {
+	status faucet.Status
+}
+
+// FaucetStatus resolves the funding status of the faucet for the given erc20 token or the native token.
+func (rs *RootResolver) FaucetStatus(args struct {
+	Erc20Address *common.Address
+}) (*FaucetStatus, error) {
+	status, err := rs.faucet.Status(args.Erc20Address)
+	if err != nil {
+		return nil, err
+	}
+	return &FaucetStatus{*status}, nil
+}
+
+// Balance resolves the native balance of the wallet paying the claims.
+func (fs *FaucetStatus) Balance() *hexutil.Big {
+	return (*hexutil.Big)(fs.status.Balance)
+}
+
+// RemainingClaims resolves the number of claims the balance can pay.
+func (fs *FaucetStatus) RemainingClaims() *hexutil.Uint64 {
+	return (*hexutil.Uint64)(fs.status.RemainingClaims)
+}
+
+// Enabled resolves whether the claims are enabled.
+func (fs *FaucetStatus) Enabled() bool {
+	return fs.status.Enabled
+}
+
+// FaucetGate represents resolvable parameters of the faucet gate.
+type FaucetGate struct {
+	params faucet.GateParams
+}
+
+// FaucetGate resolves the parameters the clients need to pass the gate of the faucet.
+func (rs *RootResolver) FaucetGate() *FaucetGate {
+	return &FaucetGate{rs.faucet.GateParams()}
+}
+
+// Kind resolves the kind of the gate.
+func (fg *FaucetGate) Kind() string {
+	return fg.params.Kind
+}
+
+// Difficulty resolves the number of leading zero bits of the proof-of-work stamp hash.
+func (fg *FaucetGate) Difficulty() *int32 {
+	if fg.params.Difficulty == nil {
+		return nil
+	}
+	d := int32(*fg.params.Difficulty)
+	return &d
+}
+
+// Resource resolves the resource of the proof-of-work stamp.
+func (fg *FaucetGate) Resource() *string {
+	if fg.params.Resource == "" {
+		return nil
+	}
+	return &fg.params.Resource
+}
+
+// SiteKey resolves the public key of the site used to render the captcha.
+func (fg *FaucetGate) SiteKey() *string {
+	if fg.params.SiteKey == "" {
+		return nil
+	}
+	return &fg.params.SiteKey
+}
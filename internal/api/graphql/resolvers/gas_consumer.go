@@ -0,0 +1,63 @@
+package resolvers
+
+import (
+	"fmt"
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// kTopGasConsumersMaxLimit is the maximal number of the returned gas consumers.
+const kTopGasConsumersMaxLimit = 100
+
+// GasConsumer represents resolvable gas usage of a contract method.
+type GasConsumer struct {
+	usage types.GasUsage
+}
+
+// TopGasConsumers resolves the contract methods ranked by the gas used in the given window.
+func (rs *RootResolver) TopGasConsumers(args struct {
+	Resolution types.AggResolution
+	Window     int32
+	Limit      int32
+}) ([]*GasConsumer, error) {
+	if args.Window <= 0 {
+		return nil, fmt.Errorf("invalid window value")
+	}
+	if args.Limit <= 0 || args.Limit > kTopGasConsumersMaxLimit {
+		return nil, fmt.Errorf("invalid limit value, must be between 1 and %d", kTopGasConsumersMaxLimit)
+	}
+
+	usage, err := rs.repository.GetTopGasConsumers(args.Resolution, uint(args.Window), uint(args.Limit))
+	if err != nil {
+		rs.log.Errorf("failed to get top gas consumers: %v", err)
+		return nil, err
+	}
+
+	rv := make([]*GasConsumer, len(usage))
+	for i, u := range usage {
+		rv[i] = &GasConsumer{u}
+	}
+	return rv, nil
+}
+
+// Contract resolves the address of the called contract.
+func (gc *GasConsumer) Contract() common.Address {
+	return gc.usage.Contract
+}
+
+// Selector resolves the selector of the called method.
+func (gc *GasConsumer) Selector() string {
+	return gc.usage.Selector
+}
+
+// GasUsed resolves the total gas used by the calls.
+func (gc *GasConsumer) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(gc.usage.GasUsed)
+}
+
+// Calls resolves the number of the calls.
+func (gc *GasConsumer) Calls() hexutil.Uint64 {
+	return hexutil.Uint64(gc.usage.Calls)
+}
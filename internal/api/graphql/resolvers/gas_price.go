@@ -0,0 +1,57 @@
+package resolvers
+
+import (
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// GasPrice represents resolvable gas price estimate.
+type GasPrice struct {
+	gasPrice types.GasPrice
+}
+
+// GasPrice resolves the gas price estimate of the gas price oracle.
+func (rs *RootResolver) GasPrice() (*GasPrice, error) {
+	gp, err := rs.repository.GetGasPrice()
+	if err != nil {
+		rs.log.Errorf("failed to get gas price: %v", err)
+		return nil, err
+	}
+	return &GasPrice{*gp}, nil
+}
+
+// SafeLow resolves the gas price likely to be accepted, but not soon.
+func (gp *GasPrice) SafeLow() hexutil.Big {
+	return gp.gasPrice.SafeLow
+}
+
+// Average resolves the gas price paid by an average transaction.
+func (gp *GasPrice) Average() hexutil.Big {
+	return gp.gasPrice.Average
+}
+
+// Fast resolves the gas price likely to be accepted within a few blocks.
+func (gp *GasPrice) Fast() hexutil.Big {
+	return gp.gasPrice.Fast
+}
+
+// Fastest resolves the gas price likely to be accepted in the next block.
+func (gp *GasPrice) Fastest() hexutil.Big {
+	return gp.gasPrice.Fastest
+}
+
+// BaseFee resolves the base fee of the latest block.
+func (gp *GasPrice) BaseFee() *hexutil.Big {
+	return gp.gasPrice.BaseFee
+}
+
+// PriorityFee resolves the average priority fee paid above the base fee.
+func (gp *GasPrice) PriorityFee() *hexutil.Big {
+	return gp.gasPrice.PriorityFee
+}
+
+// BlockNumber resolves the number of the latest block the estimate is derived from.
+func (gp *GasPrice) BlockNumber() hexutil.Uint64 {
+	return gp.gasPrice.BlockNumber
+}
@@ -0,0 +1,121 @@
+package resolvers
+
+import (
+	"context"
+	"ftm-explorer/internal/types"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// kLoaderMaxConcurrency represents the maximum number of concurrent fetches of a single loader.
+const kLoaderMaxConcurrency = 16
+
+// loadersCtxKey is the context key of request scoped loaders.
+type loadersCtxKey struct{}
+
+// loaders represents a set of request scoped data loaders. The loaders coalesce
+// and deduplicate fetches of the same data within a single GraphQL request.
+type loaders struct {
+	transactions *loader[common.Hash, *types.Transaction]
+	blocks       *loader[uint64, *types.Block]
+	balances     *loader[common.Address, *hexutil.Big]
+}
+
+// WithLoaders returns a copy of the given context carrying a fresh set of data loaders.
+// It is expected to be called once per GraphQL request.
+func (rs *RootResolver) WithLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersCtxKey{}, rs.newLoaders())
+}
+
+// loaders returns the data loaders of the request. If the context does not carry
+// any loaders, a new set is created, so that the fetches are still deduplicated
+// within the calling resolver.
+func (rs *RootResolver) loaders(ctx context.Context) *loaders {
+	if l, ok := ctx.Value(loadersCtxKey{}).(*loaders); ok {
+		return l
+	}
+	return rs.newLoaders()
+}
+
+// newLoaders creates a new set of data loaders backed by the repository.
+func (rs *RootResolver) newLoaders() *loaders {
+	return &loaders{
+		transactions: newLoader(rs.repository.GetTransactionByHash),
+		blocks:       newLoader(rs.repository.GetBlockByNumber),
+		balances:     newLoader(rs.repository.AccountBalance),
+	}
+}
+
+// loaderResult represents a result of a single fetch of the loader.
+type loaderResult[V any] struct {
+	// done is closed once the fetch is finished
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// loader represents a data loader memoizing fetches by key.
+// Concurrent loads of the same key share a single fetch.
+type loader[K comparable, V any] struct {
+	mtx     sync.Mutex
+	fetch   func(K) (V, error)
+	results map[K]*loaderResult[V]
+}
+
+// newLoader creates a new data loader using the given fetch function.
+func newLoader[K comparable, V any](fetch func(K) (V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:   fetch,
+		results: make(map[K]*loaderResult[V]),
+	}
+}
+
+// load returns the value identified by the given key.
+// The value is fetched only once, subsequent loads wait for the first fetch to finish.
+func (l *loader[K, V]) load(key K) (V, error) {
+	l.mtx.Lock()
+	res, ok := l.results[key]
+	if !ok {
+		res = &loaderResult[V]{done: make(chan struct{})}
+		l.results[key] = res
+	}
+	l.mtx.Unlock()
+
+	if ok {
+		<-res.done
+		return res.value, res.err
+	}
+
+	res.value, res.err = l.fetch(key)
+	close(res.done)
+	return res.value, res.err
+}
+
+// loadMany returns values identified by the given keys in the order of the keys.
+// Values are fetched concurrently; the first error encountered is returned.
+func (l *loader[K, V]) loadMany(keys []K) ([]V, error) {
+	values := make([]V, len(keys))
+	errs := make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, kLoaderMaxConcurrency)
+	for i, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, key K) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			values[i], errs[i] = l.load(key)
+		}(i, key)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,59 @@
+package resolvers
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Test that concurrent loads of the same key share a single fetch.
+func TestLoader_DeduplicatesFetches(t *testing.T) {
+	var calls int32
+	l := newLoader(func(key int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return key * 2, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values, err := l.loadMany([]int{1, 2, 3, 1, 2, 3})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			for i, v := range values {
+				if expected := []int{2, 4, 6, 2, 4, 6}[i]; v != expected {
+					t.Errorf("expected %d, got %d", expected, v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 3 {
+		t.Errorf("expected 3 fetches, got %d", calls)
+	}
+}
+
+// Test that errors of the fetch are returned to the caller.
+func TestLoader_ReturnsError(t *testing.T) {
+	l := newLoader(func(key int) (int, error) {
+		if key == 2 {
+			return 0, fmt.Errorf("not found")
+		}
+		return key, nil
+	})
+
+	if _, err := l.loadMany([]int{1, 2, 3}); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if v, err := l.load(3); err != nil || v != 3 {
+		t.Errorf("expected 3, got %d; %v", v, err)
+	}
+}
@@ -2,11 +2,9 @@ package resolvers
 
 import (
 	"fmt"
-	"ftm-explorer/internal/auth"
 	"ftm-explorer/internal/types"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // Maze returns the maze.
@@ -28,11 +26,17 @@ func (rs *RootResolver) MazeList() ([]*types.Maze, error) {
 }
 
 // MazeGameSession returns the maze game session.
-func (rs *RootResolver) MazeGameSession() (string, error) {
+func (rs *RootResolver) MazeGameSession(args struct {
+	TypedData *bool
+}) (string, error) {
 	if rs.maze == nil {
 		return "", fmt.Errorf("maze is not initialized")
 	}
-	return rs.maze.GenerateChallenge()
+	challenge, err := rs.maze.GenerateChallenge()
+	if err != nil {
+		return "", err
+	}
+	return rs.challenge(challenge, args.TypedData)
 }
 
 // MazeMyPosition returns the position of the player.
@@ -45,22 +49,41 @@ func (rs *RootResolver) MazeMyPosition(args struct {
 	if rs.maze == nil {
 		return nil, fmt.Errorf("maze is not initialized")
 	}
-	// decode the signature
-	decodedSignature, err := hexutil.Decode(args.Signature)
+	// verify signature
+	challenge, err := rs.verifySignature(args.Challenge, args.Address, args.Signature)
 	if err != nil {
-		return nil, fmt.Errorf("signature hex decoding failed; %s", err)
+		return nil, err
 	}
-	// verify signature
-	_, err = auth.VerifySignature(args.Challenge, args.Address, decodedSignature)
+	// the challenge is consumed only after the signature is verified, so it can not be burnt by others
+	if err := rs.maze.UseChallenge(challenge, args.Address); err != nil {
+		return nil, err
+	}
+	return rs.mazePosition(args.MazeAddress, args.Address)
+}
+
+// MazeMyPositionWithSession returns the position of the player of the sign-in session.
+func (rs *RootResolver) MazeMyPositionWithSession(args struct {
+	Session     string
+	MazeAddress common.Address
+}) (*types.MazePosition, error) {
+	if rs.maze == nil {
+		return nil, fmt.Errorf("maze is not initialized")
+	}
+	session, err := rs.session(args.Session)
 	if err != nil {
-		return nil, fmt.Errorf("signature verification failed; %s", err)
+		return nil, err
 	}
+	return rs.mazePosition(args.MazeAddress, session.Address)
+}
+
+// mazePosition returns the position of the given player in the given maze.
+func (rs *RootResolver) mazePosition(mazeAddress common.Address, player common.Address) (*types.MazePosition, error) {
 	// return nil if the maze does not exist
-	if !rs.maze.Exists(args.MazeAddress) {
+	if !rs.maze.Exists(mazeAddress) {
 		return nil, fmt.Errorf("maze does not exist")
 	}
 	// get the player position
-	tileId, err := rs.repository.MazePlayerPosition(args.MazeAddress, args.Address)
+	tileId, err := rs.repository.MazePlayerPosition(mazeAddress, player)
 	if err != nil {
 		rs.log.Errorf("error getting player position: %v", err)
 		return nil, fmt.Errorf("error getting player position")
@@ -69,5 +92,5 @@ func (rs *RootResolver) MazeMyPosition(args struct {
 	if tileId == 0 {
 		return nil, nil
 	}
-	return rs.maze.TileToPosition(args.MazeAddress, int32(tileId))
+	return rs.maze.TileToPosition(mazeAddress, int32(tileId))
 }
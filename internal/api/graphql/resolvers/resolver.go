@@ -1,10 +1,12 @@
 package resolvers
 
 import (
+	"ftm-explorer/internal/auth"
 	"ftm-explorer/internal/faucet"
 	"ftm-explorer/internal/logger"
 	"ftm-explorer/internal/maze"
 	"ftm-explorer/internal/repository"
+	"ftm-explorer/internal/siwe"
 
 	"golang.org/x/sync/singleflight"
 )
@@ -15,6 +17,8 @@ type RootResolver struct {
 	log        logger.ILogger
 	faucet     faucet.IFaucet
 	maze       maze.IMaze
+	siwe       siwe.ISiwe
+	verifier   *auth.Verifier
 
 	// singleflight is used to prevent multiple concurrent requests for the same data.
 	sfg singleflight.Group
@@ -25,12 +29,14 @@ type RootResolver struct {
 }
 
 // NewResolver creates a new root resolver.
-func NewResolver(repository repository.IRepository, log logger.ILogger, faucet faucet.IFaucet, maze maze.IMaze, isPersisted bool) *RootResolver {
+func NewResolver(repository repository.IRepository, log logger.ILogger, faucet faucet.IFaucet, maze maze.IMaze, siwe siwe.ISiwe, isPersisted bool) *RootResolver {
 	return &RootResolver{
 		repository:  repository,
 		log:         log.ModuleLogger("resolver"),
 		faucet:      faucet,
 		maze:        maze,
+		siwe:        siwe,
+		verifier:    auth.NewVerifier(repository),
 		isPersisted: isPersisted,
 	}
 }
@@ -0,0 +1,62 @@
+package resolvers
+
+import (
+	"fmt"
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SiweChallenge returns the sign-in message to be signed by the given address.
+func (rs *RootResolver) SiweChallenge(args struct {
+	Address common.Address
+}) (string, error) {
+	if rs.siwe == nil {
+		return "", fmt.Errorf("sign-in is not initialized")
+	}
+	return rs.siwe.Challenge(args.Address)
+}
+
+// SiweSignIn verifies the signed sign-in message and returns the session token.
+func (rs *RootResolver) SiweSignIn(args struct {
+	Message   string
+	Signature string
+}) (string, error) {
+	if rs.siwe == nil {
+		return "", fmt.Errorf("sign-in is not initialized")
+	}
+	decodedSignature, err := hexutil.Decode(args.Signature)
+	if err != nil {
+		return "", fmt.Errorf("signature hex decoding failed; %s", err)
+	}
+	return rs.siwe.SignIn(args.Message, decodedSignature)
+}
+
+// session returns the active sign-in session identified by the given token.
+func (rs *RootResolver) session(token string) (*types.SiweSession, error) {
+	if rs.siwe == nil {
+		return nil, fmt.Errorf("sign-in is not initialized")
+	}
+	return rs.siwe.Session(token)
+}
+
+// challenge returns the given challenge to be signed by the user,
+// wrapped into EIP-712 typed data if requested.
+func (rs *RootResolver) challenge(challenge string, typedData *bool) (string, error) {
+	if typedData == nil || !*typedData {
+		return challenge, nil
+	}
+	return rs.verifier.TypedChallenge(challenge)
+}
+
+// verifySignature verifies the hex encoded signature of the given challenge was made by the given address
+// and returns the plain challenge text. The challenge may be signed as text or as EIP-712 typed data,
+// signatures of contract wallets are verified by EIP-1271.
+func (rs *RootResolver) verifySignature(challenge string, address common.Address, signature string) (string, error) {
+	decodedSignature, err := hexutil.Decode(signature)
+	if err != nil {
+		return "", fmt.Errorf("signature hex decoding failed; %s", err)
+	}
+	return rs.verifier.VerifyChallenge(challenge, address, decodedSignature)
+}
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"ftm-explorer/internal/types"
 	"ftm-explorer/internal/utils"
 
@@ -14,8 +15,8 @@ type Transaction struct {
 }
 
 // Transaction resolves blockchain transaction by transaction hash.
-func (rs *RootResolver) Transaction(args *struct{ Hash common.Hash }) (*Transaction, error) {
-	trx, err := rs.repository.GetTransactionByHash(args.Hash)
+func (rs *RootResolver) Transaction(ctx context.Context, args *struct{ Hash common.Hash }) (*Transaction, error) {
+	trx, err := rs.loaders(ctx).transactions.load(args.Hash)
 	if err != nil {
 		rs.log.Warningf("Failed to get transaction by hash [%s]; %v", args.Hash.Hex(), err)
 		return nil, err
@@ -40,11 +41,11 @@ func (trx *Transaction) Type() string {
 }
 
 // Block resolves transaction block.
-func (trx *Transaction) Block() (*Block, error) {
+func (trx *Transaction) Block(ctx context.Context) (*Block, error) {
 	if trx.BlockNumber == nil {
 		return nil, nil
 	}
-	block, err := trx.rs.repository.GetBlockByNumber(uint64(*trx.BlockNumber))
+	block, err := trx.rs.loaders(ctx).blocks.load(uint64(*trx.BlockNumber))
 	if err != nil {
 		trx.rs.log.Warningf("Failed to get block by hash [%s]; %v", trx.BlockHash.Hex(), err)
 		return nil, err
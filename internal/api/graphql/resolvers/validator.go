@@ -0,0 +1,97 @@
+package resolvers
+
+import (
+	"ftm-explorer/internal/types"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Validator represents resolvable validator of the network.
+type Validator struct {
+	validator types.Validator
+}
+
+// Validators resolves the validators of the latest sealed epoch.
+func (rs *RootResolver) Validators() ([]*Validator, error) {
+	validators, err := rs.repository.GetValidators()
+	if err != nil {
+		rs.log.Errorf("failed to get validators: %v", err)
+		return nil, err
+	}
+
+	rv := make([]*Validator, len(validators))
+	for i, v := range validators {
+		rv[i] = &Validator{v}
+	}
+	return rv, nil
+}
+
+// Validator resolves the validator with the given id.
+func (rs *RootResolver) Validator(args struct{ Id hexutil.Uint64 }) (*Validator, error) {
+	validator, err := rs.repository.GetValidator(uint64(args.Id))
+	if err != nil {
+		rs.log.Errorf("failed to get validator %d: %v", args.Id, err)
+		return nil, err
+	}
+	if validator == nil {
+		return nil, nil
+	}
+	return &Validator{*validator}, nil
+}
+
+// Id resolves the id of the validator.
+func (v *Validator) Id() hexutil.Uint64 {
+	return v.validator.Id
+}
+
+// Auth resolves the address the validator is authorized by.
+func (v *Validator) Auth() common.Address {
+	return v.validator.Auth
+}
+
+// Status resolves the status of the validator.
+func (v *Validator) Status() types.ValidatorStatus {
+	return v.validator.Status
+}
+
+// SelfStake resolves the amount staked by the validator itself.
+func (v *Validator) SelfStake() hexutil.Big {
+	return v.validator.SelfStake
+}
+
+// DelegatedStake resolves the amount delegated to the validator by other accounts.
+func (v *Validator) DelegatedStake() hexutil.Big {
+	return v.validator.DelegatedStake
+}
+
+// TotalStake resolves the sum of the self stake and the delegated stake.
+func (v *Validator) TotalStake() hexutil.Big {
+	return hexutil.Big(*new(big.Int).Add(v.validator.SelfStake.ToInt(), v.validator.DelegatedStake.ToInt()))
+}
+
+// CreatedEpoch resolves the epoch the validator was created in.
+func (v *Validator) CreatedEpoch() hexutil.Uint64 {
+	return v.validator.CreatedEpoch
+}
+
+// CreatedTime resolves the unix timestamp the validator was created at.
+func (v *Validator) CreatedTime() hexutil.Uint64 {
+	return v.validator.CreatedTime
+}
+
+// DeactivatedEpoch resolves the epoch the validator was deactivated in.
+func (v *Validator) DeactivatedEpoch() hexutil.Uint64 {
+	return v.validator.DeactivatedEpoch
+}
+
+// DeactivatedTime resolves the unix timestamp the validator was deactivated at.
+func (v *Validator) DeactivatedTime() hexutil.Uint64 {
+	return v.validator.DeactivatedTime
+}
+
+// Uptime resolves the number of seconds the validator was online in the latest sealed epoch.
+func (v *Validator) Uptime() hexutil.Uint64 {
+	return v.validator.Uptime
+}
@@ -121,6 +121,14 @@ enum AggSubject {
     TXS_COUNT,
     GAS_USED
 }
+
+# AggResolution is the resolution of the aggregation
+enum AggResolution {
+    SECONDS,
+    MINUTE,
+    HOUR,
+    DAY
+}
 # MazePathDirection is an enum that represents the four directions that a MazePath can go in.
 enum MazePathDirection {
     NORTH,
@@ -218,6 +226,21 @@ type CurrentState {
 
     # Get idle state of the blockchain.
     isIdle: Boolean!
+
+    # Get usage statistics of the transactions cache.
+    transactionCache: CacheStats!
+}
+
+# CacheStats represents usage statistics of an in-memory cache.
+type CacheStats {
+    # Number of lookups served from the cache.
+    hits: Long!
+
+    # Number of lookups not found in the cache.
+    misses: Long!
+
+    # Number of entries in the cache.
+    size: Long!
 }
 # Account defines block-chain account information container
 type Account {
@@ -229,6 +252,255 @@ type Account {
 
     # transactions is the list of transactions that are linked to this account.
     transactions: [Transaction!]!
+
+    # delegations is the list of the stakes delegated by this account to validators,
+    # including the delegations with no stake left, but with rewards to be claimed.
+    delegations: [Delegation!]!
+
+    # stakingRewards are the rewards of all the delegations of this account.
+    stakingRewards: StakingRewards!
+}
+# FaucetClaim represents a claim of faucet tokens and the state of its transaction.
+type FaucetClaim {
+    # Id is the unique identifier of the claim.
+    id: String!
+
+    # Receiver is the address the tokens are sent to.
+    receiver: Address!
+
+    # Erc20Address is the address of the claimed erc20 token, null for native tokens.
+    erc20Address: Address
+
+    # TxHash is the hash of the claim transaction, null while the transaction is being sent.
+    txHash: Bytes32
+
+    # Status is the status of the claim transaction - pending, mined or failed.
+    # Failed claims do not count into the claims limit. Claims made before
+    # the status tracking was introduced are reported as unknown.
+    status: String!
+
+    # ClaimedAt is the time of the claim in unix seconds.
+    claimedAt: Long!
+}
+
+# Delegation represents the stake delegated by an account to a validator.
+type Delegation {
+    # ValidatorId is the id of the validator the stake is delegated to.
+    validatorId: Long!
+
+    # Validator is the validator the stake is delegated to.
+    validator: Validator
+
+    # Amount is the delegated amount in WEI.
+    amount: BigInt!
+
+    # PendingRewards are the rewards which can be claimed, including the stashed rewards.
+    pendingRewards: BigInt!
+
+    # StashedRewards are the rewards already moved to the stash, e.g. on undelegation.
+    stashedRewards: BigInt!
+
+    # Lockup is the lockup of the delegated stake, null if no stake is locked.
+    lockup: Lockup
+}
+
+# Lockup represents the locked part of a delegation.
+type Lockup {
+    # LockedStake is the locked amount in WEI.
+    lockedStake: BigInt!
+
+    # FromEpoch is the epoch the stake was locked in.
+    fromEpoch: Long!
+
+    # EndTime is the unix timestamp the lockup ends at.
+    endTime: Long!
+
+    # Duration is the duration of the lockup in seconds.
+    duration: Long!
+}
+
+# StakingRewards represents the rewards of all the delegations of an account.
+type StakingRewards {
+    # Pending are the rewards which can be claimed, including the stashed rewards.
+    pending: BigInt!
+
+    # Stashed are the rewards already moved to the stash.
+    stashed: BigInt!
+}
+
+# Epoch represents the snapshot of a sealed epoch stored in the SFC contract.
+type Epoch {
+    # Number is the number of the epoch.
+    number: Long!
+
+    # EndTime is the unix timestamp the epoch was sealed at.
+    endTime: Long!
+
+    # Duration is the number of seconds between the end of the previous epoch and the end of this epoch.
+    duration: Long!
+
+    # EpochFee is the total fee collected in the epoch.
+    epochFee: BigInt!
+
+    # BaseRewardPerSecond is the base reward per second paid to the validators in the epoch.
+    baseRewardPerSecond: BigInt!
+
+    # TotalStake is the total stake of the validators of the epoch.
+    totalStake: BigInt!
+
+    # TotalSupply is the total supply of the native tokens at the end of the epoch.
+    totalSupply: BigInt!
+
+    # ValidatorIds are the ids of the validators of the epoch.
+    validatorIds: [Long!]!
+}
+
+# ValidatorStatus is the status of a validator.
+enum ValidatorStatus {
+    ACTIVE,
+    OFFLINE,
+    WITHDRAWN,
+    CHEATER
+}
+
+# Validator represents a validator of the network registered in the SFC contract.
+type Validator {
+    # Id is the id of the validator.
+    id: Long!
+
+    # Auth is the address the validator is authorized by.
+    auth: Address!
+
+    # Status is the status of the validator.
+    status: ValidatorStatus!
+
+    # SelfStake is the amount staked by the validator itself.
+    selfStake: BigInt!
+
+    # DelegatedStake is the amount delegated to the validator by other accounts.
+    delegatedStake: BigInt!
+
+    # TotalStake is the sum of the self stake and the delegated stake.
+    totalStake: BigInt!
+
+    # CreatedEpoch is the epoch the validator was created in.
+    createdEpoch: Long!
+
+    # CreatedTime is the unix timestamp the validator was created at.
+    createdTime: Long!
+
+    # DeactivatedEpoch is the epoch the validator was deactivated in, zero if it is active.
+    deactivatedEpoch: Long!
+
+    # DeactivatedTime is the unix timestamp the validator was deactivated at, zero if it is active.
+    deactivatedTime: Long!
+
+    # Uptime is the number of seconds the validator was online in the latest sealed epoch.
+    uptime: Long!
+}
+
+# EpochStats represents the statistics of the observed blocks of a sealed epoch.
+type EpochStats {
+    # Epoch is the number of the epoch.
+    epoch: Long!
+
+    # Blocks is the number of blocks of the epoch.
+    blocks: Long!
+
+    # Transactions is the number of transactions of the epoch.
+    transactions: Long!
+
+    # GasUsed is the total gas used by the blocks of the epoch.
+    gasUsed: Long!
+
+    # StartTime is the unix timestamp of the first block of the epoch.
+    startTime: Long!
+
+    # EndTime is the unix timestamp of the last block of the epoch.
+    endTime: Long!
+
+    # Duration is the number of seconds between the last block of the previous epoch and the last block of the epoch.
+    duration: Long!
+
+    # AvgTimeToFinality is the average time to finality in seconds measured during the epoch, zero if not measured.
+    avgTimeToFinality: Float!
+}
+
+# EpochStatsList is a page of the statistics of sealed epochs ordered from the newest epoch.
+type EpochStatsList {
+    # Items are the statistics of the epochs of the page.
+    items: [EpochStats!]!
+
+    # Next is the cursor of the next page, null if there are no older epochs.
+    next: Cursor
+}
+
+# GasConsumer represents the gas used by the calls of a contract method.
+type GasConsumer {
+    # Contract is the address of the called contract.
+    contract: Address!
+
+    # Selector is the 4-byte selector of the called method, 0x for calls without data.
+    selector: String!
+
+    # GasUsed is the total gas used by the calls.
+    gasUsed: Long!
+
+    # Calls is the number of the calls.
+    calls: Long!
+}
+# GasPrice represents the gas price estimate in wei derived from the gas prices
+# paid by the transactions of the latest observed blocks.
+type GasPrice {
+    # SafeLow is the gas price likely to be accepted, but not soon.
+    safeLow: BigInt!
+
+    # Average is the gas price paid by an average transaction.
+    average: BigInt!
+
+    # Fast is the gas price likely to be accepted within a few blocks.
+    fast: BigInt!
+
+    # Fastest is the gas price likely to be accepted in the next block.
+    fastest: BigInt!
+
+    # BaseFee is the base fee of the latest block, null if the chain does not support London.
+    baseFee: BigInt
+
+    # PriorityFee is the average priority fee paid above the base fee, null if the chain does not support London.
+    priorityFee: BigInt
+
+    # BlockNumber is the number of the latest block the estimate is derived from, zero until estimated.
+    blockNumber: Long!
+}
+
+# FaucetStatus represents the funds of the faucet wallet paying the claims of a token.
+type FaucetStatus {
+    # Balance is the native balance of the wallet paying the claims, null until observed.
+    balance: BigInt
+
+    # RemainingClaims is the number of claims the balance can pay before the claims are disabled,
+    # null for erc20 tokens, whose claims pay only the gas of minting.
+    remainingClaims: Long
+
+    # Enabled tells if the claims are enabled, they are disabled while the wallet is low on funds.
+    enabled: Boolean!
+}
+
+# FaucetGate represents the gate protecting the faucet requests against bots.
+type FaucetGate {
+    # Kind is the kind of the gate - none, pow for hashcash proof-of-work or captcha.
+    kind: String!
+
+    # Difficulty is the number of leading zero bits of the SHA-1 hash of the proof-of-work stamp.
+    difficulty: Int
+
+    # Resource is the resource the hashcash version 1 stamp must be minted for,
+    # the stamp date is expected in the YYMMDDhhmmss format in UTC.
+    resource: String
+
+    # SiteKey is the public key of the site used to render the captcha.
+    siteKey: String
 }
 # Bytes32 is a 32 byte binary string, represented by 0x prefixed hexadecimal hash.
 scalar Bytes32
@@ -284,6 +556,22 @@ type Query {
     # Get total number of validators
     numberOfValidators:Int!
 
+    # Get validators of the latest sealed epoch.
+    validators:[Validator!]!
+
+    # Get validator by its id, null if the validator does not exist.
+    validator(id:Long!):Validator
+
+    # Get sealed epoch by its number, the latest sealed epoch if the number is not given.
+    # Returns null if the epoch is not sealed yet.
+    epoch(number:Long):Epoch
+
+    # Get statistics of the observed sealed epochs ordered from the newest one.
+    # parameters:
+    #   cursor: the next cursor of the previous page, the newest epochs are returned if not given
+    #   count: the number of epochs to return, at most 100
+    epochs(cursor:Cursor, count:Int!):EpochStatsList!
+
     # Get disk size per 100M transactions in bytes
     diskSizePer100MTxs:Long!
 
@@ -312,25 +600,63 @@ type Query {
     # Get idle state of the blockchain.
     isIdle: Boolean!
 
+    # Get gas price estimate based on the transactions of the latest observed blocks.
+    gasPrice: GasPrice!
+
+    # Get contract methods ranked by the gas used in the last window of resolution periods,
+    # e.g. 24 periods of HOUR. The gas usage is collected only if the explorer is persisted.
+    topGasConsumers(resolution: AggResolution!, window: Int!, limit: Int!): [GasConsumer!]!
+
     # Get list of maze games.
     mazeList: [Maze!]!
 
+    # Get faucet claim by its id.
+    faucetClaim(id: String!): FaucetClaim
+
+    # Get latest faucet claims made from the ip address of the caller.
+    myFaucetClaims: [FaucetClaim!]!
+
+    # Get funding status of the faucet for the given erc20 token, or for the native token if not given.
+    faucetStatus(erc20Address: Address): FaucetStatus!
+
+    # Get parameters of the gate protecting the faucet requests against bots.
+    faucetGate: FaucetGate!
+
     # Get maze metadata.
     maze(address:Address!): Maze!
 }
 
 type Mutation {
     # Send request to obtain tokens from faucet. Returns phrase that should be signed by the user.
-    requestTokens(symbol: String): String!
+    # If typedData is set, the phrase is wrapped into EIP-712 typed data JSON to be signed by eth_signTypedData_v4.
+    # The proof of passing the faucet gate, the proof-of-work stamp or the captcha response, is required by the gate.
+    requestTokens(symbol: String, typedData: Boolean, proof: String): String!
 
-    # Send signed phrase to faucet to obtain tokens.
+    # Send signed phrase to faucet to obtain tokens. Contract wallets are verified by EIP-1271.
     claimTokens(address: Address!, challenge: String!, signature: String!, erc20Address: Address): Boolean!
 
     # Generate challenge that should be signed by the user to obtain position.
-    mazeGameSession: String!
+    # If typedData is set, the challenge is wrapped into EIP-712 typed data JSON to be signed by eth_signTypedData_v4.
+    mazeGameSession(typedData: Boolean): String!
 
-    # Send signed challenge to obtain position.
+    # Send signed challenge to obtain position. Contract wallets are verified by EIP-1271.
     mazeMyPosition(address: Address!, challenge: String!, signature: String!, mazeAddress: Address!): MazePosition
+
+    # Get Sign-In with Ethereum (EIP-4361) message that should be signed by the user.
+    siweChallenge(address: Address!): String!
+
+    # Send signed sign-in message to obtain session token for the mutations below.
+    siweSignIn(message: String!, signature: String!): String!
+
+    # Claim tokens from faucet to the address of the sign-in session.
+    # The proof of passing the faucet gate is required by the gate the same way as by requestTokens.
+    claimTokensWithSession(session: String!, erc20Address: Address, proof: String): Boolean!
+
+    # Get position of the player of the sign-in session.
+    mazeMyPositionWithSession(session: String!, mazeAddress: Address!): MazePosition
+
+    # Override the idle state of the blockchain. Requires the admin role.
+    setIdleOverride(idle: Boolean!): Boolean!
 }
 
 `
@@ -1,33 +1,173 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"ftm-explorer/internal/api/graphql/cost"
 	"ftm-explorer/internal/api/graphql/resolvers"
 	"ftm-explorer/internal/api/graphql/schema"
+	"ftm-explorer/internal/config"
 	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/ratelimit"
+	"math"
 	"net/http"
 
 	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/graph-gophers/graphql-transport-ws/graphqlws"
 	"github.com/rs/cors"
 )
 
 // ApiHandler constructs and return the API HTTP handlers chain for serving GraphQL API calls.
-func ApiHandler(corsOrigins []string, resolver *resolvers.RootResolver, log logger.ILogger) http.Handler {
+func ApiHandler(cfg *config.ApiServer, resolver *resolvers.RootResolver, log logger.ILogger) http.Handler {
 	// Create new CORS handler and attach the logger into it, so we get information on Debug level if needed
-	corsHandler := cors.New(corsOptions(corsOrigins))
+	corsHandler := cors.New(corsOptions(cfg.CorsOrigin))
 
 	// we don't want to write a method for each type field if it could be matched directly
-	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
+	// the depth of queries is limited during the query validation, before the execution
+	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers(), graphql.MaxDepth(cfg.MaxQueryDepth)}
 
 	// create new parsed GraphQL schema
 	s := graphql.MustParseSchema(schema.Schema(), resolver, opts...)
 
+	// queries exceeding the cost budget are rejected before they reach the schema,
+	// both over HTTP and over websocket
+	h := loadersHandler(resolver, &relay.Handler{Schema: s})
+	svc := &operationLimitService{log: log, next: s}
+	if cfg.MaxQueryCost > 0 {
+		svc.limiter = &costLimiter{estimator: cost.NewEstimator(s.ASTSchema(), cfg.FieldCosts), maxCost: cfg.MaxQueryCost}
+		h = costLimitHandler(svc.limiter, h)
+	}
+
+	// websocket messages are limited to the size of HTTP requests instead of the 4 KiB default of the library,
+	// the rate limited client of the upgraded request is charged for each operation of the connection
+	wsHandler := graphqlws.NewHandlerFunc(svc, h,
+		graphqlws.WithReadLimit(cost.MaxRequestSize),
+		graphqlws.WithContextGenerator(graphqlws.ContextGeneratorFunc(func(ctx context.Context, r *http.Request) (context.Context, error) {
+			return ratelimit.CopyClient(ctx, r.Context()), nil
+		})),
+	)
+
 	// return the constructed API handler chain
 	return &LoggingHandler{
 		log:     log,
-		handler: corsHandler.Handler(graphqlws.NewHandlerFunc(s, &relay.Handler{Schema: s})),
+		handler: corsHandler.Handler(wsHandler),
+	}
+}
+
+// graphQLService represents the GraphQL service executing operations received over websocket.
+type graphQLService interface {
+	Subscribe(ctx context.Context, query string, operationName string, variables map[string]interface{}) (<-chan interface{}, error)
+}
+
+// costLimiter rejects GraphQL operations with the estimated cost exceeding the budget.
+type costLimiter struct {
+	estimator *cost.Estimator
+	maxCost   int
+}
+
+// check returns an error if the estimated cost of the given operation exceeds the budget.
+func (cl *costLimiter) check(doc *cost.Document, operationName string) *errors.QueryError {
+	queryCost := cl.estimator.Estimate(doc, operationName)
+	if queryCost <= cl.maxCost {
+		return nil
 	}
+
+	qe := errors.Errorf("query cost %d exceeds the maximum allowed cost %d", queryCost, cl.maxCost)
+	qe.Extensions = map[string]interface{}{"code": "QUERY_COST_EXCEEDED", "cost": queryCost, "maxCost": cl.maxCost}
+	return qe
+}
+
+// costLimitHandler rejects GraphQL requests with the estimated cost exceeding the budget.
+// The request parsed by the rate limiter is reused, if there is one.
+func costLimitHandler(limiter *costLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, r, err := cost.ReadRequest(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// queries which can not be estimated are rejected, requests without a query
+		// are left to be rejected by the GraphQL handler
+		if req.ParseErr != nil {
+			writeGraphQLErrors(w, errors.Errorf("failed to parse query; %v", req.ParseErr))
+			return
+		}
+		if req.Doc != nil {
+			if qe := limiter.check(req.Doc, req.OperationName); qe != nil {
+				writeGraphQLErrors(w, qe)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// operationLimitService limits GraphQL operations received over websocket. Each operation is charged
+// to the rate limit budget of its type and operations with the estimated cost exceeding the budget
+// are rejected. The rejection is sent as the only response of the operation.
+type operationLimitService struct {
+	log logger.ILogger
+	// limiter is the cost limiter, it is nil if the cost is not limited
+	limiter *costLimiter
+	next    graphQLService
+}
+
+// Subscribe executes the given operation if it fits the rate limit and the cost budget.
+// Queries which can not be parsed are rejected.
+func (ol *operationLimitService) Subscribe(ctx context.Context, query string, operationName string, variables map[string]interface{}) (<-chan interface{}, error) {
+	doc, err := cost.Parse(query)
+	if err != nil {
+		return rejectOperation(errors.Errorf("failed to parse query; %v", err)), nil
+	}
+
+	// fail open if the store is not available, the api should stay usable
+	kind, _ := cost.OperationType(doc, operationName)
+	res, err := ratelimit.TakeFromContext(ctx, ratelimit.OperationBudget(kind))
+	if err != nil {
+		ol.log.Warningf("failed to take rate limit token; %v", err)
+	} else if !res.Allowed {
+		qe := errors.Errorf("rate limit exceeded")
+		qe.Extensions = map[string]interface{}{"code": "RATE_LIMITED", "retryAfter": int(math.Ceil(res.RetryAfter.Seconds()))}
+		return rejectOperation(qe), nil
+	}
+
+	if ol.limiter != nil {
+		if qe := ol.limiter.check(doc, operationName); qe != nil {
+			return rejectOperation(qe), nil
+		}
+	}
+	return ol.next.Subscribe(ctx, query, operationName, variables)
+}
+
+// rejectOperation returns a websocket operation responses channel with the given error as the only response.
+func rejectOperation(qe *errors.QueryError) <-chan interface{} {
+	c := make(chan interface{}, 1)
+	c <- &graphql.Response{Errors: []*errors.QueryError{qe}}
+	close(c)
+	return c
+}
+
+// writeGraphQLErrors writes a GraphQL response containing only the given errors.
+func writeGraphQLErrors(w http.ResponseWriter, errs ...*errors.QueryError) {
+	responseJSON, err := json.Marshal(&graphql.Response{Errors: errs})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(responseJSON)
+}
+
+// loadersHandler attaches a fresh set of request scoped data loaders to each request,
+// so that the resolvers can share and deduplicate fetches within the request.
+func loadersHandler(resolver *resolvers.RootResolver, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(resolver.WithLoaders(r.Context())))
+	})
 }
 
 // corsOptions constructs new set of options for the CORS handler based on provided configuration.
@@ -35,7 +175,7 @@ func corsOptions(corsOrigins []string) cors.Options {
 	return cors.Options{
 		AllowedOrigins: corsOrigins,
 		AllowedMethods: []string{"HEAD", "GET", "POST"},
-		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With"},
+		AllowedHeaders: []string{"Origin", "Accept", "Authorization", "Content-Type", "X-Requested-With"},
 		MaxAge:         300,
 	}
 }
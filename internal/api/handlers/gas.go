@@ -5,33 +5,45 @@ import (
 	"ftm-explorer/internal/logger"
 	"ftm-explorer/internal/repository"
 	"math"
+	"math/big"
 	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
-// GasPriceHandler returns the current gas price.
+// GasPriceHandler returns the current gas price estimate of the gas price oracle.
+// The prices are in tenths of gwei, as the ETH Gas Station API.
 func GasPriceHandler(repo repository.IRepository, log logger.ILogger) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		gasPrice, err := repo.SuggestGasPrice()
+		gp, err := repo.GetGasPrice()
 		if err != nil {
 			log.Errorf("failed to get gas price: %v", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		price := math.Round(float64(gasPrice.Int64())/float64(10000000)) / 10.0
-
 		resStruct := struct {
-			// Fast is +10% of the average
-			Fast float64 `json:"fast"`
-			// Fastest is +20% of the average
-			Fastest float64 `json:"fastest"`
-			SafeLow float64 `json:"safeLow"`
-			Average float64 `json:"average"`
+			Fast        float64  `json:"fast"`
+			Fastest     float64  `json:"fastest"`
+			SafeLow     float64  `json:"safeLow"`
+			Average     float64  `json:"average"`
+			BaseFee     *float64 `json:"baseFee,omitempty"`
+			PriorityFee *float64 `json:"priorityFee,omitempty"`
+			BlockNumber uint64   `json:"blockNum"`
 		}{
-			Fast:    math.Round(price*11) / 10.0,
-			Fastest: math.Round(price*12) / 10.0,
-			SafeLow: price,
-			Average: price,
+			Fast:        gasPriceUnits(&gp.Fast),
+			Fastest:     gasPriceUnits(&gp.Fastest),
+			SafeLow:     gasPriceUnits(&gp.SafeLow),
+			Average:     gasPriceUnits(&gp.Average),
+			BlockNumber: uint64(gp.BlockNumber),
+		}
+		if gp.BaseFee != nil {
+			baseFee := gasPriceUnits(gp.BaseFee)
+			resStruct.BaseFee = &baseFee
+		}
+		if gp.PriorityFee != nil {
+			priorityFee := gasPriceUnits(gp.PriorityFee)
+			resStruct.PriorityFee = &priorityFee
 		}
 
 		res, err := json.Marshal(resStruct)
@@ -45,3 +57,9 @@ func GasPriceHandler(repo repository.IRepository, log logger.ILogger) func(http.
 		_, _ = w.Write(res)
 	}
 }
+
+// gasPriceUnits converts the given gas price in wei to tenths of gwei rounded to one decimal.
+func gasPriceUnits(price *hexutil.Big) float64 {
+	units, _ := new(big.Float).Quo(new(big.Float).SetInt(price.ToInt()), big.NewFloat(1e7)).Float64()
+	return math.Round(units) / 10.0
+}
@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"ftm-explorer/internal/auth"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"net/http"
+	"strings"
+)
+
+// kApiKeyScheme is the authorization scheme of requests authenticated by an api key.
+const kApiKeyScheme = "Bearer"
+
+// ApiKeyMiddleware defines HTTP handler middleware resolving the api key carried in the Authorization
+// header into the principal of the request context. Requests without the header are anonymous,
+// requests with an unknown or revoked key are rejected.
+func ApiKeyMiddleware(repo repository.IRepository, log logger.ILogger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scheme, key, found := strings.Cut(header, " ")
+		key = strings.TrimSpace(key)
+		if !found || !strings.EqualFold(scheme, kApiKeyScheme) || key == "" {
+			http.Error(w, "invalid authorization header", http.StatusUnauthorized)
+			return
+		}
+
+		apiKey, err := repo.UseApiKey(auth.HashApiKey(key))
+		if err != nil {
+			log.Errorf("failed to verify api key; %v", err)
+			http.Error(w, "api key can not be verified", http.StatusServiceUnavailable)
+			return
+		}
+		if apiKey == nil {
+			http.Error(w, "invalid api key", http.StatusUnauthorized)
+			return
+		}
+
+		principal := &auth.Principal{Name: apiKey.Name, Roles: apiKey.Roles}
+		next.ServeHTTP(w, r.WithContext(auth.SetPrincipal(r.Context(), principal)))
+	})
+}
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"fmt"
+	"ftm-explorer/internal/auth"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"ftm-explorer/internal/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// Test that api keys are resolved into the principal of the request.
+func TestApiKeyMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockRepository.EXPECT().UseApiKey(auth.HashApiKey("valid")).Return(&types.ApiKey{Name: "holder", Roles: []string{auth.RoleAdmin}}, nil).AnyTimes()
+	mockRepository.EXPECT().UseApiKey(auth.HashApiKey("unknown")).Return(nil, nil).AnyTimes()
+	mockRepository.EXPECT().UseApiKey(auth.HashApiKey("failing")).Return(nil, fmt.Errorf("db down")).AnyTimes()
+
+	var principal *auth.Principal
+	handler := ApiKeyMiddleware(mockRepository, logger.NewMockLogger(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = auth.GetPrincipal(r.Context())
+	}))
+
+	testCases := []struct {
+		name      string
+		header    string
+		status    int
+		principal string
+	}{
+		{"Anonymous", "", http.StatusOK, ""},
+		{"ValidKey", "Bearer valid", http.StatusOK, "holder"},
+		{"CaseInsensitiveScheme", "bearer valid", http.StatusOK, "holder"},
+		{"UnknownKey", "Bearer unknown", http.StatusUnauthorized, ""},
+		{"UnsupportedScheme", "Basic valid", http.StatusUnauthorized, ""},
+		{"MissingKey", "Bearer ", http.StatusUnauthorized, ""},
+		{"StoreFailure", "Bearer failing", http.StatusServiceUnavailable, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			principal = nil
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, rec.Code)
+			}
+			if tc.principal == "" && principal != nil {
+				t.Errorf("expected anonymous request, got principal %s", principal.Name)
+			}
+			if tc.principal != "" && (principal == nil || principal.Name != tc.principal || !principal.HasRole(auth.RoleAdmin)) {
+				t.Errorf("expected principal %s with admin role, got %+v", tc.principal, principal)
+			}
+		})
+	}
+}
@@ -1,33 +1,178 @@
 package middlewares
 
 import (
+	"fmt"
 	"ftm-explorer/internal/auth"
 	"net"
 	"net/http"
+	"net/netip"
 	"strings"
 )
 
-// AuthMiddleware defines HTTP handler middleware for logging incoming communication through provided ILogger.
-func AuthMiddleware(next http.Handler) http.Handler {
+// TrustedProxies represents networks of reverse proxies trusted to report the client address.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses the given list of trusted proxy CIDRs.
+// Plain ip addresses are accepted as single address networks.
+func ParseTrustedProxies(cidrs []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %s; %v", cidr, err)
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s; %v", cidr, err)
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+// contains returns true if the given address belongs to a trusted proxy.
+func (tp TrustedProxies) contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range tp {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Client ip address headers, which can be set by the trusted proxies.
+const (
+	// ClientIpHeaderForwarded is the RFC 7239 Forwarded header.
+	ClientIpHeaderForwarded = "Forwarded"
+
+	// ClientIpHeaderForwardedFor is the X-Forwarded-For header.
+	ClientIpHeaderForwardedFor = "X-Forwarded-For"
+
+	// ClientIpHeaderRealIp is the X-Real-IP header.
+	ClientIpHeaderRealIp = "X-Real-Ip"
+)
+
+// ParseClientIpHeader validates the name of the header the trusted proxies report the client address in
+// and returns it in the canonical form.
+func ParseClientIpHeader(name string) (string, error) {
+	header := http.CanonicalHeaderKey(strings.TrimSpace(name))
+	switch header {
+	case ClientIpHeaderForwarded, ClientIpHeaderForwardedFor, ClientIpHeaderRealIp:
+		return header, nil
+	default:
+		return "", fmt.Errorf("unsupported client ip header %s", name)
+	}
+}
+
+// AuthMiddleware defines HTTP handler middleware resolving the client ip address into the request context.
+// Only the given forwarding header is honoured and only for requests coming from the trusted proxies,
+// any other forwarding header may be set by the client and is ignored.
+func AuthMiddleware(proxies TrustedProxies, header string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		next.ServeHTTP(w, r.WithContext(auth.SetIpAddress(r.Context(), getIP(r))))
+		next.ServeHTTP(w, r.WithContext(auth.SetIpAddress(r.Context(), getIP(r, proxies, header))))
 	})
 }
 
-func getIP(req *http.Request) string {
-	// Try to get the IP address from the X-Forwarded-For header
-	if fwdAddress := req.Header.Get("X-Forwarded-For"); fwdAddress != "" {
-		// The header can contain multiple IP addresses, so take the first one
-		split := strings.Split(fwdAddress, ",")
-		if len(split) > 0 {
-			return split[0]
+// getIP returns the ip address of the client. The forwarding chain of the given header is walked
+// from right to left, starting with the direct connection's remote address, and the first hop
+// which is not a trusted proxy is the client. Each hop is appended by the proxy on its right,
+// so only the hops appended by trusted proxies are taken into account.
+func getIP(req *http.Request, proxies TrustedProxies, header string) string {
+	remote, err := parseAddr(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr // This might not be a perfect IP:port format, but it's something
+	}
+
+	// the direct peer is the client unless it is a trusted proxy
+	if !proxies.contains(remote) {
+		return remote.String()
+	}
+
+	hops := forwardedHops(req, header)
+	client := remote.String()
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := parseAddr(hops[i])
+		if err != nil {
+			// the trusted proxy does not know the address of its peer, e.g. "unknown"
+			// or an obfuscated identifier; the identifier is the client, not the proxy
+			return hops[i]
+		}
+		client = hop.String()
+		if !proxies.contains(hop) {
+			break
+		}
+	}
+
+	return client
+}
+
+// forwardedHops returns the addresses of the forwarding chain reported in the given header,
+// ordered from the client to the last proxy.
+func forwardedHops(req *http.Request, header string) []string {
+	hops := make([]string, 0)
+
+	switch header {
+	case ClientIpHeaderForwarded:
+		// Forwarded: for=192.0.2.60;proto=http;by=203.0.113.43, for="[2001:db8::1]:4711"
+		for _, value := range req.Header.Values(header) {
+			for _, element := range strings.Split(value, ",") {
+				for _, pair := range strings.Split(element, ";") {
+					key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+					if found && strings.EqualFold(key, "for") && strings.Trim(value, `"`) != "" {
+						hops = append(hops, strings.Trim(value, `"`))
+					}
+				}
+			}
+		}
+	case ClientIpHeaderForwardedFor:
+		// X-Forwarded-For: client, proxy1, proxy2
+		for _, value := range req.Header.Values(header) {
+			for _, hop := range strings.Split(value, ",") {
+				if hop = strings.TrimSpace(hop); hop != "" {
+					hops = append(hops, hop)
+				}
+			}
+		}
+	case ClientIpHeaderRealIp:
+		// X-Real-IP: client; the proxy replaces the header, so only the last one is its own
+		if values := req.Header.Values(header); len(values) > 0 {
+			if realIp := strings.TrimSpace(values[len(values)-1]); realIp != "" {
+				hops = append(hops, realIp)
+			}
 		}
 	}
+	return hops
+}
 
-	// If the above header doesn't exist, then fall back to the direct connection's remote address
-	ip, _, err := net.SplitHostPort(req.RemoteAddr)
+// parseAddr parses the given ip address, optionally with a port.
+func parseAddr(value string) (netip.Addr, error) {
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	addr, err := netip.ParseAddr(strings.Trim(value, "[]"))
 	if err != nil {
-		return req.RemoteAddr // This might not be a perfect IP:port format, but it's something
+		return netip.Addr{}, err
+	}
+	return addr.Unmap().WithZone(""), nil
+}
+
+// clientKey returns the key identifying the client with the given ip address.
+// IPv6 clients usually get a whole /64 network, so the addresses are normalized
+// to their /64 prefix to prevent bypassing limits by rotating addresses.
+func clientKey(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || !addr.Is6() || addr.Is4In6() {
+		return ip
+	}
+	prefix, err := addr.Prefix(64)
+	if err != nil {
+		return ip
 	}
-	return ip
+	return prefix.String()
 }
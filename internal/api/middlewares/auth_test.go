@@ -0,0 +1,101 @@
+package middlewares
+
+import (
+	"net/http"
+	"testing"
+)
+
+// Test that the client ip address is resolved through trusted proxies only.
+func TestAuthMiddleware_GetIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"})
+	if err != nil {
+		t.Fatalf("failed to parse trusted proxies: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		header   string
+		remote   string
+		headers  map[string]string
+		expected string
+	}{
+		{"UntrustedPeer", ClientIpHeaderForwardedFor, "1.2.3.4:5000", map[string]string{"X-Forwarded-For": "5.6.7.8"}, "1.2.3.4"},
+		{"TrustedPeerNoHeaders", ClientIpHeaderForwardedFor, "10.0.0.1:5000", nil, "10.0.0.1"},
+		{"ForwardedFor", ClientIpHeaderForwardedFor, "10.0.0.1:5000", map[string]string{"X-Forwarded-For": "5.6.7.8"}, "5.6.7.8"},
+		{"ForwardedForSpoofed", ClientIpHeaderForwardedFor, "10.0.0.1:5000", map[string]string{"X-Forwarded-For": "9.9.9.9, 5.6.7.8, 192.168.1.1"}, "5.6.7.8"},
+		{"ForwardedForAllTrusted", ClientIpHeaderForwardedFor, "10.0.0.1:5000", map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"}, "10.0.0.3"},
+		{"ForwardedForInvalidHop", ClientIpHeaderForwardedFor, "10.0.0.1:5000", map[string]string{"X-Forwarded-For": "5.6.7.8, garbage, 10.0.0.2"}, "garbage"},
+		{"ForwardedForEmptyHop", ClientIpHeaderForwardedFor, "10.0.0.1:5000", map[string]string{"X-Forwarded-For": "5.6.7.8, , 10.0.0.2"}, "5.6.7.8"},
+		{"Forwarded", ClientIpHeaderForwarded, "10.0.0.1:5000", map[string]string{"Forwarded": `for=5.6.7.8;proto=http, for="[fd00::1]:4711"`}, "5.6.7.8"},
+		{"ForwardedIPv6", ClientIpHeaderForwarded, "10.0.0.1:5000", map[string]string{"Forwarded": `For="[2001:db8::1]:4711"`}, "2001:db8::1"},
+		{"ForwardedUnknown", ClientIpHeaderForwarded, "10.0.0.1:5000", map[string]string{"Forwarded": "for=unknown"}, "unknown"},
+		{"RealIP", ClientIpHeaderRealIp, "10.0.0.1:5000", map[string]string{"X-Real-IP": "5.6.7.8"}, "5.6.7.8"},
+		{"IPv4Mapped", ClientIpHeaderRealIp, "[::ffff:10.0.0.1]:5000", map[string]string{"X-Real-IP": "5.6.7.8"}, "5.6.7.8"},
+
+		// the client injects other forwarding headers than the one set by the proxy
+		{"InjectedForwarded", ClientIpHeaderForwardedFor, "10.0.0.1:5000", map[string]string{"Forwarded": "for=1.2.3.4", "X-Forwarded-For": "5.6.7.8"}, "5.6.7.8"},
+		{"InjectedRealIP", ClientIpHeaderForwardedFor, "10.0.0.1:5000", map[string]string{"X-Real-IP": "1.2.3.4", "X-Forwarded-For": "5.6.7.8"}, "5.6.7.8"},
+		{"InjectedForwardedFor", ClientIpHeaderRealIp, "10.0.0.1:5000", map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Real-IP": "5.6.7.8"}, "5.6.7.8"},
+		{"InjectedForwardedNoProxyHeader", ClientIpHeaderRealIp, "10.0.0.1:5000", map[string]string{"Forwarded": "for=1.2.3.4"}, "10.0.0.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.RemoteAddr = tc.remote
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			if ip := getIP(req, proxies, tc.header); ip != tc.expected {
+				t.Errorf("expected ip %s, got %s", tc.expected, ip)
+			}
+		})
+	}
+}
+
+// Test that only the supported client ip headers are accepted.
+func TestAuthMiddleware_ParseClientIpHeader(t *testing.T) {
+	testCases := map[string]string{
+		"Forwarded":       ClientIpHeaderForwarded,
+		"x-forwarded-for": ClientIpHeaderForwardedFor,
+		"X-Real-IP":       ClientIpHeaderRealIp,
+	}
+	for name, expected := range testCases {
+		header, err := ParseClientIpHeader(name)
+		if err != nil || header != expected {
+			t.Errorf("expected header %s for %s, got %s; %v", expected, name, header, err)
+		}
+	}
+	for _, name := range []string{"", "X-Client-IP"} {
+		if _, err := ParseClientIpHeader(name); err == nil {
+			t.Errorf("expected error for client ip header %q", name)
+		}
+	}
+}
+
+// Test that invalid trusted proxies are rejected.
+func TestAuthMiddleware_ParseTrustedProxies(t *testing.T) {
+	for _, cidr := range []string{"10.0.0.0/33", "not-an-ip", ""} {
+		if _, err := ParseTrustedProxies([]string{cidr}); err == nil {
+			t.Errorf("expected error for trusted proxy %q", cidr)
+		}
+	}
+}
+
+// Test that IPv6 clients are keyed by their /64 network.
+func TestAuthMiddleware_ClientKey(t *testing.T) {
+	testCases := map[string]string{
+		"5.6.7.8":              "5.6.7.8",
+		"2001:db8:1:2:3:4:5:6": "2001:db8:1:2::/64",
+		"2001:db8:1:2:ffff::1": "2001:db8:1:2::/64",
+		"not-an-ip":            "not-an-ip",
+	}
+	for ip, expected := range testCases {
+		if key := clientKey(ip); key != expected {
+			t.Errorf("expected key %s for %s, got %s", expected, ip, key)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"ftm-explorer/internal/api/graphql/cost"
+	"ftm-explorer/internal/auth"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/ratelimit"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RateLimitMiddleware defines HTTP handler middleware limiting the rate of requests of each client.
+// Queries, mutations and subscriptions are limited by separate budgets. Websocket upgrades are not
+// charged, the client is attached to the request context instead, so that each operation received
+// over the connection is charged by the GraphQL handler.
+// The client is identified by the api key if present, otherwise by the ip address, so the middleware
+// must be wrapped by AuthMiddleware and ApiKeyMiddleware. IPv6 clients share the budget of their /64 network.
+func RateLimitMiddleware(limiter *ratelimit.Limiter, log logger.ILogger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, err := rateLimitClient(r)
+		if err != nil {
+			log.Errorf("rate limit client not identified; %v", err)
+			http.Error(w, "client not identified", http.StatusBadRequest)
+			return
+		}
+
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			next.ServeHTTP(w, r.WithContext(ratelimit.SetClient(r.Context(), limiter, client)))
+			return
+		}
+
+		// the parsed request is passed to the next handlers, so the body is parsed only once
+		budget, r, err := requestBudget(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// fail open if the store is not available, the api should stay usable
+		res, err := limiter.Take(budget, client)
+		if err != nil {
+			log.Warningf("failed to take rate limit token of %s; %v", client, err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !res.Allowed {
+			retryAfter := int(math.Ceil(res.RetryAfter.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitClient returns the key identifying the client of the given request.
+func rateLimitClient(r *http.Request) (string, error) {
+	if p := auth.GetPrincipal(r.Context()); p != nil {
+		return "key:" + p.Name, nil
+	}
+	ip, err := auth.GetIpOrErr(r.Context())
+	if err != nil {
+		return "", err
+	}
+	return clientKey(ip), nil
+}
+
+// requestBudget returns the rate limit budget the given request is charged to, together with
+// the request carrying the parsed GraphQL request in its context.
+func requestBudget(w http.ResponseWriter, r *http.Request) (ratelimit.Budget, *http.Request, error) {
+	req, r, err := cost.ReadRequest(w, r)
+	if err != nil {
+		return "", r, err
+	}
+
+	// requests with unknown operation type are charged as queries, they fail later anyway
+	if req.Doc == nil {
+		return ratelimit.BudgetQuery, r, nil
+	}
+	kind, _ := cost.OperationType(req.Doc, req.OperationName)
+	return ratelimit.OperationBudget(kind), r, nil
+}
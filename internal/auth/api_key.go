@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	// kApiKeyPrefix is the prefix of generated api keys, it makes them easy to recognize.
+	kApiKeyPrefix = "ftmx_"
+
+	// kApiKeyLength is the number of random bytes of generated api keys.
+	kApiKeyLength = 32
+)
+
+// GenerateApiKey generates a new random api key.
+func GenerateApiKey() (string, error) {
+	buf := make([]byte, kApiKeyLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate api key; %v", err)
+	}
+	return kApiKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// HashApiKey returns the hash of the given api key, which is stored instead of the key.
+// Keys are random with high entropy, so a plain SHA-256 hash is sufficient.
+func HashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// Test that generated api keys are unique and hashed deterministically.
+func TestApiKey_GenerateAndHash(t *testing.T) {
+	first, err := GenerateApiKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := GenerateApiKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(first, kApiKeyPrefix) || len(first) != len(kApiKeyPrefix)+2*kApiKeyLength {
+		t.Errorf("unexpected api key format: %s", first)
+	}
+	if first == second {
+		t.Errorf("expected unique api keys")
+	}
+	if HashApiKey(first) != HashApiKey(first) || HashApiKey(first) == HashApiKey(second) {
+		t.Errorf("expected deterministic and distinct hashes")
+	}
+}
+
+// Test that roles are required from the principal of the context.
+func TestApiKey_RequireRole(t *testing.T) {
+	if err := RequireRole(context.Background(), RoleAdmin); err == nil {
+		t.Errorf("expected error for anonymous context")
+	}
+	if err := RequireRole(SetPrincipal(context.Background(), &Principal{Name: "user"}), RoleAdmin); err == nil {
+		t.Errorf("expected error for principal without role")
+	}
+	if err := RequireRole(SetPrincipal(context.Background(), &Principal{Name: "admin", Roles: []string{RoleAdmin}}), RoleAdmin); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
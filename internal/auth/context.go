@@ -28,3 +28,55 @@ func GetIpOrErr(ctx context.Context) (string, error) {
 	}
 	return ip, nil
 }
+
+// kAuthPrincipalContextKeyName is the name of the principal field
+// in the context values.
+const kAuthPrincipalContextKeyName = "principal"
+
+// RoleAdmin is the role allowing administrative operations.
+const RoleAdmin = "admin"
+
+// Principal represents an authenticated api key holder.
+type Principal struct {
+	// Name is the unique name of the api key holder.
+	Name string
+
+	// Roles is the list of roles granted to the api key holder.
+	Roles []string
+}
+
+// HasRole returns true if the principal has been granted the given role.
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPrincipal adds the given principal to the provided context
+// returning a new derived context.
+func SetPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, kAuthPrincipalContextKeyName, p)
+}
+
+// GetPrincipal extracts the authenticated principal from the provided context.
+// It returns nil if the request is anonymous.
+func GetPrincipal(ctx context.Context) *Principal {
+	p, _ := ctx.Value(kAuthPrincipalContextKeyName).(*Principal)
+	return p
+}
+
+// RequireRole returns an error if the principal of the provided context
+// has not been granted the given role.
+func RequireRole(ctx context.Context, role string) error {
+	p := GetPrincipal(ctx)
+	if p == nil {
+		return fmt.Errorf("authentication required")
+	}
+	if !p.HasRole(role) {
+		return fmt.Errorf("role %s required", role)
+	}
+	return nil
+}
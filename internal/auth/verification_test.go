@@ -3,11 +3,12 @@ package auth
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-// Test that signature verification works
-func TestVerification_VerifySignature(t *testing.T) {
+// Test that the signer of a personal_sign signature is recovered.
+func TestVerification_EcRecover(t *testing.T) {
 	// Decode the private key from hex
 	privateKey, err := crypto.HexToECDSA("bb39aa88008bc6260ff9ebc816178c47a01c44efe55810ea1f271c00f5878812")
 	if err != nil {
@@ -20,12 +21,20 @@ func TestVerification_VerifySignature(t *testing.T) {
 	// sign the message
 	message := "\"Sign following challenge:\n test"
 	signature, err := SignMessage(message, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	result, err := VerifySignature(message, derivedAddress, signature)
+	recovered, err := ecRecover(accounts.TextHash([]byte(message)), signature)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !result {
-		t.Fatal("signature verification failed")
+	if recovered != derivedAddress {
+		t.Fatalf("expected signer %s, got %s", derivedAddress.Hex(), recovered.Hex())
+	}
+
+	// the signature is not modified by the recovery
+	if signature[crypto.RecoveryIDOffset] < 27 {
+		t.Fatal("signature modified by the recovery")
 	}
 }
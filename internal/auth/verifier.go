@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	// kTypedChallengeDomainName is the EIP-712 domain name of typed challenges.
+	kTypedChallengeDomainName = "Fantom Explorer"
+
+	// kTypedChallengeDomainVersion is the EIP-712 domain version of typed challenges.
+	kTypedChallengeDomainVersion = "1"
+
+	// kTypedChallengePrimaryType is the EIP-712 primary type of typed challenges.
+	kTypedChallengePrimaryType = "Challenge"
+)
+
+// IVerifierBackend provides the chain access needed to verify signatures
+// of smart-contract wallets and to bind typed challenges to the network.
+type IVerifierBackend interface {
+	// IsValidSignature verifies the signature of the given hash by the EIP-1271 call of the given contract.
+	IsValidSignature(common.Address, common.Hash, []byte) (bool, error)
+
+	// NetworkID returns the network ID.
+	NetworkID() (*big.Int, error)
+}
+
+// Verifier verifies challenge signatures of externally owned accounts
+// and of smart-contract wallets (EIP-1271). Challenges are signed either
+// as plain text (personal_sign) or as EIP-712 typed data (eth_signTypedData_v4).
+type Verifier struct {
+	backend IVerifierBackend
+	chainId atomic.Pointer[big.Int]
+}
+
+// NewVerifier creates a new signature verifier using the given backend.
+func NewVerifier(backend IVerifierBackend) *Verifier {
+	return &Verifier{backend: backend}
+}
+
+// TypedChallenge wraps the given challenge into EIP-712 typed data
+// and returns its JSON encoding to be signed by eth_signTypedData_v4.
+func (v *Verifier) TypedChallenge(challenge string) (string, error) {
+	td, err := v.typedChallenge(challenge)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(td)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode typed challenge; %v", err)
+	}
+	return string(data), nil
+}
+
+// VerifyChallenge verifies the signature of the given challenge was made by the given address
+// and returns the plain challenge text. The challenge is either the plain text itself,
+// or the typed data issued by TypedChallenge.
+func (v *Verifier) VerifyChallenge(challenge string, address common.Address, signature []byte) (string, error) {
+	text, hash, err := v.challengeHash(challenge)
+	if err != nil {
+		return "", err
+	}
+	ok, err := v.VerifyHash(hash, address, signature)
+	if err != nil {
+		return "", fmt.Errorf("signature verification failed; %v", err)
+	}
+	if !ok {
+		return "", fmt.Errorf("signature verification failed; signature does not match the address")
+	}
+	return text, nil
+}
+
+// VerifyMessage verifies the personal_sign signature of the given message was made by the given address.
+func (v *Verifier) VerifyMessage(message string, address common.Address, signature []byte) (bool, error) {
+	return v.VerifyHash(common.BytesToHash(accounts.TextHash([]byte(message))), address, signature)
+}
+
+// VerifyHash verifies the signature of the given hash was made by the given address.
+// Signatures recovering to the address are accepted right away, any other signature
+// is checked by the EIP-1271 call, which fails for accounts without code.
+func (v *Verifier) VerifyHash(hash common.Hash, address common.Address, signature []byte) (bool, error) {
+	recovered, err := ecRecover(hash.Bytes(), signature)
+	if err == nil && bytes.Equal(recovered.Bytes(), address.Bytes()) {
+		return true, nil
+	}
+	return v.backend.IsValidSignature(address, hash, signature)
+}
+
+// challengeHash returns the plain text and the signed hash of the given challenge.
+func (v *Verifier) challengeHash(challenge string) (string, common.Hash, error) {
+	if !strings.HasPrefix(strings.TrimSpace(challenge), "{") {
+		return challenge, common.BytesToHash(accounts.TextHash([]byte(challenge))), nil
+	}
+
+	var td apitypes.TypedData
+	if err := json.Unmarshal([]byte(challenge), &td); err != nil {
+		return "", common.Hash{}, fmt.Errorf("invalid typed challenge; %v", err)
+	}
+	text, ok := td.Message["challenge"].(string)
+	if !ok {
+		return "", common.Hash{}, fmt.Errorf("invalid typed challenge; missing challenge")
+	}
+
+	// only the typed data issued by us is accepted, so the signature
+	// can not be replayed from a different domain or network
+	expected, err := v.typedChallenge(text)
+	if err != nil {
+		return "", common.Hash{}, err
+	}
+	expectedHash, _, err := apitypes.TypedDataAndHash(expected)
+	if err != nil {
+		return "", common.Hash{}, fmt.Errorf("failed to hash typed challenge; %v", err)
+	}
+	hash, _, err := apitypes.TypedDataAndHash(td)
+	if err != nil {
+		return "", common.Hash{}, fmt.Errorf("invalid typed challenge; %v", err)
+	}
+	if !bytes.Equal(hash, expectedHash) {
+		return "", common.Hash{}, fmt.Errorf("invalid typed challenge; domain or types mismatch")
+	}
+	return text, common.BytesToHash(hash), nil
+}
+
+// typedChallenge builds the EIP-712 typed data of the given challenge.
+func (v *Verifier) typedChallenge(challenge string) (apitypes.TypedData, error) {
+	chainId, err := v.chainID()
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			kTypedChallengePrimaryType: {
+				{Name: "challenge", Type: "string"},
+			},
+		},
+		PrimaryType: kTypedChallengePrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:    kTypedChallengeDomainName,
+			Version: kTypedChallengeDomainVersion,
+			ChainId: (*math.HexOrDecimal256)(chainId),
+		},
+		Message: apitypes.TypedDataMessage{
+			"challenge": challenge,
+		},
+	}, nil
+}
+
+// chainID returns the chain id typed challenges are bound to.
+// The network id never changes, so it is loaded only once.
+func (v *Verifier) chainID() (*big.Int, error) {
+	if id := v.chainId.Load(); id != nil {
+		return id, nil
+	}
+	id, err := v.backend.NetworkID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network id; %v", err)
+	}
+	v.chainId.Store(id)
+	return id, nil
+}
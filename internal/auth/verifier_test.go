@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// testBackend is a verifier backend with a single contract wallet owned by the given key.
+type testBackend struct {
+	wallet common.Address
+	owner  common.Address
+}
+
+// IsValidSignature accepts signatures of the owner for the wallet, like EIP-1271 wallets do.
+func (b *testBackend) IsValidSignature(contract common.Address, hash common.Hash, signature []byte) (bool, error) {
+	if contract != b.wallet {
+		return false, nil
+	}
+	signer, err := ecRecover(hash.Bytes(), signature)
+	return err == nil && signer == b.owner, nil
+}
+
+// NetworkID returns the testnet network id.
+func (b *testBackend) NetworkID() (*big.Int, error) {
+	return big.NewInt(4002), nil
+}
+
+// createVerifier creates a verifier with a contract wallet owned by the test key.
+func createVerifier(t *testing.T) (*Verifier, *ecdsa.PrivateKey, common.Address) {
+	key, err := crypto.HexToECDSA("bb39aa88008bc6260ff9ebc816178c47a01c44efe55810ea1f271c00f5878812")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wallet := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	return NewVerifier(&testBackend{wallet: wallet, owner: crypto.PubkeyToAddress(key.PublicKey)}), key, wallet
+}
+
+// signTypedData signs the given typed data JSON like eth_signTypedData_v4 does.
+func signTypedData(t *testing.T, data string, key *ecdsa.PrivateKey) []byte {
+	var td apitypes.TypedData
+	if err := json.Unmarshal([]byte(data), &td); err != nil {
+		t.Fatal(err)
+	}
+	hash, _, err := apitypes.TypedDataAndHash(td)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := crypto.Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature[64] += 27
+	return signature
+}
+
+// Test that plain challenges are verified for accounts and contract wallets.
+func TestVerifier_VerifyChallenge(t *testing.T) {
+	v, key, wallet := createVerifier(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	otherKey, _ := crypto.GenerateKey()
+
+	challenge := "Sign following challenge:\n test"
+	signature, err := SignMessage(challenge, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSignature, err := SignMessage(challenge, otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, addr := range []common.Address{address, wallet} {
+		text, err := v.VerifyChallenge(challenge, addr, signature)
+		if err != nil || text != challenge {
+			t.Errorf("expected valid signature for %s, got %q; %v", addr, text, err)
+		}
+		if _, err := v.VerifyChallenge(challenge, addr, otherSignature); err == nil {
+			t.Errorf("expected error for signature of other key for %s", addr)
+		}
+	}
+}
+
+// Test that typed challenges are verified and only the issued typed data is accepted.
+func TestVerifier_VerifyTypedChallenge(t *testing.T) {
+	v, key, wallet := createVerifier(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	challenge := "Sign following challenge:\n test"
+	data, err := v.TypedChallenge(challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := signTypedData(t, data, key)
+
+	for _, addr := range []common.Address{address, wallet} {
+		text, err := v.VerifyChallenge(data, addr, signature)
+		if err != nil || text != challenge {
+			t.Errorf("expected valid signature for %s, got %q; %v", addr, text, err)
+		}
+	}
+
+	// typed data of other domain is rejected even if properly signed
+	var td apitypes.TypedData
+	if err := json.Unmarshal([]byte(data), &td); err != nil {
+		t.Fatal(err)
+	}
+	td.Domain.Name = "Other Dapp"
+	other, err := json.Marshal(td)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.VerifyChallenge(string(other), address, signTypedData(t, string(other), key)); err == nil {
+		t.Errorf("expected error for typed data of other domain")
+	}
+
+	// the plain text signature does not match the typed challenge
+	plainSignature, err := SignMessage(challenge, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.VerifyChallenge(data, address, plainSignature); err == nil {
+		t.Errorf("expected error for plain text signature of typed challenge")
+	}
+}
@@ -3,6 +3,9 @@ package buffer
 import (
 	"fmt"
 	"ftm-explorer/internal/types"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // BlocksBuffer represents a buffer of blocks. It is used to store blocks
@@ -11,8 +14,11 @@ import (
 // is added to the buffer. The buffer relies on the fact that the block
 // numbers are monotonically increasing. That means that the block with
 // the number N+1 is always added to the buffer after the block with the
-// number N. The buffer is not thread-safe.
+// number N. Blocks are also indexed by their hash and by hashes of their
+// transactions. The buffer is thread-safe.
 type BlocksBuffer struct {
+	// mtx guards access to the buffer data and indexes
+	mtx sync.RWMutex
 	// data is a slice of blocks
 	data []*types.Block
 	// capacity is the capacity of the buffer
@@ -21,6 +27,10 @@ type BlocksBuffer struct {
 	head uint
 	// size is the number of blocks in the buffer
 	size uint
+	// byHash maps block hash to the block
+	byHash map[common.Hash]*types.Block
+	// byTxHash maps transaction hash to the block containing the transaction
+	byTxHash map[common.Hash]*types.Block
 }
 
 // NewBlocksBuffer creates a new blocks buffer.
@@ -31,25 +41,38 @@ func NewBlocksBuffer(size uint) *BlocksBuffer {
 		capacity: size,
 		head:     0,
 		size:     0,
+		byHash:   make(map[common.Hash]*types.Block),
+		byTxHash: make(map[common.Hash]*types.Block),
 	}
 }
 
 // Get returns the block with the specified number.
 // If the block is not found, the second return value is false.
 func (bb *BlocksBuffer) Get(number uint64) (*types.Block, bool) {
-	blk := bb.data[bb.index(number)]
+	bb.mtx.RLock()
+	defer bb.mtx.RUnlock()
 
-	// if the block is not found, return nil
-	if blk == nil {
-		return nil, false
-	}
+	return bb.get(number)
+}
 
-	// if the block is found on given index, check the number
-	if uint64(blk.Number) != number {
-		return nil, false
-	}
+// GetByHash returns the block with the specified hash.
+// If the block is not found, the second return value is false.
+func (bb *BlocksBuffer) GetByHash(hash common.Hash) (*types.Block, bool) {
+	bb.mtx.RLock()
+	defer bb.mtx.RUnlock()
 
-	return blk, true
+	blk, ok := bb.byHash[hash]
+	return blk, ok
+}
+
+// GetByTxHash returns the block containing the transaction with the specified hash.
+// If the block is not found, the second return value is false.
+func (bb *BlocksBuffer) GetByTxHash(hash common.Hash) (*types.Block, bool) {
+	bb.mtx.RLock()
+	defer bb.mtx.RUnlock()
+
+	blk, ok := bb.byTxHash[hash]
+	return blk, ok
 }
 
 // GetLatest returns the latest inserted blocks.
@@ -57,6 +80,9 @@ func (bb *BlocksBuffer) Get(number uint64) (*types.Block, bool) {
 // If the number of blocks in the buffer is less than the number parameter,
 // all blocks in the buffer are returned.
 func (bb *BlocksBuffer) GetLatest(count uint) []*types.Block {
+	bb.mtx.RLock()
+	defer bb.mtx.RUnlock()
+
 	// if the number of blocks in the buffer is less than the number of
 	// blocks to return, return all blocks
 	if bb.size < count {
@@ -74,27 +100,142 @@ func (bb *BlocksBuffer) GetLatest(count uint) []*types.Block {
 	return blocks
 }
 
+// Range returns blocks with numbers in the given inclusive range
+// in ascending order. Blocks not present in the buffer are skipped.
+func (bb *BlocksBuffer) Range(from uint64, to uint64) []*types.Block {
+	bb.mtx.RLock()
+	defer bb.mtx.RUnlock()
+
+	blocks := make([]*types.Block, 0)
+	if from > to || bb.size == 0 {
+		return blocks
+	}
+
+	// limit the range to the window of blocks the buffer can hold
+	latest := uint64(bb.data[bb.head].Number)
+	if to > latest {
+		to = latest
+	}
+	if latest >= uint64(bb.capacity) && from < latest-uint64(bb.capacity)+1 {
+		from = latest - uint64(bb.capacity) + 1
+	}
+
+	for number := from; number <= to; number++ {
+		if blk, ok := bb.get(number); ok {
+			blocks = append(blocks, blk)
+		}
+	}
+
+	return blocks
+}
+
 // Add adds the specified block to the buffer.
 func (bb *BlocksBuffer) Add(block *types.Block) {
+	bb.mtx.Lock()
+	defer bb.mtx.Unlock()
+
 	index := bb.index(uint64(block.Number))
 
-	// increase the size if the block is new
+	// increase the size if the block is new, otherwise drop the replaced block from indexes
 	if bb.data[index] == nil {
 		bb.size++
+	} else {
+		bb.unindex(bb.data[index])
 	}
 
 	// add the block to the buffer
 	bb.data[index] = block
+	bb.reindex(block)
 
 	// set head to the index of last inserted block
 	bb.head = index
 }
 
+// Rollback removes all blocks with number greater than the given number
+// from the buffer. It is used to handle chain reorganizations.
+// It returns the number of removed blocks.
+func (bb *BlocksBuffer) Rollback(toNumber uint64) uint {
+	bb.mtx.Lock()
+	defer bb.mtx.Unlock()
+
+	removed := uint(0)
+	var latest *types.Block
+	for i, blk := range bb.data {
+		if blk == nil {
+			continue
+		}
+		// keep track of the latest block that stays in the buffer
+		if uint64(blk.Number) <= toNumber {
+			if latest == nil || blk.Number > latest.Number {
+				latest = blk
+			}
+			continue
+		}
+		bb.unindex(blk)
+		bb.data[i] = nil
+		bb.size--
+		removed++
+	}
+
+	// move the head to the latest remaining block
+	if latest != nil {
+		bb.head = bb.index(uint64(latest.Number))
+	} else {
+		bb.head = 0
+	}
+
+	return removed
+}
+
 // Len returns the number of blocks in the buffer.
 func (bb *BlocksBuffer) Len() uint {
+	bb.mtx.RLock()
+	defer bb.mtx.RUnlock()
+
 	return bb.size
 }
 
+// get returns the block with the specified number.
+// The caller is responsible for holding the lock.
+func (bb *BlocksBuffer) get(number uint64) (*types.Block, bool) {
+	blk := bb.data[bb.index(number)]
+
+	// if the block is not found, return nil
+	if blk == nil {
+		return nil, false
+	}
+
+	// if the block is found on given index, check the number
+	if uint64(blk.Number) != number {
+		return nil, false
+	}
+
+	return blk, true
+}
+
+// reindex adds the given block into the hash indexes.
+// The caller is responsible for holding the lock.
+func (bb *BlocksBuffer) reindex(block *types.Block) {
+	bb.byHash[block.Hash] = block
+	for _, tx := range block.Transactions {
+		bb.byTxHash[tx] = block
+	}
+}
+
+// unindex removes the given block from the hash indexes.
+// The caller is responsible for holding the lock.
+func (bb *BlocksBuffer) unindex(block *types.Block) {
+	// the hash may already point to a newer block
+	if bb.byHash[block.Hash] == block {
+		delete(bb.byHash, block.Hash)
+	}
+	for _, tx := range block.Transactions {
+		if bb.byTxHash[tx] == block {
+			delete(bb.byTxHash, tx)
+		}
+	}
+}
+
 // index returns the index of the block with the specified number.
 func (bb *BlocksBuffer) index(number uint64) uint {
 	return uint(number % uint64(bb.capacity))
@@ -102,6 +243,9 @@ func (bb *BlocksBuffer) index(number uint64) uint {
 
 // printBuffer prints the buffer to the standard output.
 func (bb *BlocksBuffer) printBuffer() {
+	bb.mtx.RLock()
+	defer bb.mtx.RUnlock()
+
 	for i := 0; i < len(bb.data); i++ {
 		blk := bb.data[i]
 		if blk == nil {
@@ -2,8 +2,11 @@ package buffer
 
 import (
 	"ftm-explorer/internal/types"
+	"math/big"
+	"sync"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -117,3 +120,189 @@ func TestBlocksBuffer_BlocksAreRewritten(t *testing.T) {
 		t.Error("expected length to be 5")
 	}
 }
+
+// Test blocks can be retrieved by their hash and by hashes of their transactions
+func TestBlocksBuffer_GetByHash(t *testing.T) {
+	bb := NewBlocksBuffer(3)
+
+	// add 5 blocks, so that the first 2 blocks are rewritten
+	for number := uint64(1); number <= 5; number++ {
+		bb.Add(testBlock(number))
+	}
+
+	// assert rewritten blocks are not indexed anymore
+	for number := uint64(1); number <= 2; number++ {
+		if _, ok := bb.GetByHash(testBlockHash(number)); ok {
+			t.Errorf("expected block %d to not be found by hash", number)
+		}
+		if _, ok := bb.GetByTxHash(testTxHash(number)); ok {
+			t.Errorf("expected block %d to not be found by transaction hash", number)
+		}
+	}
+
+	// assert present blocks are indexed
+	for number := uint64(3); number <= 5; number++ {
+		blk, ok := bb.GetByHash(testBlockHash(number))
+		if !ok || uint64(blk.Number) != number {
+			t.Fatalf("expected block %d to be found by hash", number)
+		}
+		blk, ok = bb.GetByTxHash(testTxHash(number))
+		if !ok || uint64(blk.Number) != number {
+			t.Fatalf("expected block %d to be found by transaction hash", number)
+		}
+	}
+}
+
+// Test range of blocks can be retrieved
+func TestBlocksBuffer_Range(t *testing.T) {
+	bb := NewBlocksBuffer(5)
+
+	// add 10 blocks, only the last 5 stay in the buffer
+	for number := uint64(10); number < 20; number++ {
+		bb.Add(testBlock(number))
+	}
+
+	// range inside the buffer is returned in ascending order
+	blocks := bb.Range(16, 18)
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(blocks))
+	}
+	for i, blk := range blocks {
+		if uint64(blk.Number) != uint64(16+i) {
+			t.Errorf("expected block %d, got %d", 16+i, uint64(blk.Number))
+		}
+	}
+
+	// range partially outside the buffer returns only present blocks
+	blocks = bb.Range(0, 100)
+	if len(blocks) != 5 || uint64(blocks[0].Number) != 15 || uint64(blocks[4].Number) != 19 {
+		t.Errorf("expected blocks 15-19 to be returned, got %d blocks", len(blocks))
+	}
+
+	// invalid range returns no blocks
+	if blocks = bb.Range(18, 16); len(blocks) != 0 {
+		t.Errorf("expected no blocks, got %d", len(blocks))
+	}
+}
+
+// Test blocks can be rolled back
+func TestBlocksBuffer_Rollback(t *testing.T) {
+	bb := NewBlocksBuffer(10)
+
+	for number := uint64(1); number <= 8; number++ {
+		bb.Add(testBlock(number))
+	}
+
+	// rollback the last 3 blocks
+	if removed := bb.Rollback(5); removed != 3 {
+		t.Fatalf("expected 3 blocks to be removed, got %d", removed)
+	}
+	if bb.Len() != 5 {
+		t.Errorf("expected length to be 5, got %d", bb.Len())
+	}
+
+	// assert removed blocks are not present in buffer and indexes
+	for number := uint64(6); number <= 8; number++ {
+		if _, ok := bb.Get(number); ok {
+			t.Errorf("expected block %d to not be found", number)
+		}
+		if _, ok := bb.GetByHash(testBlockHash(number)); ok {
+			t.Errorf("expected block %d to not be found by hash", number)
+		}
+		if _, ok := bb.GetByTxHash(testTxHash(number)); ok {
+			t.Errorf("expected block %d to not be found by transaction hash", number)
+		}
+	}
+
+	// assert the latest block is the rollback target
+	latest := bb.GetLatest(1)
+	if len(latest) != 1 || uint64(latest[0].Number) != 5 {
+		t.Fatalf("expected latest block to be 5")
+	}
+
+	// the chain can continue from the rollback target
+	bb.Add(testBlock(6))
+	latest = bb.GetLatest(2)
+	if len(latest) != 2 || uint64(latest[0].Number) != 6 || uint64(latest[1].Number) != 5 {
+		t.Errorf("expected latest blocks to be 6 and 5")
+	}
+}
+
+// Test buffer can be used by concurrent readers and a writer; run with -race
+func TestBlocksBuffer_ConcurrentAccess(t *testing.T) {
+	bb := NewBlocksBuffer(100)
+	count := uint64(2_000)
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// start readers
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				for _, blk := range bb.GetLatest(10) {
+					if blk == nil {
+						t.Error("expected latest block to be present")
+						return
+					}
+					if found, ok := bb.GetByHash(blk.Hash); ok && found.Hash != blk.Hash {
+						t.Error("expected block hash to match")
+						return
+					}
+				}
+				latest := bb.GetLatest(1)
+				if len(latest) == 1 {
+					number := uint64(latest[0].Number)
+					_, _ = bb.Get(number)
+					_ = bb.Range(number-5, number)
+					_, _ = bb.GetByTxHash(testTxHash(number))
+				}
+				_ = bb.Len()
+			}
+		}()
+	}
+
+	// write blocks and roll back from time to time
+	for number := uint64(10); number < count; number++ {
+		bb.Add(testBlock(number))
+		if number%100 == 0 {
+			bb.Rollback(number - 1)
+			bb.Add(testBlock(number))
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if bb.Len() != 100 {
+		t.Errorf("expected length to be 100, got %d", bb.Len())
+	}
+	if blk, ok := bb.Get(count - 1); !ok || uint64(blk.Number) != count-1 {
+		t.Errorf("expected block %d to be found", count-1)
+	}
+}
+
+// testBlock creates a block with the given number, hash and a single transaction.
+func testBlock(number uint64) *types.Block {
+	return &types.Block{
+		Number:       hexutil.Uint64(number),
+		Hash:         testBlockHash(number),
+		Transactions: []common.Hash{testTxHash(number)},
+	}
+}
+
+// testBlockHash returns a hash of the test block with the given number.
+func testBlockHash(number uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(number))
+}
+
+// testTxHash returns a hash of the test transaction in the block with the given number.
+func testTxHash(number uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(number + 1_000_000))
+}
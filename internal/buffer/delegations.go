@@ -0,0 +1,97 @@
+package buffer
+
+import (
+	"container/list"
+	"ftm-explorer/internal/types"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// delegationsEntry represents the delegations of an account read in a sealed epoch.
+type delegationsEntry struct {
+	account     common.Address
+	epoch       uint64
+	readAt      time.Time
+	delegations []types.Delegation
+}
+
+// DelegationsCache represents a bounded LRU cache of delegations of accounts.
+// The delegations are cached together with the sealed epoch they were read in
+// and they are served only for the same epoch, as the rewards change when an epoch is sealed.
+// The stakes may change within the epoch, so the delegations also expire after the ttl.
+// When the cache is full, the least recently used account is evicted.
+// The cache is thread-safe.
+type DelegationsCache struct {
+	// mtx guards access to the cache data
+	mtx sync.Mutex
+	// capacity is the maximum number of cached accounts
+	capacity uint
+	// ttl is the time the delegations are served for after they were read
+	ttl time.Duration
+	// items maps account address to the list element holding its delegations
+	items map[common.Address]*list.Element
+	// order keeps accounts ordered from the most to the least recently used
+	order *list.List
+}
+
+// NewDelegationsCache creates a new delegations cache.
+// The maximum number of cached accounts is specified by the size parameter
+// and the time the delegations are served for by the ttl parameter.
+func NewDelegationsCache(size uint, ttl time.Duration) *DelegationsCache {
+	return &DelegationsCache{
+		capacity: size,
+		ttl:      ttl,
+		items:    make(map[common.Address]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the delegations of the account read in the given sealed epoch.
+// If the delegations are not cached for the epoch or expired, the second return value is false.
+func (dc *DelegationsCache) Get(account common.Address, epoch uint64) ([]types.Delegation, bool) {
+	dc.mtx.Lock()
+	defer dc.mtx.Unlock()
+
+	el, ok := dc.items[account]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*delegationsEntry)
+	if entry.epoch != epoch || time.Since(entry.readAt) >= dc.ttl {
+		return nil, false
+	}
+	dc.order.MoveToFront(el)
+	return el.Value.(*delegationsEntry).delegations, true
+}
+
+// Add adds the delegations of the account read in the given sealed epoch to the cache.
+// Delegations read in an older epoch than the cached ones are ignored.
+// If the cache is full, the least recently used account is evicted.
+func (dc *DelegationsCache) Add(account common.Address, epoch uint64, delegations []types.Delegation) {
+	dc.mtx.Lock()
+	defer dc.mtx.Unlock()
+
+	if dc.capacity == 0 {
+		return
+	}
+
+	entry := &delegationsEntry{account: account, epoch: epoch, readAt: time.Now(), delegations: delegations}
+	if el, ok := dc.items[account]; ok {
+		if el.Value.(*delegationsEntry).epoch <= epoch {
+			el.Value = entry
+		}
+		dc.order.MoveToFront(el)
+		return
+	}
+
+	// evict the least recently used account
+	if uint(dc.order.Len()) >= dc.capacity {
+		last := dc.order.Back()
+		dc.order.Remove(last)
+		delete(dc.items, last.Value.(*delegationsEntry).account)
+	}
+
+	dc.items[account] = dc.order.PushFront(entry)
+}
@@ -0,0 +1,90 @@
+package buffer
+
+import (
+	"ftm-explorer/internal/types"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Test delegations are cached for the epoch they were read in only
+func TestDelegationsCache_Epoch(t *testing.T) {
+	dc := NewDelegationsCache(5, time.Minute)
+	account := common.Address{0x01}
+
+	// check nothing is cached
+	if _, ok := dc.Get(account, 10); ok {
+		t.Error("expected delegations to not be found")
+	}
+
+	// cache delegations and retrieve them
+	dc.Add(account, 10, []types.Delegation{{ValidatorId: 1}, {ValidatorId: 2}})
+	delegations, ok := dc.Get(account, 10)
+	if !ok || len(delegations) != 2 {
+		t.Fatalf("expected 2 delegations, got %v", delegations)
+	}
+
+	// delegations are not served for other epochs
+	if _, ok := dc.Get(account, 11); ok {
+		t.Error("expected delegations of epoch 11 to not be found")
+	}
+
+	// delegations read in an older epoch do not replace the cached ones
+	dc.Add(account, 9, []types.Delegation{{ValidatorId: 3}})
+	if _, ok := dc.Get(account, 10); !ok {
+		t.Error("expected delegations of epoch 10 to be found")
+	}
+
+	// delegations read in a newer epoch replace the cached ones
+	dc.Add(account, 11, []types.Delegation{{ValidatorId: 3}})
+	if _, ok := dc.Get(account, 10); ok {
+		t.Error("expected delegations of epoch 10 to not be found")
+	}
+	delegations, ok = dc.Get(account, 11)
+	if !ok || len(delegations) != 1 || delegations[0].ValidatorId != hexutil.Uint64(3) {
+		t.Errorf("expected delegation to validator 3, got %v", delegations)
+	}
+}
+
+// Test delegations expire after the ttl within the same epoch
+func TestDelegationsCache_Expiration(t *testing.T) {
+	dc := NewDelegationsCache(5, 50*time.Millisecond)
+	account := common.Address{0x01}
+
+	dc.Add(account, 10, []types.Delegation{{ValidatorId: 1}})
+	if _, ok := dc.Get(account, 10); !ok {
+		t.Fatal("expected delegations to be found")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, ok := dc.Get(account, 10); ok {
+		t.Error("expected delegations to expire")
+	}
+}
+
+// Test the least recently used account is evicted when the cache is full
+func TestDelegationsCache_Eviction(t *testing.T) {
+	dc := NewDelegationsCache(2, time.Minute)
+	first, second, third := common.Address{0x01}, common.Address{0x02}, common.Address{0x03}
+
+	dc.Add(first, 1, []types.Delegation{})
+	dc.Add(second, 1, []types.Delegation{})
+
+	// touch the first account, so the second becomes the least recently used
+	if _, ok := dc.Get(first, 1); !ok {
+		t.Fatal("expected delegations of the first account to be found")
+	}
+
+	dc.Add(third, 1, []types.Delegation{})
+	if _, ok := dc.Get(second, 1); ok {
+		t.Error("expected delegations of the second account to be evicted")
+	}
+	if _, ok := dc.Get(first, 1); !ok {
+		t.Error("expected delegations of the first account to be found")
+	}
+	if _, ok := dc.Get(third, 1); !ok {
+		t.Error("expected delegations of the third account to be found")
+	}
+}
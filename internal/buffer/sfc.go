@@ -0,0 +1,103 @@
+package buffer
+
+import (
+	"container/list"
+	"ftm-explorer/internal/types"
+	"sync"
+)
+
+// SfcCache represents a cache of the data read from the SFC contract.
+// The validators are cached for a single sealed epoch and replaced once a newer
+// epoch is sealed. Snapshots of sealed epochs never change, so they are kept
+// in a bounded LRU cache.
+// The cache is thread-safe.
+type SfcCache struct {
+	// mtx guards access to the cache data
+	mtx sync.Mutex
+	// epoch is the number of the sealed epoch the validators are cached for
+	epoch uint64
+	// validators are the validators of the epoch, nil if not cached
+	validators []types.Validator
+	// capacity is the maximum number of cached epochs
+	capacity uint
+	// epochs maps epoch number to the list element holding the epoch
+	epochs map[uint64]*list.Element
+	// order keeps epochs ordered from the most to the least recently used
+	order *list.List
+}
+
+// NewSfcCache creates a new SFC cache.
+// The maximum number of cached epochs is specified by the size parameter.
+func NewSfcCache(size uint) *SfcCache {
+	return &SfcCache{
+		capacity: size,
+		epochs:   make(map[uint64]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Validators returns the validators of the given sealed epoch.
+// If the validators are not cached for the epoch, the second return value is false.
+func (sc *SfcCache) Validators(epoch uint64) ([]types.Validator, bool) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	if sc.validators == nil || sc.epoch != epoch {
+		return nil, false
+	}
+	return sc.validators, true
+}
+
+// SetValidators caches the validators of the given sealed epoch.
+// Validators of an epoch older than the cached one are ignored.
+func (sc *SfcCache) SetValidators(epoch uint64, validators []types.Validator) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	if sc.validators != nil && epoch < sc.epoch {
+		return
+	}
+	sc.epoch = epoch
+	sc.validators = validators
+}
+
+// Epoch returns the sealed epoch with the given number.
+// If the epoch is not found, the second return value is false.
+func (sc *SfcCache) Epoch(number uint64) (*types.Epoch, bool) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	el, ok := sc.epochs[number]
+	if !ok {
+		return nil, false
+	}
+	sc.order.MoveToFront(el)
+	return el.Value.(*types.Epoch), true
+}
+
+// AddEpoch adds the sealed epoch to the cache.
+// If the cache is full, the least recently used epoch is evicted.
+func (sc *SfcCache) AddEpoch(epoch *types.Epoch) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	if sc.capacity == 0 {
+		return
+	}
+
+	number := uint64(epoch.Number)
+	if el, ok := sc.epochs[number]; ok {
+		el.Value = epoch
+		sc.order.MoveToFront(el)
+		return
+	}
+
+	// evict the least recently used epoch
+	if uint(sc.order.Len()) >= sc.capacity {
+		last := sc.order.Back()
+		sc.order.Remove(last)
+		delete(sc.epochs, uint64(last.Value.(*types.Epoch).Number))
+	}
+
+	sc.epochs[number] = sc.order.PushFront(epoch)
+}
@@ -0,0 +1,71 @@
+package buffer
+
+import (
+	"ftm-explorer/internal/types"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Test validators are cached for the latest set epoch only
+func TestSfcCache_Validators(t *testing.T) {
+	sc := NewSfcCache(5)
+
+	// check nothing is cached
+	if _, ok := sc.Validators(10); ok {
+		t.Error("expected validators to not be found")
+	}
+
+	// cache validators and retrieve them
+	sc.SetValidators(10, []types.Validator{{Id: 1}, {Id: 2}})
+	validators, ok := sc.Validators(10)
+	if !ok || len(validators) != 2 {
+		t.Fatalf("expected 2 validators, got %v", validators)
+	}
+
+	// validators of other epochs are not cached
+	if _, ok := sc.Validators(11); ok {
+		t.Error("expected validators of epoch 11 to not be found")
+	}
+
+	// validators of an older epoch do not replace the cached ones
+	sc.SetValidators(9, []types.Validator{{Id: 3}})
+	if _, ok := sc.Validators(10); !ok {
+		t.Error("expected validators of epoch 10 to be found")
+	}
+
+	// validators of a newer epoch replace the cached ones
+	sc.SetValidators(11, []types.Validator{{Id: 3}})
+	if _, ok := sc.Validators(10); ok {
+		t.Error("expected validators of epoch 10 to not be found")
+	}
+	validators, ok = sc.Validators(11)
+	if !ok || len(validators) != 1 || validators[0].Id != 3 {
+		t.Errorf("expected validator 3, got %v", validators)
+	}
+}
+
+// Test the least recently used epoch is evicted when the cache is full
+func TestSfcCache_EpochEviction(t *testing.T) {
+	sc := NewSfcCache(3)
+
+	for number := uint64(1); number <= 3; number++ {
+		sc.AddEpoch(&types.Epoch{Number: hexutil.Uint64(number)})
+	}
+
+	// touch epoch 1, so epoch 2 becomes the least recently used
+	if _, ok := sc.Epoch(1); !ok {
+		t.Fatal("expected epoch 1 to be found")
+	}
+
+	// add epoch 4, epoch 2 should be evicted
+	sc.AddEpoch(&types.Epoch{Number: 4})
+	if _, ok := sc.Epoch(2); ok {
+		t.Error("expected epoch 2 to be evicted")
+	}
+	for _, number := range []uint64{1, 3, 4} {
+		if epoch, ok := sc.Epoch(number); !ok || uint64(epoch.Number) != number {
+			t.Errorf("expected epoch %d to be found, got %v", number, epoch)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package buffer
+
+import (
+	"container/list"
+	"ftm-explorer/internal/types"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TransactionsCache represents a bounded LRU cache of transactions.
+// Transactions are stored together with their receipt data, so only
+// transactions already included in a block should be cached.
+// When the cache is full, the least recently used transaction is evicted.
+// The cache is thread-safe.
+type TransactionsCache struct {
+	// mtx guards access to the cache data
+	mtx sync.Mutex
+	// capacity is the maximum number of cached transactions
+	capacity uint
+	// items maps transaction hash to the list element holding the transaction
+	items map[common.Hash]*list.Element
+	// order keeps transactions ordered from the most to the least recently used
+	order *list.List
+	// hits is the number of lookups served from the cache
+	hits uint64
+	// misses is the number of lookups not found in the cache
+	misses uint64
+}
+
+// NewTransactionsCache creates a new transactions cache.
+// The maximum number of cached transactions is specified by the size parameter.
+func NewTransactionsCache(size uint) *TransactionsCache {
+	return &TransactionsCache{
+		capacity: size,
+		items:    make(map[common.Hash]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the transaction with the specified hash.
+// If the transaction is not found, the second return value is false.
+func (tc *TransactionsCache) Get(hash common.Hash) (*types.Transaction, bool) {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+
+	el, ok := tc.items[hash]
+	if !ok {
+		tc.misses++
+		return nil, false
+	}
+
+	tc.hits++
+	tc.order.MoveToFront(el)
+	return el.Value.(*types.Transaction), true
+}
+
+// Add adds the specified transaction to the cache.
+// If the cache is full, the least recently used transaction is evicted.
+func (tc *TransactionsCache) Add(trx *types.Transaction) {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+
+	if tc.capacity == 0 {
+		return
+	}
+
+	// replace the transaction if it is already cached
+	if el, ok := tc.items[trx.Hash]; ok {
+		el.Value = trx
+		tc.order.MoveToFront(el)
+		return
+	}
+
+	// evict the least recently used transaction
+	if uint(tc.order.Len()) >= tc.capacity {
+		last := tc.order.Back()
+		tc.order.Remove(last)
+		delete(tc.items, last.Value.(*types.Transaction).Hash)
+	}
+
+	tc.items[trx.Hash] = tc.order.PushFront(trx)
+}
+
+// Len returns the number of cached transactions.
+func (tc *TransactionsCache) Len() uint {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+
+	return uint(tc.order.Len())
+}
+
+// Stats returns usage statistics of the cache.
+func (tc *TransactionsCache) Stats() types.CacheStats {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+
+	return types.CacheStats{
+		Hits:   tc.hits,
+		Misses: tc.misses,
+		Size:   uint(tc.order.Len()),
+	}
+}
@@ -0,0 +1,92 @@
+package buffer
+
+import (
+	"ftm-explorer/internal/types"
+	"sync"
+	"testing"
+)
+
+// Test cached transaction can be retrieved and hits and misses are counted
+func TestTransactionsCache_AddAndGet(t *testing.T) {
+	tc := NewTransactionsCache(5)
+
+	// check the cache is empty
+	if _, ok := tc.Get(testTxHash(1)); ok {
+		t.Error("expected transaction to not be found")
+	}
+
+	// add transaction and retrieve it
+	tc.Add(&types.Transaction{Hash: testTxHash(1)})
+	trx, ok := tc.Get(testTxHash(1))
+	if !ok {
+		t.Fatal("expected transaction to be found")
+	}
+	if trx.Hash != testTxHash(1) {
+		t.Errorf("expected transaction %s, got %s", testTxHash(1), trx.Hash)
+	}
+
+	// check stats
+	stats := tc.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Size != 1 {
+		t.Errorf("expected 1 hit, 1 miss and size 1, got %+v", stats)
+	}
+}
+
+// Test the least recently used transaction is evicted when the cache is full
+func TestTransactionsCache_Eviction(t *testing.T) {
+	tc := NewTransactionsCache(3)
+
+	for number := uint64(1); number <= 3; number++ {
+		tc.Add(&types.Transaction{Hash: testTxHash(number)})
+	}
+
+	// touch the first transaction, so that the second one is the least recently used
+	if _, ok := tc.Get(testTxHash(1)); !ok {
+		t.Fatal("expected transaction 1 to be found")
+	}
+	tc.Add(&types.Transaction{Hash: testTxHash(4)})
+
+	if tc.Len() != 3 {
+		t.Errorf("expected length to be 3, got %d", tc.Len())
+	}
+	if _, ok := tc.Get(testTxHash(2)); ok {
+		t.Error("expected transaction 2 to be evicted")
+	}
+	for _, number := range []uint64{1, 3, 4} {
+		if _, ok := tc.Get(testTxHash(number)); !ok {
+			t.Errorf("expected transaction %d to be found", number)
+		}
+	}
+
+	// adding already cached transaction does not evict anything
+	tc.Add(&types.Transaction{Hash: testTxHash(3)})
+	if tc.Len() != 3 {
+		t.Errorf("expected length to be 3, got %d", tc.Len())
+	}
+}
+
+// Test cache can be used concurrently; run with -race
+func TestTransactionsCache_ConcurrentAccess(t *testing.T) {
+	tc := NewTransactionsCache(100)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(offset uint64) {
+			defer wg.Done()
+			for number := uint64(0); number < 1_000; number++ {
+				tc.Add(&types.Transaction{Hash: testTxHash(number + offset)})
+				_, _ = tc.Get(testTxHash(number))
+				_ = tc.Stats()
+			}
+		}(uint64(i) * 1_000)
+	}
+	wg.Wait()
+
+	if tc.Len() != 100 {
+		t.Errorf("expected length to be 100, got %d", tc.Len())
+	}
+	if stats := tc.Stats(); stats.Hits+stats.Misses != 4_000 {
+		t.Errorf("expected 4000 lookups, got %d", stats.Hits+stats.Misses)
+	}
+}
@@ -14,6 +14,7 @@ type Config struct {
 	Logger      Logger
 	MongoDb     MongoDb
 	Maze        *Maze
+	Siwe        Siwe
 }
 
 // Explorer is the configuration structure for the explorer.
@@ -21,12 +22,25 @@ type Explorer struct {
 	// BlockBufferSize is the size of the block buffer. The buffer is used to
 	// store blocks in memory, so that they can be accessed quickly.
 	BlockBufferSize uint
+	// TrxCacheSize is the maximum number of transactions kept in the in-memory
+	// cache. The cache is used to serve recently observed transactions quickly.
+	TrxCacheSize uint
+	// CacheObservedTrxs is the flag indicating whether transactions of observed blocks
+	// are fetched into the cache even if the explorer data is not persisted.
+	CacheObservedTrxs bool
 	// IsPersisted is the flag indicating whether the explorer data is persisted.
 	IsPersisted bool
 	// MaxTxsCount is the maximum number of transactions to be stored in the database.
 	// If the number of transactions in the database exceeds this value, the oldest
 	// transactions are removed.
 	MaxTxsCount uint
+	// FetchWorkers is the number of workers fetching transactions of observed blocks.
+	FetchWorkers uint
+	// EnrichWorkers is the number of workers preparing observed blocks to be persisted.
+	EnrichWorkers uint
+	// GasOracleBlocks is the number of latest observed blocks whose transaction
+	// gas prices are used to estimate the gas price.
+	GasOracleBlocks uint
 }
 
 type Faucet struct {
@@ -38,6 +52,28 @@ type Faucet struct {
 	WalletPrivateKey string
 	// ClaimsPerDay is the number of claims per day allowed from the same ip address.
 	ClaimsPerDay uint
+	// ReceiverClaimsPerDay is the number of claims of each token per day allowed to the same receiver, zero for unlimited.
+	ReceiverClaimsPerDay uint
+	// HourlyBudgetHex is the amount of wei the faucet can send per hour, empty for unlimited.
+	HourlyBudgetHex string
+	// DailyBudgetHex is the amount of wei the faucet can send per day, empty for unlimited.
+	DailyBudgetHex string
+	// MinBalanceHex is the wei balance of the faucet wallet below which the claims of the native token are disabled.
+	MinBalanceHex string
+	// MinMinterBalanceHex is the wei balance of an erc20 minter wallet below which the claims of the token are disabled.
+	MinMinterBalanceHex string
+	// BalanceCheckSeconds is the time between two checks of the faucet wallet balances.
+	BalanceCheckSeconds uint
+	// LowBalanceWebhookUrl is the url notified when a faucet wallet runs low on funds, empty to disable.
+	LowBalanceWebhookUrl string
+	// Gate is the configuration of the gate protecting the faucet requests against bots.
+	Gate FaucetGate
+	// MultisendAddress is the address of the multisend contract paying the native claims in batches, empty to disable batching.
+	MultisendAddress string
+	// BatchWindowMillis is the time the native claims are collected before they are paid out in a batch.
+	BatchWindowMillis uint
+	// BatchMaxClaims is the maximal number of claims paid out in a batch.
+	BatchMaxClaims uint
 	// Erc20sPath is the path to the erc20 tokens configuration file.
 	Erc20sPath string
 	// Erc20MintAmountHex is the amount of erc20 tokens to be minted.
@@ -46,10 +82,41 @@ type Faucet struct {
 	Erc20s []FaucetErc20
 }
 
+// FaucetGate is the configuration structure for the gate protecting the faucet requests.
+type FaucetGate struct {
+	// Kind is the kind of the gate, either "none", "pow" for the hashcash proof-of-work
+	// or "captcha" for the external captcha provider.
+	Kind string
+	// PowDifficulty is the number of leading zero bits of the proof-of-work stamp hash.
+	PowDifficulty uint
+	// CaptchaVerifyUrl is the url of the captcha provider verifying the captcha responses.
+	CaptchaVerifyUrl string
+	// CaptchaSiteKey is the public key of the site used by the clients to render the captcha.
+	CaptchaSiteKey string
+	// CaptchaSecret is the secret key of the site used to verify the captcha responses.
+	CaptchaSecret string
+}
+
 // FaucetErc20 is the configuration structure for the faucet erc20 token.
 type FaucetErc20 struct {
 	Address  string `json:"address"`
 	MinterPk string `json:"minter_key"`
+
+	// HourlyBudgetHex is the amount of tokens the faucet can give away per hour, empty for unlimited.
+	HourlyBudgetHex string `json:"hourly_budget"`
+	// DailyBudgetHex is the amount of tokens the faucet can give away per day, empty for unlimited.
+	DailyBudgetHex string `json:"daily_budget"`
+
+	// Mode is the way the tokens are given away, either "mint" (default) by the mint function
+	// or "transfer" from the balance of the funded wallet.
+	Mode string `json:"mode"`
+	// Amount is the number of whole tokens given away per claim, e.g. "2.5",
+	// empty for the global Erc20MintAmountHex in the smallest units of the token.
+	Amount string `json:"amount"`
+	// Decimals is the number of decimals of the token, read from the token contract if not set.
+	Decimals *uint8 `json:"decimals"`
+	// MaxClaim is the maximal number of whole tokens given away per claim, empty for no cap.
+	MaxClaim string `json:"max_claim"`
 }
 
 // MetaFetcher is the configuration structure for meta fetcher obtaining blockchain metadata.
@@ -77,6 +144,59 @@ type ApiServer struct {
 	HeaderTimeout   int
 	ResolverTimeout int
 	CorsOrigin      []string
+	// TrustedProxies is the list of CIDRs of reverse proxies trusted to report
+	// the client address in the ClientIpHeader.
+	TrustedProxies []string
+	// ClientIpHeader is the header the trusted proxies report the client address in,
+	// one of Forwarded, X-Forwarded-For or X-Real-IP. Other forwarding headers are ignored.
+	ClientIpHeader string
+	// MaxQueryDepth is the maximum nesting depth of fields in a query. Zero disables the limit.
+	MaxQueryDepth int
+	// MaxQueryCost is the maximum estimated cost of a query. Zero disables the limit.
+	MaxQueryCost int
+	// FieldCosts are the cost weights of fields keyed by type name and field name.
+	// Fields without weight cost 1, the weight of a list field applies to each nested field.
+	FieldCosts map[string]map[string]int
+	// RateLimit is the configuration of the rate limiting of API clients.
+	RateLimit RateLimit
+}
+
+// RateLimit is the configuration structure for rate limiting of API clients.
+type RateLimit struct {
+	// Store is the storage of rate limit buckets, either "memory" or "mongodb".
+	// The "mongodb" store shares the buckets by multiple instances of the explorer.
+	Store string
+	// Queries is the budget of GraphQL queries.
+	Queries RateLimitBudget
+	// Mutations is the budget of GraphQL mutations.
+	Mutations RateLimitBudget
+	// Subscriptions is the budget of websocket subscriptions.
+	Subscriptions RateLimitBudget
+}
+
+// RateLimitBudget is the configuration structure for a token bucket of a rate limit budget.
+// The budget is not limited if the rate or the burst is zero.
+type RateLimitBudget struct {
+	// Rate is the number of requests per second allowed in the long run.
+	Rate float64
+	// Burst is the number of requests allowed at once.
+	Burst int
+}
+
+// Siwe is the configuration structure for Sign-In with Ethereum (EIP-4361) sessions.
+type Siwe struct {
+	// Domain is the domain requesting the sign-in, signed messages must match it.
+	Domain string
+	// Uri is the URI of the resource the sign-in is requested for.
+	Uri string
+	// Statement is the human-readable statement of the sign-in message.
+	Statement string
+	// ChainId is the chain id of sign-in messages. Zero uses the network id of the RPC node.
+	ChainId uint64
+	// ChallengeTtl is the number of seconds an issued sign-in message can be signed in.
+	ChallengeTtl uint
+	// SessionTtl is the number of seconds a session is valid after signing in.
+	SessionTtl uint
 }
 
 // Logger is the configuration structure for logging.
@@ -97,8 +217,11 @@ type MongoDb struct {
 // Maze is the configuration structure for the maze.
 type Maze struct {
 	VisibilityRange uint
-	ConfigPaths     []string
-	Configs         []MazeConfig
+	// ChallengeTtl is the number of seconds an issued position challenge can be used.
+	// Zero uses the default of 5 minutes.
+	ChallengeTtl uint
+	ConfigPaths  []string
+	Configs      []MazeConfig
 }
 
 // MazeConfig is the configuration structure for the maze.
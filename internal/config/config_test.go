@@ -11,19 +11,39 @@ func TestConfig_Load(t *testing.T) {
 	cfgStr := `{
 	  "explorer": {
 		"blockBufferSize": 128964,
+		"trxCacheSize": 25000,
+		"cacheObservedTrxs": true,
 		"isPersisted": true,
-		"maxTxsCount": 66999999
+		"maxTxsCount": 66999999,
+		"fetchWorkers": 16,
+		"enrichWorkers": 4,
+		"gasOracleBlocks": 50
 	  },
       "faucet": {
         "claimLimitSeconds": 1000,
         "claimTokensAmount": 0.5,
         "walletPrivateKey": "9s4d5dea0bdffb09d78a81c15f0b3b893f504679eb8cd1de585309cad58a6285",
         "claimsPerDay": 5,
+        "receiverClaimsPerDay": 2,
+        "hourlyBudgetHex": "0x56bc75e2d63100000",
+        "dailyBudgetHex": "0x3635c9adc5dea00000",
+        "minBalanceHex": "0x8ac7230489e80000",
+        "balanceCheckSeconds": 30,
+        "lowBalanceWebhookUrl": "https://hooks.example.com/faucet",
+        "multisendAddress": "0xd152f549545093347a162dce210e7293f1452150",
+        "batchMaxClaims": 50,
+        "gate": {
+          "kind": "captcha",
+          "captchaVerifyUrl": "https://captcha.example.com/siteverify",
+          "captchaSiteKey": "site-key",
+          "captchaSecret": "secret"
+        },
 	    "erc20MintAmountHex": "0x38d7ea4c68000",
         "erc20sPath": "%s"
       },
 	  "maze": {
  		"visibilityRange": 3,
+		"challengeTtl": 120,
 	    "configPaths": ["%s"]
 	  },
 	  "metaFetcher": {
@@ -45,7 +65,17 @@ func TestConfig_Load(t *testing.T) {
 		"resolverTimeout": 12,
 		"bindAddress": "bindAddress",
 		"domainAddress": "domainAddress",
-		"corsOrigin": ["cors1", "cors2"]
+		"corsOrigin": ["cors1", "cors2"],
+		"trustedProxies": ["10.0.0.0/8", "192.168.1.1"],
+		"clientIpHeader": "Forwarded",
+		"maxQueryDepth": 7,
+		"maxQueryCost": 500,
+		"fieldCosts": {"block": {"fullTransactions": 20}},
+		"rateLimit": {
+		  "store": "mongodb",
+		  "queries": {"rate": 5.5, "burst": 10},
+		  "mutations": {"rate": 0.1, "burst": 2}
+		}
 	  },
 	  "logger": {
 		"loggingLevel": 1,
@@ -57,18 +87,29 @@ func TestConfig_Load(t *testing.T) {
 		"db": "mongodb",
 		"user": "testUser",
 		"password": "testPassword"
+	  },
+	  "siwe": {
+		"domain": "explorer.test",
+		"uri": "https://explorer.test",
+		"chainId": 4002,
+		"sessionTtl": 3600
 	  }
 	}`
 	erc20CfgStr := `[
 	  {
 		"name":"Apatite",
 		"address":"0x3bc666c4073853a59a7bfb0184298551d922f1df",
-		"minter_key":"904d5dea0bdffb09d78a81c15f0b3b893f504679eb8cd1de585309cad58e6285"
+		"minter_key":"904d5dea0bdffb09d78a81c15f0b3b893f504679eb8cd1de585309cad58e6285",
+		"daily_budget":"0x8ac7230489e80000"
 	  },
 	  {
 		"name":"Epidote",
 		"address":"0x1234567890123456789012345678901234567890",
-		"minter_key":"904d5dea0bdffb09d78a81c15f0b3b893f504679eb8cd1de585309cad58e6285"
+		"minter_key":"904d5dea0bdffb09d78a81c15f0b3b893f504679eb8cd1de585309cad58e6285",
+		"mode":"transfer",
+		"amount":"2.5",
+		"decimals":6,
+		"max_claim":"10"
 	  }
 	]`
 	mazeCfgStr := `{
@@ -161,12 +202,27 @@ func TestConfig_Load(t *testing.T) {
 	if cfg.Explorer.BlockBufferSize != 128964 {
 		t.Errorf("expected Explorer.BlockBufferSize to be 128964, got %d", cfg.Explorer.BlockBufferSize)
 	}
+	if cfg.Explorer.TrxCacheSize != 25000 {
+		t.Errorf("expected Explorer.TrxCacheSize to be 25000, got %d", cfg.Explorer.TrxCacheSize)
+	}
+	if !cfg.Explorer.CacheObservedTrxs {
+		t.Errorf("expected Explorer.CacheObservedTrxs to be true, got %v", cfg.Explorer.CacheObservedTrxs)
+	}
 	if !cfg.Explorer.IsPersisted {
 		t.Errorf("expected Explorer.IsPersisted to be true, got %v", cfg.Explorer.IsPersisted)
 	}
 	if cfg.Explorer.MaxTxsCount != 66999999 {
 		t.Errorf("expected Explorer.MaxTxsCount to be 66999999, got %d", cfg.Explorer.MaxTxsCount)
 	}
+	if cfg.Explorer.FetchWorkers != 16 {
+		t.Errorf("expected Explorer.FetchWorkers to be 16, got %d", cfg.Explorer.FetchWorkers)
+	}
+	if cfg.Explorer.EnrichWorkers != 4 {
+		t.Errorf("expected Explorer.EnrichWorkers to be 4, got %d", cfg.Explorer.EnrichWorkers)
+	}
+	if cfg.Explorer.GasOracleBlocks != 50 {
+		t.Errorf("expected Explorer.GasOracleBlocks to be 50, got %d", cfg.Explorer.GasOracleBlocks)
+	}
 	if cfg.Faucet.ClaimLimitSeconds != 1000 {
 		t.Errorf("expected Faucet.ClaimLimitSeconds to be 1000, got %d", cfg.Faucet.ClaimLimitSeconds)
 	}
@@ -179,6 +235,48 @@ func TestConfig_Load(t *testing.T) {
 	if cfg.Faucet.ClaimsPerDay != 5 {
 		t.Errorf("expected Faucet.ClaimsPerDay to be 5, got %d", cfg.Faucet.ClaimsPerDay)
 	}
+	if cfg.Faucet.ReceiverClaimsPerDay != 2 {
+		t.Errorf("expected Faucet.ReceiverClaimsPerDay to be 2, got %d", cfg.Faucet.ReceiverClaimsPerDay)
+	}
+	if cfg.Faucet.HourlyBudgetHex != "0x56bc75e2d63100000" {
+		t.Errorf("expected Faucet.HourlyBudgetHex to be 0x56bc75e2d63100000, got %s", cfg.Faucet.HourlyBudgetHex)
+	}
+	if cfg.Faucet.DailyBudgetHex != "0x3635c9adc5dea00000" {
+		t.Errorf("expected Faucet.DailyBudgetHex to be 0x3635c9adc5dea00000, got %s", cfg.Faucet.DailyBudgetHex)
+	}
+	if cfg.Faucet.MinBalanceHex != "0x8ac7230489e80000" {
+		t.Errorf("expected Faucet.MinBalanceHex to be 0x8ac7230489e80000, got %s", cfg.Faucet.MinBalanceHex)
+	}
+	if cfg.Faucet.MinMinterBalanceHex != "0xde0b6b3a7640000" {
+		t.Errorf("expected Faucet.MinMinterBalanceHex to default to 0xde0b6b3a7640000, got %s", cfg.Faucet.MinMinterBalanceHex)
+	}
+	if cfg.Faucet.BalanceCheckSeconds != 30 {
+		t.Errorf("expected Faucet.BalanceCheckSeconds to be 30, got %d", cfg.Faucet.BalanceCheckSeconds)
+	}
+	if cfg.Faucet.LowBalanceWebhookUrl != "https://hooks.example.com/faucet" {
+		t.Errorf("expected Faucet.LowBalanceWebhookUrl to be https://hooks.example.com/faucet, got %s", cfg.Faucet.LowBalanceWebhookUrl)
+	}
+	if cfg.Faucet.MultisendAddress != "0xd152f549545093347a162dce210e7293f1452150" {
+		t.Errorf("expected Faucet.MultisendAddress to be 0xd152f549545093347a162dce210e7293f1452150, got %s", cfg.Faucet.MultisendAddress)
+	}
+	if cfg.Faucet.BatchWindowMillis != 2000 {
+		t.Errorf("expected Faucet.BatchWindowMillis to default to 2000, got %d", cfg.Faucet.BatchWindowMillis)
+	}
+	if cfg.Faucet.BatchMaxClaims != 50 {
+		t.Errorf("expected Faucet.BatchMaxClaims to be 50, got %d", cfg.Faucet.BatchMaxClaims)
+	}
+	if cfg.Faucet.Gate.Kind != "captcha" {
+		t.Errorf("expected Faucet.Gate.Kind to be captcha, got %s", cfg.Faucet.Gate.Kind)
+	}
+	if cfg.Faucet.Gate.PowDifficulty != 20 {
+		t.Errorf("expected Faucet.Gate.PowDifficulty to default to 20, got %d", cfg.Faucet.Gate.PowDifficulty)
+	}
+	if cfg.Faucet.Gate.CaptchaVerifyUrl != "https://captcha.example.com/siteverify" {
+		t.Errorf("expected Faucet.Gate.CaptchaVerifyUrl to be https://captcha.example.com/siteverify, got %s", cfg.Faucet.Gate.CaptchaVerifyUrl)
+	}
+	if cfg.Faucet.Gate.CaptchaSiteKey != "site-key" || cfg.Faucet.Gate.CaptchaSecret != "secret" {
+		t.Errorf("expected Faucet.Gate captcha keys site-key and secret, got %s and %s", cfg.Faucet.Gate.CaptchaSiteKey, cfg.Faucet.Gate.CaptchaSecret)
+	}
 	if cfg.Faucet.Erc20sPath != erc20File.Name() {
 		t.Errorf("expected Faucet.Erc20sPath to be %s, got %s", erc20File.Name(), cfg.Faucet.Erc20sPath)
 	}
@@ -195,12 +293,24 @@ func TestConfig_Load(t *testing.T) {
 	if cfg.Faucet.Erc20s[0].MinterPk != "904d5dea0bdffb09d78a81c15f0b3b893f504679eb8cd1de585309cad58e6285" {
 		t.Errorf("expected Faucet.Erc20s[0].MinterPk to be 904d5dea0bdffb09d78a81c15f0b3b893f504679eb8cd1de585309cad58e6285, got %s", cfg.Faucet.Erc20s[0].MinterPk)
 	}
+	if cfg.Faucet.Erc20s[0].DailyBudgetHex != "0x8ac7230489e80000" || cfg.Faucet.Erc20s[0].HourlyBudgetHex != "" {
+		t.Errorf("expected Faucet.Erc20s[0] daily budget 0x8ac7230489e80000 only, got %s and %s", cfg.Faucet.Erc20s[0].DailyBudgetHex, cfg.Faucet.Erc20s[0].HourlyBudgetHex)
+	}
 	if cfg.Faucet.Erc20s[1].Address != "0x1234567890123456789012345678901234567890" {
 		t.Errorf("expected Faucet.Erc20s[1].Address to be 0x1234567890123456789012345678901234567890, got %s", cfg.Faucet.Erc20s[1].Address)
 	}
 	if cfg.Faucet.Erc20s[1].MinterPk != "904d5dea0bdffb09d78a81c15f0b3b893f504679eb8cd1de585309cad58e6285" {
 		t.Errorf("expected Faucet.Erc20s[1].MinterPk to be 904d5dea0bdffb09d78a81c15f0b3b893f504679eb8cd1de585309cad58e6285, got %s", cfg.Faucet.Erc20s[1].MinterPk)
 	}
+	if cfg.Faucet.Erc20s[0].Mode != "" || cfg.Faucet.Erc20s[0].Amount != "" || cfg.Faucet.Erc20s[0].Decimals != nil {
+		t.Errorf("expected Faucet.Erc20s[0] to use the default mint mode and amount, got %+v", cfg.Faucet.Erc20s[0])
+	}
+	if cfg.Faucet.Erc20s[1].Mode != "transfer" || cfg.Faucet.Erc20s[1].Amount != "2.5" || cfg.Faucet.Erc20s[1].MaxClaim != "10" {
+		t.Errorf("expected Faucet.Erc20s[1] transfer of 2.5 tokens capped at 10, got %+v", cfg.Faucet.Erc20s[1])
+	}
+	if cfg.Faucet.Erc20s[1].Decimals == nil || *cfg.Faucet.Erc20s[1].Decimals != 6 {
+		t.Errorf("expected Faucet.Erc20s[1].Decimals to be 6, got %v", cfg.Faucet.Erc20s[1].Decimals)
+	}
 	if cfg.MetaFetcher.NumberOfAccountsUrl != "number-of-accounts-test-url" {
 		t.Errorf("expected MetaFetcher.NumberOfAccountsUrl to be number-of-accounts-test-url, got %s", cfg.MetaFetcher.NumberOfAccountsUrl)
 	}
@@ -237,6 +347,33 @@ func TestConfig_Load(t *testing.T) {
 	if cfg.Api.ResolverTimeout != 12 {
 		t.Errorf("expected Api.ResolverTimeout to be 12, got %d", cfg.Api.ResolverTimeout)
 	}
+	if len(cfg.Api.TrustedProxies) != 2 || cfg.Api.TrustedProxies[0] != "10.0.0.0/8" || cfg.Api.TrustedProxies[1] != "192.168.1.1" {
+		t.Errorf("expected Api.TrustedProxies to be [10.0.0.0/8 192.168.1.1], got %v", cfg.Api.TrustedProxies)
+	}
+	if cfg.Api.ClientIpHeader != "Forwarded" {
+		t.Errorf("expected Api.ClientIpHeader to be Forwarded, got %s", cfg.Api.ClientIpHeader)
+	}
+	if cfg.Api.MaxQueryDepth != 7 {
+		t.Errorf("expected Api.MaxQueryDepth to be 7, got %d", cfg.Api.MaxQueryDepth)
+	}
+	if cfg.Api.MaxQueryCost != 500 {
+		t.Errorf("expected Api.MaxQueryCost to be 500, got %d", cfg.Api.MaxQueryCost)
+	}
+	if cfg.Api.FieldCosts["block"]["fulltransactions"] != 20 {
+		t.Errorf("expected Api.FieldCosts.block.fullTransactions to be 20, got %v", cfg.Api.FieldCosts)
+	}
+	if cfg.Api.RateLimit.Store != "mongodb" {
+		t.Errorf("expected Api.RateLimit.Store to be mongodb, got %s", cfg.Api.RateLimit.Store)
+	}
+	if cfg.Api.RateLimit.Queries.Rate != 5.5 || cfg.Api.RateLimit.Queries.Burst != 10 {
+		t.Errorf("expected Api.RateLimit.Queries to be 5.5/10, got %v", cfg.Api.RateLimit.Queries)
+	}
+	if cfg.Api.RateLimit.Mutations.Rate != 0.1 || cfg.Api.RateLimit.Mutations.Burst != 2 {
+		t.Errorf("expected Api.RateLimit.Mutations to be 0.1/2, got %v", cfg.Api.RateLimit.Mutations)
+	}
+	if cfg.Api.RateLimit.Subscriptions.Rate != 0.2 || cfg.Api.RateLimit.Subscriptions.Burst != 5 {
+		t.Errorf("expected Api.RateLimit.Subscriptions to default to 0.2/5, got %v", cfg.Api.RateLimit.Subscriptions)
+	}
 	if cfg.Api.BindAddress != "bindAddress" {
 		t.Errorf("expected Api.BindAddress to be bindAddress, got %s", cfg.Api.BindAddress)
 	}
@@ -273,6 +410,21 @@ func TestConfig_Load(t *testing.T) {
 	if *cfg.MongoDb.Password != "testPassword" {
 		t.Errorf("expected Mongodb.Password to be testPassword, got %s", *cfg.MongoDb.Password)
 	}
+	if cfg.Siwe.Domain != "explorer.test" {
+		t.Errorf("expected Siwe.Domain to be explorer.test, got %s", cfg.Siwe.Domain)
+	}
+	if cfg.Siwe.Uri != "https://explorer.test" {
+		t.Errorf("expected Siwe.Uri to be https://explorer.test, got %s", cfg.Siwe.Uri)
+	}
+	if cfg.Siwe.ChainId != 4002 {
+		t.Errorf("expected Siwe.ChainId to be 4002, got %d", cfg.Siwe.ChainId)
+	}
+	if cfg.Siwe.ChallengeTtl != 300 {
+		t.Errorf("expected Siwe.ChallengeTtl to be 300, got %d", cfg.Siwe.ChallengeTtl)
+	}
+	if cfg.Siwe.SessionTtl != 3600 {
+		t.Errorf("expected Siwe.SessionTtl to be 3600, got %d", cfg.Siwe.SessionTtl)
+	}
 	// check maze config
 	if cfg.Maze == nil {
 		t.Errorf("expected Maze to be not nil")
@@ -280,6 +432,9 @@ func TestConfig_Load(t *testing.T) {
 	if cfg.Maze.VisibilityRange != 3 {
 		t.Errorf("expected Maze.VisibilityRange to be 3, got %d", cfg.Maze.VisibilityRange)
 	}
+	if cfg.Maze.ChallengeTtl != 120 {
+		t.Errorf("expected Maze.ChallengeTtl to be 120, got %d", cfg.Maze.ChallengeTtl)
+	}
 	if len(cfg.Maze.ConfigPaths) != 1 || cfg.Maze.ConfigPaths[0] != mazeFile.Name() {
 		t.Errorf("expected Maze.ConfigPaths to be [%s], got %v", mazeFile.Name(), cfg.Maze.ConfigPaths)
 	}
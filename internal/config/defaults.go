@@ -9,8 +9,13 @@ import (
 func applyDefaults(cfg *viper.Viper) {
 	// explorer
 	cfg.SetDefault("explorer.blockBufferSize", 10_000)
+	cfg.SetDefault("explorer.trxCacheSize", 50_000)
+	cfg.SetDefault("explorer.cacheObservedTrxs", false)
 	cfg.SetDefault("explorer.isPersisted", false)
 	cfg.SetDefault("explorer.maxTxsCount", 10_000_000)
+	cfg.SetDefault("explorer.fetchWorkers", 8)
+	cfg.SetDefault("explorer.enrichWorkers", 2)
+	cfg.SetDefault("explorer.gasOracleBlocks", 20)
 
 	// rpc
 	cfg.SetDefault("rpc.operaRpcUrl", "https://rpcapi.fantom.network")
@@ -25,6 +30,30 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault("api.bindAddress", "localhost:16761")
 	cfg.SetDefault("api.domainAddress", "localhost:16761")
 	cfg.SetDefault("api.corsOrigin", []string{"*"})
+	cfg.SetDefault("api.trustedProxies", []string{"127.0.0.1/32", "::1/128"})
+	cfg.SetDefault("api.clientIpHeader", "X-Forwarded-For")
+	cfg.SetDefault("api.maxQueryDepth", 15)
+	cfg.SetDefault("api.maxQueryCost", 10_000)
+	cfg.SetDefault("api.fieldCosts", map[string]interface{}{
+		"query":   map[string]interface{}{"recentBlocks": 10},
+		"block":   map[string]interface{}{"fullTransactions": 50},
+		"account": map[string]interface{}{"transactions": 250, "delegations": 100, "stakingRewards": 100},
+	})
+	cfg.SetDefault("api.rateLimit.store", "memory")
+	cfg.SetDefault("api.rateLimit.queries.rate", 20)
+	cfg.SetDefault("api.rateLimit.queries.burst", 100)
+	cfg.SetDefault("api.rateLimit.mutations.rate", 0.5)
+	cfg.SetDefault("api.rateLimit.mutations.burst", 5)
+	cfg.SetDefault("api.rateLimit.subscriptions.rate", 0.2)
+	cfg.SetDefault("api.rateLimit.subscriptions.burst", 5)
+
+	// sign-in with ethereum
+	cfg.SetDefault("siwe.domain", "localhost:16761")
+	cfg.SetDefault("siwe.uri", "http://localhost:16761")
+	cfg.SetDefault("siwe.statement", "Sign in to the Fantom explorer.")
+	cfg.SetDefault("siwe.chainId", 0)
+	cfg.SetDefault("siwe.challengeTtl", 300)
+	cfg.SetDefault("siwe.sessionTtl", 86400)
 
 	// logger
 	cfg.SetDefault("logger.loggingLevel", logging.INFO)
@@ -39,4 +68,11 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault("faucet.claimLimitSeconds", 86400)
 	cfg.SetDefault("faucet.claimTokensAmount", 0.5)
 	cfg.SetDefault("faucet.erc20MintAmountHex", "0x38d7ea4c68000")
+	cfg.SetDefault("faucet.minBalanceHex", "0xde0b6b3a7640000")
+	cfg.SetDefault("faucet.minMinterBalanceHex", "0xde0b6b3a7640000")
+	cfg.SetDefault("faucet.balanceCheckSeconds", 60)
+	cfg.SetDefault("faucet.gate.kind", "none")
+	cfg.SetDefault("faucet.gate.powDifficulty", 20)
+	cfg.SetDefault("faucet.batchWindowMillis", 2000)
+	cfg.SetDefault("faucet.batchMaxClaims", 100)
 }
@@ -0,0 +1,102 @@
+package faucet
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// batchClaim represents a native claim waiting in the batch queue.
+type batchClaim struct {
+	receiver common.Address
+	amount   *big.Int
+	result   chan batchResult
+}
+
+// batchResult represents the result of paying out a batched claim.
+type batchResult struct {
+	hash common.Hash
+	err  error
+}
+
+// batcher collects the native claims for a short window and pays them out
+// in a single call of the multisend contract, instead of a transaction per claim.
+type batcher struct {
+	wallet    IFaucetWallet
+	contract  common.Address
+	window    time.Duration
+	maxClaims int
+	queue     chan *batchClaim
+}
+
+// newBatcher creates a new batcher paying the claims by the given wallet and starts its queue.
+func newBatcher(w IFaucetWallet, contract common.Address, window time.Duration, maxClaims uint) *batcher {
+	if maxClaims == 0 {
+		maxClaims = 1
+	}
+	b := &batcher{
+		wallet:    w,
+		contract:  contract,
+		window:    window,
+		maxClaims: int(maxClaims),
+		queue:     make(chan *batchClaim, maxClaims),
+	}
+	go b.run()
+	return b
+}
+
+// Send queues the claim of the given amount of wei and waits until its batch is sent.
+// It returns the hash of the transaction paying the batch.
+func (b *batcher) Send(amount *big.Int, receiver common.Address) (common.Hash, error) {
+	c := &batchClaim{receiver: receiver, amount: amount, result: make(chan batchResult, 1)}
+	b.queue <- c
+	res := <-c.result
+	return res.hash, res.err
+}
+
+// run pays out the queued claims batch by batch.
+func (b *batcher) run() {
+	for first := range b.queue {
+		b.pay(b.collect(first))
+	}
+}
+
+// collect returns the batch of the given claim and the claims queued within the window after it.
+func (b *batcher) collect(first *batchClaim) []*batchClaim {
+	batch := []*batchClaim{first}
+	timer := time.NewTimer(b.window)
+	defer timer.Stop()
+
+	for len(batch) < b.maxClaims {
+		select {
+		case c := <-b.queue:
+			batch = append(batch, c)
+		case <-timer.C:
+			return batch
+		}
+	}
+	return batch
+}
+
+// pay sends the given batch and passes the result to all of its claims. A single claim is sent
+// directly, since the call of the multisend contract costs more gas than a plain transfer.
+func (b *batcher) pay(batch []*batchClaim) {
+	var hash common.Hash
+	var err error
+	if len(batch) == 1 {
+		hash, err = b.wallet.SendWeiToAddress(batch[0].amount, batch[0].receiver)
+	} else {
+		amounts := make([]*big.Int, len(batch))
+		receivers := make([]common.Address, len(batch))
+		for i, c := range batch {
+			amounts[i] = c.amount
+			receivers[i] = c.receiver
+		}
+		hash, err = b.wallet.SendWeiToAddresses(b.contract, amounts, receivers)
+	}
+
+	for _, c := range batch {
+		c.result <- batchResult{hash: hash, err: err}
+	}
+}
@@ -0,0 +1,78 @@
+package faucet
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+)
+
+// kMultisendAddress is the address of the multisend contract used in tests.
+const kMultisendAddress = "0xd152f549545093347a162dce210e7293f1452150"
+
+// sendBatch sends the claims of the given receivers concurrently and returns their results.
+func sendBatch(b *batcher, receivers []common.Address) []batchResult {
+	results := make([]batchResult, len(receivers))
+	var wg sync.WaitGroup
+	for i, receiver := range receivers {
+		wg.Add(1)
+		go func(i int, receiver common.Address) {
+			defer wg.Done()
+			hash, err := b.Send(big.NewInt(int64(i+1)), receiver)
+			results[i] = batchResult{hash: hash, err: err}
+		}(i, receiver)
+	}
+	wg.Wait()
+	return results
+}
+
+// Test that the claims queued within the window are paid out in a single multisend call.
+func TestBatcher_Send(t *testing.T) {
+	wallet := NewMockFaucetWallet(gomock.NewController(t))
+	contract := common.HexToAddress(kMultisendAddress)
+	b := newBatcher(wallet, contract, time.Minute, 3)
+
+	var receivers []common.Address
+	wallet.EXPECT().SendWeiToAddresses(contract, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ common.Address, amounts []*big.Int, to []common.Address) (common.Hash, error) {
+			receivers = to
+			if len(amounts) != len(to) {
+				t.Errorf("expected an amount per receiver, got %d amounts of %d receivers", len(amounts), len(to))
+			}
+			return common.Hash{0x0b}, nil
+		})
+
+	// the batch is paid out once full, without waiting for the window
+	results := sendBatch(b, []common.Address{{0x01}, {0x02}, {0x03}})
+	for _, res := range results {
+		if res.err != nil || res.hash != (common.Hash{0x0b}) {
+			t.Errorf("expected the batch transaction, got %s; %v", res.hash.Hex(), res.err)
+		}
+	}
+	if len(receivers) != 3 {
+		t.Errorf("expected 3 receivers in the batch, got %d", len(receivers))
+	}
+}
+
+// Test that a single claim is sent directly and the errors are passed to all the claims of a batch.
+func TestBatcher_SendSingleAndError(t *testing.T) {
+	wallet := NewMockFaucetWallet(gomock.NewController(t))
+	contract := common.HexToAddress(kMultisendAddress)
+	b := newBatcher(wallet, contract, 10*time.Millisecond, 2)
+
+	wallet.EXPECT().SendWeiToAddress(big.NewInt(1), common.Address{0x01}).Return(common.Hash{0x0a}, nil)
+	if hash, err := b.Send(big.NewInt(1), common.Address{0x01}); err != nil || hash != (common.Hash{0x0a}) {
+		t.Fatalf("expected the direct transaction, got %s; %v", hash.Hex(), err)
+	}
+
+	wallet.EXPECT().SendWeiToAddresses(contract, gomock.Any(), gomock.Any()).Return(common.Hash{}, fmt.Errorf("insufficient funds"))
+	for _, res := range sendBatch(b, []common.Address{{0x01}, {0x02}}) {
+		if res.err == nil {
+			t.Errorf("expected error of the batch")
+		}
+	}
+}
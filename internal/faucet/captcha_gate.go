@@ -0,0 +1,80 @@
+package faucet
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// kCaptchaVerifyTimeout is the timeout of the captcha verify request.
+const kCaptchaVerifyTimeout = 10 * time.Second
+
+// captchaVerifyResponse is the response of the captcha provider.
+type captchaVerifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// captchaGate represents a gate verifying the captcha responses by the verify
+// endpoint of an external provider, e.g. hCaptcha, reCAPTCHA or Turnstile.
+type captchaGate struct {
+	verifyUrl string
+	siteKey   string
+	secret    string
+	client    *http.Client
+}
+
+// newCaptchaGate creates a new captcha gate verifying the responses at the given url.
+func newCaptchaGate(verifyUrl string, siteKey string, secret string) *captchaGate {
+	return &captchaGate{
+		verifyUrl: verifyUrl,
+		siteKey:   siteKey,
+		secret:    secret,
+		client:    &http.Client{Timeout: kCaptchaVerifyTimeout},
+	}
+}
+
+// Params returns the parameters of the captcha.
+func (g *captchaGate) Params() GateParams {
+	return GateParams{
+		Kind:    kGateCaptcha,
+		SiteKey: g.siteKey,
+	}
+}
+
+// Verify verifies the given captcha response solved from the given ip address.
+func (g *captchaGate) Verify(ip string, response string) error {
+	if response == "" {
+		return fmt.Errorf("captcha response required")
+	}
+
+	resp, err := g.client.PostForm(g.verifyUrl, url.Values{
+		"secret":   {g.secret},
+		"response": {response},
+		"remoteip": {ip},
+		"sitekey":  {g.siteKey},
+	})
+	if err != nil {
+		return fmt.Errorf("error verifying captcha: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error verifying captcha: status %d", resp.StatusCode)
+	}
+
+	var result captchaVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("error decoding captcha verification: %v", err)
+	}
+	if !result.Success {
+		if len(result.ErrorCodes) > 0 {
+			return fmt.Errorf("invalid captcha response: %s", strings.Join(result.ErrorCodes, ", "))
+		}
+		return fmt.Errorf("invalid captcha response")
+	}
+	return nil
+}
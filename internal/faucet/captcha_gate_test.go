@@ -0,0 +1,39 @@
+package faucet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// createCaptchaGate creates a captcha gate verifying the responses by a local stub of the provider.
+func createCaptchaGate(t *testing.T) *captchaGate {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("secret") != "secret" || r.FormValue("remoteip") != "192.168.0.1" {
+			t.Errorf("unexpected verify request %v", r.Form)
+		}
+		if r.FormValue("response") == "solved" {
+			_, _ = w.Write([]byte(`{"success":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+	}))
+	t.Cleanup(server.Close)
+	return newCaptchaGate(server.URL, "site-key", "secret")
+}
+
+// Test that the captcha responses are verified by the provider.
+func TestCaptchaGate_Verify(t *testing.T) {
+	gate := createCaptchaGate(t)
+
+	if err := gate.Verify("192.168.0.1", "solved"); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if err := gate.Verify("192.168.0.1", "unsolved"); err == nil || err.Error() != "invalid captcha response: invalid-input-response" {
+		t.Fatalf("expected invalid captcha response, got %v", err)
+	}
+	if err := gate.Verify("192.168.0.1", ""); err == nil {
+		t.Fatalf("expected missing captcha response to be rejected")
+	}
+}
@@ -0,0 +1,87 @@
+package faucet
+
+import (
+	"fmt"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/repository"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// kErc20ModeMint is the mode of tokens minted to the receiver by the mint function.
+	kErc20ModeMint = "mint"
+
+	// kErc20ModeTransfer is the mode of tokens transferred to the receiver from the balance of the wallet.
+	kErc20ModeTransfer = "transfer"
+)
+
+// isErc20Transfer returns true if the given mode transfers the tokens instead of minting them.
+func isErc20Transfer(mode string) (bool, error) {
+	switch mode {
+	case "", kErc20ModeMint:
+		return false, nil
+	case kErc20ModeTransfer:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown erc20 mode %s", mode)
+	}
+}
+
+// erc20ClaimAmount returns the amount of the token given away per claim in its smallest units.
+// The configured amount of whole tokens replaces the default amount, the result is capped
+// by the configured maximal claim. The decimals are read from the token contract if not configured.
+func erc20ClaimAmount(cfg *config.FaucetErc20, defaultAmount *big.Int, repo repository.IRepository) (*big.Int, error) {
+	if cfg.Amount == "" && cfg.MaxClaim == "" {
+		return defaultAmount, nil
+	}
+
+	var decimals uint8
+	if cfg.Decimals != nil {
+		decimals = *cfg.Decimals
+	} else {
+		var err error
+		decimals, err = repo.Erc20Decimals(common.HexToAddress(cfg.Address))
+		if err != nil {
+			return nil, fmt.Errorf("error getting decimals: %v", err)
+		}
+	}
+
+	amount := defaultAmount
+	if cfg.Amount != "" {
+		var err error
+		amount, err = parseTokenAmount(cfg.Amount, decimals)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing amount: %v", err)
+		}
+	}
+	if cfg.MaxClaim != "" {
+		maxClaim, err := parseTokenAmount(cfg.MaxClaim, decimals)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing max claim: %v", err)
+		}
+		if amount.Cmp(maxClaim) > 0 {
+			amount = maxClaim
+		}
+	}
+	return amount, nil
+}
+
+// parseTokenAmount converts the given decimal amount of whole tokens into the smallest units of the token.
+func parseTokenAmount(amount string, decimals uint8) (*big.Int, error) {
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %s", amount)
+	}
+	if r.Sign() <= 0 {
+		return nil, fmt.Errorf("amount %s must be positive", amount)
+	}
+
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	r.Mul(r, new(big.Rat).SetInt(unit))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("amount %s has more than %d decimals", amount, decimals)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
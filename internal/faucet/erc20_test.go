@@ -0,0 +1,91 @@
+package faucet
+
+import (
+	"fmt"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/repository"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+)
+
+// Test that the human amounts are converted to the smallest units of the token.
+func TestFaucetErc20_ParseTokenAmount(t *testing.T) {
+	for _, tc := range []struct {
+		amount   string
+		decimals uint8
+		expected *big.Int
+	}{
+		{"1", 18, big.NewInt(1_000_000_000_000_000_000)},
+		{"2.5", 6, big.NewInt(2_500_000)},
+		{"0.000001", 6, big.NewInt(1)},
+		{"15", 0, big.NewInt(15)},
+	} {
+		amount, err := parseTokenAmount(tc.amount, tc.decimals)
+		if err != nil {
+			t.Fatalf("parseTokenAmount(%s, %d) failed: %v", tc.amount, tc.decimals, err)
+		}
+		if amount.Cmp(tc.expected) != 0 {
+			t.Errorf("expected %s of %d decimals to be %d, got %d", tc.amount, tc.decimals, tc.expected, amount)
+		}
+	}
+
+	for _, amount := range []string{"", "abc", "0", "-1", "0.0000001"} {
+		if _, err := parseTokenAmount(amount, 6); err == nil {
+			t.Errorf("expected amount %q to be rejected", amount)
+		}
+	}
+}
+
+// Test that the claim amount is resolved from the configuration and the token decimals.
+func TestFaucetErc20_ClaimAmount(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	defaultAmount := big.NewInt(kErc20MintAmount)
+	six := uint8(6)
+
+	// the default amount is used if no amount is configured
+	amount, err := erc20ClaimAmount(&config.FaucetErc20{Address: kErc20Address}, defaultAmount, repo)
+	if err != nil || amount.Cmp(defaultAmount) != 0 {
+		t.Errorf("expected default amount, got %v, %v", amount, err)
+	}
+
+	// the decimals are read from the contract if not configured
+	repo.EXPECT().Erc20Decimals(common.HexToAddress(kErc20Address)).Return(uint8(6), nil)
+	amount, err = erc20ClaimAmount(&config.FaucetErc20{Address: kErc20Address, Amount: "3"}, defaultAmount, repo)
+	if err != nil || amount.Cmp(big.NewInt(3_000_000)) != 0 {
+		t.Errorf("expected 3 tokens of 6 decimals, got %v, %v", amount, err)
+	}
+
+	// the amount is capped by the max claim
+	amount, err = erc20ClaimAmount(&config.FaucetErc20{Address: kErc20Address, Amount: "3", MaxClaim: "0.5", Decimals: &six}, defaultAmount, repo)
+	if err != nil || amount.Cmp(big.NewInt(500_000)) != 0 {
+		t.Errorf("expected capped amount of 0.5 tokens, got %v, %v", amount, err)
+	}
+
+	// the default amount is capped too
+	amount, err = erc20ClaimAmount(&config.FaucetErc20{Address: kErc20Address, MaxClaim: "0.001", Decimals: &six}, defaultAmount, repo)
+	if err != nil || amount.Cmp(big.NewInt(1_000)) != 0 {
+		t.Errorf("expected capped default amount, got %v, %v", amount, err)
+	}
+
+	// the decimals must be known
+	repo.EXPECT().Erc20Decimals(common.HexToAddress(kErc20Address)).Return(uint8(0), fmt.Errorf("execution reverted"))
+	if _, err := erc20ClaimAmount(&config.FaucetErc20{Address: kErc20Address, Amount: "1"}, defaultAmount, repo); err == nil {
+		t.Errorf("expected error of unknown decimals")
+	}
+}
+
+// Test that the erc20 modes are recognized.
+func TestFaucetErc20_Mode(t *testing.T) {
+	for mode, expected := range map[string]bool{"": false, kErc20ModeMint: false, kErc20ModeTransfer: true} {
+		transfer, err := isErc20Transfer(mode)
+		if err != nil || transfer != expected {
+			t.Errorf("expected mode %q transfer %v, got %v, %v", mode, expected, transfer, err)
+		}
+	}
+	if _, err := isErc20Transfer("burn"); err == nil {
+		t.Errorf("expected unknown mode to be rejected")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"math"
 	"math/big"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -24,38 +25,100 @@ const (
 	kFaucetChallengePrefixRegex = `(?mi)^Please sign following text to obtain \d+(\.\d+)? [a-z\s]{3,25} tokens:\n\n(.+)`
 )
 
+// Status represents the funding status of the faucet for a token.
+type Status struct {
+	// Balance is the native balance of the wallet paying the claims, nil if not observed yet.
+	Balance *big.Int
+
+	// RemainingClaims is the number of claims the balance can pay before the claims
+	// are disabled, nil for erc20 tokens, whose claims pay only the minting gas.
+	RemainingClaims *uint64
+
+	// Enabled tells if the claims of the token are enabled.
+	Enabled bool
+}
+
 // FaucetErc20 represents a faucet erc20 token.
 type FaucetErc20 struct {
 	address common.Address
 	wallet  IFaucetWallet
+	budgets []claimBudget
+
+	// transfer is true if the tokens are transferred from the balance of the wallet instead of minted.
+	transfer bool
+
+	// amount is the amount of tokens given away per claim, nil for the default amount of the faucet.
+	amount *big.Int
 }
 
 // Faucet represents a faucet instance. It provides access to the
 // faucet functionality. It is used to request and claim tokens.
 type Faucet struct {
-	pg              IFaucetPhraseGenerator
-	wallet          IFaucetWallet
-	repo            repository.IRepository
-	cfg             *config.Faucet
-	erc20s          map[common.Address]FaucetErc20
-	erc20MintAmount *big.Int
+	pg      IFaucetPhraseGenerator
+	wallet  IFaucetWallet
+	repo    repository.IRepository
+	cfg     *config.Faucet
+	erc20s  map[common.Address]FaucetErc20
+	monitor IFaucetMonitor
+	gate    IFaucetGate
+
+	// budgets are the budgets of the native token.
+	budgets []claimBudget
+
+	// batcher pays out the native claims in batches, nil if the batching is disabled.
+	batcher *batcher
+
+	// mu serializes checking of the claim limits and reserving of the claims.
+	mu sync.Mutex
 }
 
 // NewFaucet creates a new faucet instance.
-func NewFaucet(cfg *config.Faucet, pg IFaucetPhraseGenerator, w IFaucetWallet, erc20s []FaucetErc20, repo repository.IRepository) (*Faucet, error) {
+func NewFaucet(cfg *config.Faucet, pg IFaucetPhraseGenerator, w IFaucetWallet, erc20s []FaucetErc20, monitor IFaucetMonitor, gate IFaucetGate, repo repository.IRepository) (*Faucet, error) {
 	mintAmount, err := hexutil.DecodeBig(cfg.Erc20MintAmountHex)
 	if err != nil {
 		return nil, fmt.Errorf("error decoding faucet erc20 amount: %v", err)
 	}
 	f := &Faucet{
-		pg:              pg,
-		wallet:          w,
-		repo:            repo,
-		cfg:             cfg,
-		erc20s:          make(map[common.Address]FaucetErc20),
-		erc20MintAmount: mintAmount,
+		pg:      pg,
+		wallet:  w,
+		repo:    repo,
+		cfg:     cfg,
+		erc20s:  make(map[common.Address]FaucetErc20),
+		monitor: monitor,
+		gate:    gate,
 	}
 	for _, erc20 := range erc20s {
+		if erc20.amount == nil {
+			erc20.amount = mintAmount
+		}
+		f.erc20s[erc20.address] = erc20
+		erc20.wallet.OnReplaced(f.replaceTxHash)
+	}
+	w.OnReplaced(f.replaceTxHash)
+
+	// pay out the native claims in batches if the multisend contract is configured
+	if cfg.MultisendAddress != "" {
+		if !common.IsHexAddress(cfg.MultisendAddress) {
+			return nil, fmt.Errorf("invalid faucet multisend address: %s", cfg.MultisendAddress)
+		}
+		window := time.Duration(cfg.BatchWindowMillis) * time.Millisecond
+		f.batcher = newBatcher(w, common.HexToAddress(cfg.MultisendAddress), window, cfg.BatchMaxClaims)
+	}
+
+	// decode budgets of the native token and erc20 tokens
+	f.budgets, err = newClaimBudgets(cfg.HourlyBudgetHex, cfg.DailyBudgetHex, getTokensAmountInWei(float64(cfg.ClaimTokensAmount)))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding faucet budgets: %v", err)
+	}
+	for _, ec := range cfg.Erc20s {
+		erc20, ok := f.erc20s[common.HexToAddress(ec.Address)]
+		if !ok {
+			continue
+		}
+		erc20.budgets, err = newClaimBudgets(ec.HourlyBudgetHex, ec.DailyBudgetHex, erc20.amount)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding budgets of erc20 %s: %v", ec.Address, err)
+		}
 		f.erc20s[erc20.address] = erc20
 	}
 	return f, nil
@@ -63,6 +126,11 @@ func NewFaucet(cfg *config.Faucet, pg IFaucetPhraseGenerator, w IFaucetWallet, e
 
 // NewFaucetErc20s creates a new faucet erc20 tokens.
 func NewFaucetErc20s(cfg *config.Faucet, repo repository.IRepository, log logger.ILogger) ([]FaucetErc20, error) {
+	defaultAmount, err := hexutil.DecodeBig(cfg.Erc20MintAmountHex)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding faucet erc20 amount: %v", err)
+	}
+
 	erc20s := make([]FaucetErc20, len(cfg.Erc20s))
 	for i, erc20 := range cfg.Erc20s {
 		address := common.HexToAddress(erc20.Address)
@@ -70,63 +138,49 @@ func NewFaucetErc20s(cfg *config.Faucet, repo repository.IRepository, log logger
 		if err != nil {
 			return nil, fmt.Errorf("error creating faucet wallet: %v", err)
 		}
+		transfer, err := isErc20Transfer(erc20.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("error creating faucet erc20 %s: %v", erc20.Address, err)
+		}
+		amount, err := erc20ClaimAmount(&cfg.Erc20s[i], defaultAmount, repo)
+		if err != nil {
+			return nil, fmt.Errorf("error creating faucet erc20 %s: %v", erc20.Address, err)
+		}
 		erc20s[i] = FaucetErc20{
-			address: address,
-			wallet:  wallet,
+			address:  address,
+			wallet:   wallet,
+			transfer: transfer,
+			amount:   amount,
 		}
 	}
 	return erc20s, nil
 }
 
-// RequestTokens requests tokens for the given ip address and phrase. Returns
-// the challenge to be signed by the user.
-func (f *Faucet) RequestTokens(ipAddress string, symbol *string) (string, error) {
-	// check if the ip address is already in the database
-	tr, err := f.repo.GetLatestUnclaimedTokensRequest(ipAddress)
-	if err != nil {
-		return "", fmt.Errorf("error getting latest tokens request: %v", err)
-	}
-
+// RequestTokens requests tokens for the given ip address and phrase. The request must pass
+// the gate of the faucet by the given proof. Returns the challenge to be signed by the user.
+func (f *Faucet) RequestTokens(ipAddress string, symbol *string, proof string) (string, error) {
 	// validate symbol
 	if symbol != nil {
-		if err = validateSymbol(*symbol); err != nil {
+		if err := validateSymbol(*symbol); err != nil {
 			return "", err
 		}
 	}
 
-	prefix := generatePrefix(float64(f.cfg.ClaimTokensAmount), symbol)
-
-	// if there is unclaimed request, return it
-	if tr != nil {
-		return prefix + tr.Phrase, nil
-	}
-
-	// otherwise get all requests for the given ip address in the last 24 hours
-	requests, err := f.repo.GetLatestClaimedTokensRequests(ipAddress, uint64(time.Now().Unix()-24*60*60))
-	if err != nil {
-		return "", fmt.Errorf("error getting latest tokens requests: %v", err)
-	}
-
-	// check if the number of requests is greater than the allowed number of claims per day
-	if len(requests) >= int(f.cfg.ClaimsPerDay) {
-		return "", fmt.Errorf("too many requests, you are allowed to claim %d times per day", f.cfg.ClaimsPerDay)
+	// verify the request is not made by a bot
+	if err := f.gate.Verify(ipAddress, proof); err != nil {
+		return "", err
 	}
 
-	phrase, err := f.pg.GeneratePhrase()
-	if err != nil {
-		return "", fmt.Errorf("error generating phrase: %v", err)
-	}
-
-	// create a new request
-	tr = &types.TokensRequest{
-		IpAddress: ipAddress,
-		Phrase:    phrase,
-	}
-	err = f.repo.AddTokensRequest(tr)
+	tr, err := f.pendingRequest(ipAddress)
 	if err != nil {
 		return "", err
 	}
-	return prefix + tr.Phrase, nil
+	return generatePrefix(float64(f.cfg.ClaimTokensAmount), symbol) + tr.Phrase, nil
+}
+
+// GateParams returns the parameters the clients need to pass the gate of the faucet.
+func (f *Faucet) GateParams() GateParams {
+	return f.gate.Params()
 }
 
 // ClaimTokens claims tokens for the given phrase and receiver address.
@@ -154,6 +208,68 @@ func (f *Faucet) ClaimTokens(ip string, phrase string, receiver common.Address,
 		return fmt.Errorf("invalid phrase")
 	}
 
+	return f.claim(tr, receiver, erc20)
+}
+
+// ClaimTokensToAddress claims tokens for the receiver address, which has already been
+// authenticated by a sign-in session. The claim has to pass the gate of the faucet
+// and the daily claims limit of the ip address applies.
+func (f *Faucet) ClaimTokensToAddress(ip string, receiver common.Address, erc20 *common.Address, proof string) error {
+	// verify the claim is not made by a bot
+	if err := f.gate.Verify(ip, proof); err != nil {
+		return err
+	}
+
+	tr, err := f.pendingRequest(ip)
+	if err != nil {
+		return err
+	}
+	return f.claim(tr, receiver, erc20)
+}
+
+// pendingRequest returns the unclaimed tokens request of the given ip address. A new request
+// is created if there is none and the ip address has not reached the daily claims limit.
+func (f *Faucet) pendingRequest(ipAddress string) (*types.TokensRequest, error) {
+	// check if the ip address is already in the database
+	tr, err := f.repo.GetLatestUnclaimedTokensRequest(ipAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest tokens request: %v", err)
+	}
+
+	// if there is unclaimed request, return it
+	if tr != nil {
+		return tr, nil
+	}
+
+	// otherwise get all requests for the given ip address in the last 24 hours
+	requests, err := f.repo.GetLatestClaimedTokensRequests(ipAddress, uint64(time.Now().Unix()-24*60*60))
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest tokens requests: %v", err)
+	}
+
+	// check if the number of requests is greater than the allowed number of claims per day
+	if len(requests) >= int(f.cfg.ClaimsPerDay) {
+		return nil, fmt.Errorf("too many requests, you are allowed to claim %d times per day", f.cfg.ClaimsPerDay)
+	}
+
+	phrase, err := f.pg.GeneratePhrase()
+	if err != nil {
+		return nil, fmt.Errorf("error generating phrase: %v", err)
+	}
+
+	// create a new request
+	tr = &types.TokensRequest{
+		IpAddress: ipAddress,
+		Phrase:    phrase,
+	}
+	if err = f.repo.AddTokensRequest(tr); err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+// claim marks the given tokens request claimed and sends the tokens to the receiver.
+func (f *Faucet) claim(tr *types.TokensRequest, receiver common.Address, erc20 *common.Address) error {
 	// check if the request was already fulfilled
 	if tr.ClaimedAt != nil {
 		return fmt.Errorf("tokens already claimed")
@@ -167,41 +283,118 @@ func (f *Faucet) ClaimTokens(ip string, phrase string, receiver common.Address,
 		}
 	}
 
-	// update the request
-	tr.Receiver = &receiver
-	now := time.Now().Unix()
-	tr.ClaimedAt = &now
-	err = f.repo.UpdateTokensRequest(tr)
+	// check the wallet paying the claim has enough funds
+	if err := f.monitor.Check(erc20); err != nil {
+		return err
+	}
+
+	// check the limits and update the request
+	err := f.reserve(tr, receiver, erc20)
 	if err != nil {
-		return fmt.Errorf("error updating tokens request: %v", err)
+		return err
 	}
 
 	// send native tokens to the receiver if erc20 is nil
+	var hash common.Hash
 	if erc20 == nil {
-		if err = f.wallet.SendWeiToAddress(getTokensAmountInWei(float64(f.cfg.ClaimTokensAmount)), receiver); err != nil {
+		hash, err = f.sendWei(getTokensAmountInWei(float64(f.cfg.ClaimTokensAmount)), receiver)
+		if err != nil {
 			// if we got error, we need to set back the request to the previous state
-			tr.Receiver = nil
-			tr.ClaimedAt = nil
-			_ = f.repo.UpdateTokensRequest(tr)
+			f.resetClaim(tr)
 			return fmt.Errorf("error sending wei to address: %v", err)
 		}
-		return nil
+	} else {
+		erc20Faucet := f.erc20s[*erc20]
+
+		// send erc20 tokens to the receiver
+		if erc20Faucet.transfer {
+			hash, err = erc20Faucet.wallet.TransferErc20TokensToAddress(erc20Faucet.address, receiver, erc20Faucet.amount)
+		} else {
+			hash, err = erc20Faucet.wallet.MintErc20TokensToAddress(erc20Faucet.address, receiver, erc20Faucet.amount)
+		}
+		if err != nil {
+			// if we got error, we need to set back the request to the previous state
+			f.resetClaim(tr)
+			return fmt.Errorf("error sending erc20 to address: %v", err)
+		}
 	}
 
-	erc20Faucet := f.erc20s[*erc20]
+	// store the transaction, so the claim watcher can confirm it; the claims
+	// paid out in a batch share the transaction and so the status
+	tr.TxHash = &hash
+	if err := f.repo.UpdateTokensRequest(tr); err != nil {
+		return fmt.Errorf("error storing claim transaction %s: %v", hash.Hex(), err)
+	}
+	return nil
+}
 
-	// send erc20 tokens to the receiver
-	if err = erc20Faucet.wallet.MintErc20TokensToAddress(erc20Faucet.address, receiver, f.erc20MintAmount); err != nil {
-		// if we got error, we need to set back the request to the previous state
-		tr.Receiver = nil
-		tr.ClaimedAt = nil
-		_ = f.repo.UpdateTokensRequest(tr)
-		return fmt.Errorf("error minting erc20 to address: %v", err)
+// sendWei sends the given amount of wei to the receiver, in a batch if the batching is enabled.
+func (f *Faucet) sendWei(amount *big.Int, receiver common.Address) (common.Hash, error) {
+	if f.batcher != nil {
+		return f.batcher.Send(amount, receiver)
 	}
+	return f.wallet.SendWeiToAddress(amount, receiver)
+}
 
+// Status returns the funding status of the faucet for the given erc20 token, nil for the native token.
+func (f *Faucet) Status(erc20 *common.Address) (*Status, error) {
+	ws, err := f.monitor.Status(erc20)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Balance: ws.Balance,
+		Enabled: ws.Enabled,
+	}
+	if erc20 == nil && ws.Balance != nil {
+		// the claims can be paid until the balance falls below the minimum
+		var remaining uint64
+		claimAmount := getTokensAmountInWei(float64(f.cfg.ClaimTokensAmount))
+		available := new(big.Int).Sub(ws.Balance, ws.MinBalance)
+		if available.Sign() > 0 && claimAmount.Sign() > 0 {
+			remaining = new(big.Int).Div(available, claimAmount).Uint64()
+		}
+		status.RemainingClaims = &remaining
+	}
+	return status, nil
+}
+
+// reserve marks the given request claimed if the claim fits into the limits of the receiver
+// and the budgets of the token. Concurrent claims are reserved one by one, so they can not exceed the limits.
+func (f *Faucet) reserve(tr *types.TokensRequest, receiver common.Address, erc20 *common.Address) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.checkLimits(receiver, erc20); err != nil {
+		return err
+	}
+
+	tr.Receiver = &receiver
+	now := time.Now().Unix()
+	tr.ClaimedAt = &now
+	tr.Erc20 = erc20
+	tr.Status = types.TokensRequestPending
+	if err := f.repo.UpdateTokensRequest(tr); err != nil {
+		return fmt.Errorf("error updating tokens request: %v", err)
+	}
 	return nil
 }
 
+// resetClaim sets the given request back to the unclaimed state.
+func (f *Faucet) resetClaim(tr *types.TokensRequest) {
+	tr.Receiver = nil
+	tr.ClaimedAt = nil
+	tr.Erc20 = nil
+	tr.Status = ""
+	_ = f.repo.UpdateTokensRequest(tr)
+}
+
+// replaceTxHash updates the claim whose transaction has been replaced by a wallet.
+func (f *Faucet) replaceTxHash(old common.Hash, new common.Hash) {
+	_ = f.repo.ReplaceTokensRequestTxHash(old, new)
+}
+
 // getTokensAmountInWei converts the given amount of tokens to wei.
 func getTokensAmountInWei(amount float64) *big.Int {
 	a := new(big.Rat).SetFloat64(amount)
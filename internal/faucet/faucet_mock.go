@@ -49,19 +49,62 @@ func (mr *MockFaucetMockRecorder) ClaimTokens(ip, phrase, receiver, erc20 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTokens", reflect.TypeOf((*MockFaucet)(nil).ClaimTokens), ip, phrase, receiver, erc20)
 }
 
+// ClaimTokensToAddress mocks base method.
+func (m *MockFaucet) ClaimTokensToAddress(ip string, receiver common.Address, erc20 *common.Address, proof string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTokensToAddress", ip, receiver, erc20, proof)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimTokensToAddress indicates an expected call of ClaimTokensToAddress.
+func (mr *MockFaucetMockRecorder) ClaimTokensToAddress(ip, receiver, erc20, proof interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTokensToAddress", reflect.TypeOf((*MockFaucet)(nil).ClaimTokensToAddress), ip, receiver, erc20, proof)
+}
+
+// GateParams mocks base method.
+func (m *MockFaucet) GateParams() GateParams {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GateParams")
+	ret0, _ := ret[0].(GateParams)
+	return ret0
+}
+
+// GateParams indicates an expected call of GateParams.
+func (mr *MockFaucetMockRecorder) GateParams() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GateParams", reflect.TypeOf((*MockFaucet)(nil).GateParams))
+}
+
 // RequestTokens mocks base method.
-func (m *MockFaucet) RequestTokens(arg0 string, arg1 *string) (string, error) {
+func (m *MockFaucet) RequestTokens(ip string, symbol *string, proof string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RequestTokens", arg0, arg1)
+	ret := m.ctrl.Call(m, "RequestTokens", ip, symbol, proof)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RequestTokens indicates an expected call of RequestTokens.
-func (mr *MockFaucetMockRecorder) RequestTokens(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockFaucetMockRecorder) RequestTokens(ip, symbol, proof interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestTokens", reflect.TypeOf((*MockFaucet)(nil).RequestTokens), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestTokens", reflect.TypeOf((*MockFaucet)(nil).RequestTokens), ip, symbol, proof)
+}
+
+// Status mocks base method.
+func (m *MockFaucet) Status(erc20 *common.Address) (*Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", erc20)
+	ret0, _ := ret[0].(*Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockFaucetMockRecorder) Status(erc20 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockFaucet)(nil).Status), erc20)
 }
 
 // MockFaucetPhraseGenerator is a mock of IFaucetPhraseGenerator interface.
@@ -125,12 +168,27 @@ func (m *MockFaucetWallet) EXPECT() *MockFaucetWalletMockRecorder {
 	return m.recorder
 }
 
+// Address mocks base method.
+func (m *MockFaucetWallet) Address() common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Address")
+	ret0, _ := ret[0].(common.Address)
+	return ret0
+}
+
+// Address indicates an expected call of Address.
+func (mr *MockFaucetWalletMockRecorder) Address() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Address", reflect.TypeOf((*MockFaucetWallet)(nil).Address))
+}
+
 // MintErc20TokensToAddress mocks base method.
-func (m *MockFaucetWallet) MintErc20TokensToAddress(arg0, arg1 common.Address, arg2 *big.Int) error {
+func (m *MockFaucetWallet) MintErc20TokensToAddress(arg0, arg1 common.Address, arg2 *big.Int) (common.Hash, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "MintErc20TokensToAddress", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // MintErc20TokensToAddress indicates an expected call of MintErc20TokensToAddress.
@@ -139,12 +197,25 @@ func (mr *MockFaucetWalletMockRecorder) MintErc20TokensToAddress(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintErc20TokensToAddress", reflect.TypeOf((*MockFaucetWallet)(nil).MintErc20TokensToAddress), arg0, arg1, arg2)
 }
 
+// OnReplaced mocks base method.
+func (m *MockFaucetWallet) OnReplaced(arg0 func(common.Hash, common.Hash)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnReplaced", arg0)
+}
+
+// OnReplaced indicates an expected call of OnReplaced.
+func (mr *MockFaucetWalletMockRecorder) OnReplaced(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnReplaced", reflect.TypeOf((*MockFaucetWallet)(nil).OnReplaced), arg0)
+}
+
 // SendWeiToAddress mocks base method.
-func (m *MockFaucetWallet) SendWeiToAddress(amount *big.Int, receiver common.Address) error {
+func (m *MockFaucetWallet) SendWeiToAddress(amount *big.Int, receiver common.Address) (common.Hash, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SendWeiToAddress", amount, receiver)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SendWeiToAddress indicates an expected call of SendWeiToAddress.
@@ -152,3 +223,136 @@ func (mr *MockFaucetWalletMockRecorder) SendWeiToAddress(amount, receiver interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendWeiToAddress", reflect.TypeOf((*MockFaucetWallet)(nil).SendWeiToAddress), amount, receiver)
 }
+
+// SendWeiToAddresses mocks base method.
+func (m *MockFaucetWallet) SendWeiToAddresses(contract common.Address, amounts []*big.Int, receivers []common.Address) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendWeiToAddresses", contract, amounts, receivers)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendWeiToAddresses indicates an expected call of SendWeiToAddresses.
+func (mr *MockFaucetWalletMockRecorder) SendWeiToAddresses(contract, amounts, receivers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendWeiToAddresses", reflect.TypeOf((*MockFaucetWallet)(nil).SendWeiToAddresses), contract, amounts, receivers)
+}
+
+// TransferErc20TokensToAddress mocks base method.
+func (m *MockFaucetWallet) TransferErc20TokensToAddress(arg0, arg1 common.Address, arg2 *big.Int) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferErc20TokensToAddress", arg0, arg1, arg2)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferErc20TokensToAddress indicates an expected call of TransferErc20TokensToAddress.
+func (mr *MockFaucetWalletMockRecorder) TransferErc20TokensToAddress(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferErc20TokensToAddress", reflect.TypeOf((*MockFaucetWallet)(nil).TransferErc20TokensToAddress), arg0, arg1, arg2)
+}
+
+// MockFaucetMonitor is a mock of IFaucetMonitor interface.
+type MockFaucetMonitor struct {
+	ctrl     *gomock.Controller
+	recorder *MockFaucetMonitorMockRecorder
+}
+
+// MockFaucetMonitorMockRecorder is the mock recorder for MockFaucetMonitor.
+type MockFaucetMonitorMockRecorder struct {
+	mock *MockFaucetMonitor
+}
+
+// NewMockFaucetMonitor creates a new mock instance.
+func NewMockFaucetMonitor(ctrl *gomock.Controller) *MockFaucetMonitor {
+	mock := &MockFaucetMonitor{ctrl: ctrl}
+	mock.recorder = &MockFaucetMonitorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFaucetMonitor) EXPECT() *MockFaucetMonitorMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockFaucetMonitor) Check(erc20 *common.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", erc20)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockFaucetMonitorMockRecorder) Check(erc20 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockFaucetMonitor)(nil).Check), erc20)
+}
+
+// Status mocks base method.
+func (m *MockFaucetMonitor) Status(erc20 *common.Address) (*WalletStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", erc20)
+	ret0, _ := ret[0].(*WalletStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockFaucetMonitorMockRecorder) Status(erc20 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockFaucetMonitor)(nil).Status), erc20)
+}
+
+// MockFaucetGate is a mock of IFaucetGate interface.
+type MockFaucetGate struct {
+	ctrl     *gomock.Controller
+	recorder *MockFaucetGateMockRecorder
+}
+
+// MockFaucetGateMockRecorder is the mock recorder for MockFaucetGate.
+type MockFaucetGateMockRecorder struct {
+	mock *MockFaucetGate
+}
+
+// NewMockFaucetGate creates a new mock instance.
+func NewMockFaucetGate(ctrl *gomock.Controller) *MockFaucetGate {
+	mock := &MockFaucetGate{ctrl: ctrl}
+	mock.recorder = &MockFaucetGateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFaucetGate) EXPECT() *MockFaucetGateMockRecorder {
+	return m.recorder
+}
+
+// Params mocks base method.
+func (m *MockFaucetGate) Params() GateParams {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Params")
+	ret0, _ := ret[0].(GateParams)
+	return ret0
+}
+
+// Params indicates an expected call of Params.
+func (mr *MockFaucetGateMockRecorder) Params() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Params", reflect.TypeOf((*MockFaucetGate)(nil).Params))
+}
+
+// Verify mocks base method.
+func (m *MockFaucetGate) Verify(ip, proof string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ip, proof)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockFaucetGateMockRecorder) Verify(ip, proof interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockFaucetGate)(nil).Verify), ip, proof)
+}
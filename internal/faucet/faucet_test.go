@@ -7,6 +7,7 @@ import (
 	"ftm-explorer/internal/types"
 	"math/big"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -56,7 +57,7 @@ func TestFaucet_RequestTokens(t *testing.T) {
 		Phrase:    "test-phrase",
 	}).Return(nil)
 
-	phrase, err := faucet.RequestTokens(ipAddress, nil)
+	phrase, err := faucet.RequestTokens(ipAddress, nil, "")
 	if err != nil {
 		t.Fatalf("RequestTokens failed: %v", err)
 	}
@@ -66,6 +67,21 @@ func TestFaucet_RequestTokens(t *testing.T) {
 	}
 }
 
+// Test that the request is rejected if it does not pass the gate.
+func TestFaucet_RequestTokensGateRejected(t *testing.T) {
+	faucet, _, _, _, _ := createFaucet(t)
+	ipAddress := "192.168.0.1"
+
+	gate := NewMockFaucetGate(gomock.NewController(t))
+	gate.EXPECT().Verify(ipAddress, "proof").Return(fmt.Errorf("insufficient proof of work"))
+	faucet.gate = gate
+
+	// expect no request to be created
+	if _, err := faucet.RequestTokens(ipAddress, nil, "proof"); err == nil || err.Error() != "insufficient proof of work" {
+		t.Fatalf("expected gate error, got %v", err)
+	}
+}
+
 // Test that the existing tokens request is returned.
 func TestFaucet_RequestTokensAlreadyPending(t *testing.T) {
 	faucet, _, _, _, repo := createFaucet(t)
@@ -80,7 +96,7 @@ func TestFaucet_RequestTokensAlreadyPending(t *testing.T) {
 	repo.EXPECT().GetLatestUnclaimedTokensRequest(ipAddress).Return(tr, nil)
 
 	// the request defined above should be returned, because it is pending
-	_, err := faucet.RequestTokens(ipAddress, nil)
+	_, err := faucet.RequestTokens(ipAddress, nil, "")
 	if err != nil {
 		t.Fatalf("RequestTokens failed: %v", err)
 	}
@@ -122,7 +138,7 @@ func TestFaucet_RequestTokensClaimLimitNotReached(t *testing.T) {
 	repo.EXPECT().GetLatestClaimedTokensRequests(ipAddress, gomock.Any()).Return(trs, nil)
 
 	// we should get an error, because the claim limit is not reached
-	_, err := faucet.RequestTokens(ipAddress, nil)
+	_, err := faucet.RequestTokens(ipAddress, nil, "")
 	if err == nil || !strings.Contains(err.Error(), "too many requests") {
 		t.Fatal("RequestTokens did not return error")
 	}
@@ -146,7 +162,7 @@ func TestFaucet_RequestTokensClaimLimitReached(t *testing.T) {
 	repo.EXPECT().AddTokensRequest(gomock.Any()).Return(nil)
 
 	// we should get a new tokens request, because the claim limit is reached
-	phrase, err := faucet.RequestTokens(ipAddress, nil)
+	phrase, err := faucet.RequestTokens(ipAddress, nil, "")
 	if err != nil {
 		t.Fatalf("RequestTokens failed: %v", err)
 	}
@@ -176,10 +192,19 @@ func TestFaucet_ClaimTokens(t *testing.T) {
 		Phrase:    phrase,
 		Receiver:  &receiver,
 		ClaimedAt: &now,
+		Status:    types.TokensRequestPending,
 	})).Return(nil)
 
 	// expect a call to wallet to send tokens
-	wallet.EXPECT().SendWeiToAddress(gomock.Eq(getTokensAmountInWei(kClaimTokensAmount)), gomock.Eq(receiver)).Return(nil)
+	wallet.EXPECT().SendWeiToAddress(gomock.Eq(getTokensAmountInWei(kClaimTokensAmount)), gomock.Eq(receiver)).Return(common.Hash{0x0a}, nil)
+
+	// expect the transaction to be stored
+	repo.EXPECT().UpdateTokensRequest(gomock.Any()).DoAndReturn(func(tr *types.TokensRequest) error {
+		if tr.TxHash == nil || *tr.TxHash != (common.Hash{0x0a}) || tr.Status != types.TokensRequestPending {
+			t.Errorf("unexpected claim transaction: %+v", tr)
+		}
+		return nil
+	})
 
 	// claim tokens
 	err := faucet.ClaimTokens(ipAddress, generatePrefix(kClaimTokensAmount, nil)+phrase, receiver, nil)
@@ -188,6 +213,62 @@ func TestFaucet_ClaimTokens(t *testing.T) {
 	}
 }
 
+// Test that the tokens can be claimed to the address of a sign-in session.
+func TestFaucet_ClaimTokensToAddress(t *testing.T) {
+	faucet, pg, wallet, _, repo := createFaucet(t)
+	ipAddress := "192.168.0.1"
+	receiver := common.Address{0x01}
+
+	// expect the request to be created, because there is no pending request
+	repo.EXPECT().GetLatestUnclaimedTokensRequest(ipAddress).Return(nil, nil)
+	repo.EXPECT().GetLatestClaimedTokensRequests(ipAddress, gomock.Any()).Return([]types.TokensRequest{}, nil)
+	pg.EXPECT().GeneratePhrase().Return("test-phrase", nil)
+	repo.EXPECT().AddTokensRequest(gomock.Any()).Return(nil)
+
+	// expect the request to be claimed
+	repo.EXPECT().UpdateTokensRequest(gomock.Any()).DoAndReturn(func(tr *types.TokensRequest) error {
+		if tr.Receiver == nil || *tr.Receiver != receiver || tr.ClaimedAt == nil {
+			t.Errorf("unexpected claimed request: %+v", tr)
+		}
+		return nil
+	}).Times(2)
+	wallet.EXPECT().SendWeiToAddress(gomock.Eq(getTokensAmountInWei(kClaimTokensAmount)), gomock.Eq(receiver)).Return(common.Hash{0x0a}, nil)
+
+	if err := faucet.ClaimTokensToAddress(ipAddress, receiver, nil, ""); err != nil {
+		t.Fatalf("ClaimTokensToAddress failed: %v", err)
+	}
+}
+
+// Test that the claim of a sign-in session is rejected if it does not pass the gate.
+func TestFaucet_ClaimTokensToAddressGateRejected(t *testing.T) {
+	faucet, _, _, _, _ := createFaucet(t)
+	ipAddress := "192.168.0.1"
+
+	gate := NewMockFaucetGate(gomock.NewController(t))
+	gate.EXPECT().Verify(ipAddress, "").Return(fmt.Errorf("insufficient proof of work"))
+	faucet.gate = gate
+
+	// expect no request to be created nor claimed
+	err := faucet.ClaimTokensToAddress(ipAddress, common.Address{0x01}, nil, "")
+	if err == nil || err.Error() != "insufficient proof of work" {
+		t.Fatalf("expected gate error, got %v", err)
+	}
+}
+
+// Test that the daily claims limit applies to claims of sign-in sessions.
+func TestFaucet_ClaimTokensToAddressClaimLimitReached(t *testing.T) {
+	faucet, _, _, _, repo := createFaucet(t)
+	ipAddress := "192.168.0.1"
+
+	repo.EXPECT().GetLatestUnclaimedTokensRequest(ipAddress).Return(nil, nil)
+	repo.EXPECT().GetLatestClaimedTokensRequests(ipAddress, gomock.Any()).Return(make([]types.TokensRequest, 3), nil)
+
+	err := faucet.ClaimTokensToAddress(ipAddress, common.Address{0x01}, nil, "")
+	if err == nil || !strings.Contains(err.Error(), "too many requests") {
+		t.Fatalf("ClaimTokensToAddress did not return error")
+	}
+}
+
 // Test that error is returned when tokens request is not found.
 func TestFaucet_ClaimTokensNoPendingRequest(t *testing.T) {
 	faucet, _, _, _, repo := createFaucet(t)
@@ -278,7 +359,7 @@ func TestFaucet_ClaimTokensWalletError(t *testing.T) {
 	repo.EXPECT().UpdateTokensRequest(gomock.Any()).Return(nil)
 
 	// expect a call to wallet to send tokens
-	wallet.EXPECT().SendWeiToAddress(gomock.Eq(getTokensAmountInWei(kClaimTokensAmount)), gomock.Eq(receiver)).Return(fmt.Errorf("error sending wei"))
+	wallet.EXPECT().SendWeiToAddress(gomock.Eq(getTokensAmountInWei(kClaimTokensAmount)), gomock.Eq(receiver)).Return(common.Hash{}, fmt.Errorf("error sending wei"))
 
 	// expect call to reset the claim
 	repo.EXPECT().UpdateTokensRequest(gomock.Eq(&types.TokensRequest{
@@ -331,16 +412,21 @@ func TestFaucet_MintErc20Tokens(t *testing.T) {
 
 	// expect a call to the repository to update the tokens request
 	now := time.Now().Unix()
+	addr := common.HexToAddress(kErc20Address)
 	repo.EXPECT().UpdateTokensRequest(gomock.Eq(&types.TokensRequest{
 		IpAddress: ipAddress,
 		Phrase:    phrase,
 		Receiver:  &receiver,
 		ClaimedAt: &now,
+		Erc20:     &addr,
+		Status:    types.TokensRequestPending,
 	})).Return(nil)
 
 	// expect a call to wallet to mint tokens
-	addr := common.HexToAddress(kErc20Address)
-	erc20Wallet.EXPECT().MintErc20TokensToAddress(gomock.Eq(addr), gomock.Eq(receiver), gomock.Eq(new(big.Int).SetUint64(kErc20MintAmount))).Return(nil)
+	erc20Wallet.EXPECT().MintErc20TokensToAddress(gomock.Eq(addr), gomock.Eq(receiver), gomock.Eq(new(big.Int).SetUint64(kErc20MintAmount))).Return(common.Hash{0x0b}, nil)
+
+	// expect the transaction to be stored
+	repo.EXPECT().UpdateTokensRequest(gomock.Any()).Return(nil)
 
 	// claim tokens
 	err := faucet.ClaimTokens(ipAddress, generatePrefix(kClaimTokensAmount, nil)+phrase, receiver, &addr)
@@ -349,6 +435,123 @@ func TestFaucet_MintErc20Tokens(t *testing.T) {
 	}
 }
 
+// Test that the erc20 tokens can be transferred from the wallet balance.
+func TestFaucet_TransferErc20Tokens(t *testing.T) {
+	faucet, _, _, erc20Wallet, repo := createFaucet(t)
+	ipAddress := "192.168.0.1"
+	phrase := "test-phrase"
+	receiver := common.Address{0x01}
+	addr := common.HexToAddress(kErc20Address)
+
+	// configure the token to be transferred
+	erc20 := faucet.erc20s[addr]
+	erc20.transfer = true
+	erc20.amount = big.NewInt(2_500_000)
+	faucet.erc20s[addr] = erc20
+
+	repo.EXPECT().GetLatestUnclaimedTokensRequest(ipAddress).Return(&types.TokensRequest{
+		IpAddress: ipAddress,
+		Phrase:    phrase,
+	}, nil)
+	repo.EXPECT().UpdateTokensRequest(gomock.Any()).Return(nil).Times(2)
+
+	// expect the tokens to be transferred, not minted
+	erc20Wallet.EXPECT().TransferErc20TokensToAddress(addr, receiver, big.NewInt(2_500_000)).Return(common.Hash{0x0c}, nil)
+
+	err := faucet.ClaimTokens(ipAddress, generatePrefix(kClaimTokensAmount, nil)+phrase, receiver, &addr)
+	if err != nil {
+		t.Fatalf("ClaimTokens failed: %v", err)
+	}
+}
+
+// Test that the native claims are paid out by the multisend contract if the batching is enabled.
+func TestFaucet_ClaimTokensBatched(t *testing.T) {
+	faucet, _, wallet, _, repo := createFaucetWithConfig(t, func(cfg *config.Faucet) {
+		cfg.MultisendAddress = kMultisendAddress
+		cfg.BatchWindowMillis = 60_000
+		cfg.BatchMaxClaims = 2
+	})
+
+	repo.EXPECT().GetLatestUnclaimedTokensRequest(gomock.Any()).DoAndReturn(func(ip string) (*types.TokensRequest, error) {
+		return &types.TokensRequest{IpAddress: ip, Phrase: "test-phrase"}, nil
+	}).Times(2)
+	repo.EXPECT().UpdateTokensRequest(gomock.Any()).Return(nil).Times(4)
+
+	// expect both claims paid out by a single transaction
+	amount := getTokensAmountInWei(kClaimTokensAmount)
+	wallet.EXPECT().SendWeiToAddresses(common.HexToAddress(kMultisendAddress), []*big.Int{amount, amount}, gomock.Any()).Return(common.Hash{0x0b}, nil)
+
+	var wg sync.WaitGroup
+	for i, ip := range []string{"192.168.0.1", "192.168.0.2"} {
+		wg.Add(1)
+		go func(ip string, receiver common.Address) {
+			defer wg.Done()
+			if err := faucet.ClaimTokens(ip, generatePrefix(kClaimTokensAmount, nil)+"test-phrase", receiver, nil); err != nil {
+				t.Errorf("ClaimTokens failed: %v", err)
+			}
+		}(ip, common.Address{byte(i + 1)})
+	}
+	wg.Wait()
+}
+
+// Test that the claims are rejected while the faucet wallet is low on funds.
+func TestFaucet_ClaimTokensFaucetEmpty(t *testing.T) {
+	faucet, _, _, _, repo := createFaucet(t)
+	ipAddress := "192.168.0.1"
+	phrase := "test-phrase"
+
+	monitor := NewMockFaucetMonitor(gomock.NewController(t))
+	monitor.EXPECT().Check(nil).Return(fmt.Errorf("faucet empty, claims are disabled until the faucet is refilled"))
+	faucet.monitor = monitor
+
+	// expect no update of the request and no transaction
+	repo.EXPECT().GetLatestUnclaimedTokensRequest(ipAddress).Return(&types.TokensRequest{
+		IpAddress: ipAddress,
+		Phrase:    phrase,
+	}, nil)
+
+	err := faucet.ClaimTokens(ipAddress, generatePrefix(kClaimTokensAmount, nil)+phrase, common.Address{0x01}, nil)
+	if err == nil || !strings.Contains(err.Error(), "faucet empty") {
+		t.Fatalf("expected faucet empty error, got %v", err)
+	}
+}
+
+// Test that the status reports the claims the balance can pay above the minimal balance.
+func TestFaucet_Status(t *testing.T) {
+	faucet, _, _, _, _ := createFaucet(t)
+	erc20 := common.HexToAddress(kErc20Address)
+	claimAmount := getTokensAmountInWei(kClaimTokensAmount)
+
+	monitor := NewMockFaucetMonitor(gomock.NewController(t))
+	monitor.EXPECT().Status(nil).Return(&WalletStatus{
+		Balance:    new(big.Int).Add(new(big.Int).Mul(claimAmount, big.NewInt(4)), big.NewInt(1)),
+		MinBalance: claimAmount,
+		Enabled:    true,
+	}, nil)
+	monitor.EXPECT().Status(&erc20).Return(&WalletStatus{
+		Balance:    big.NewInt(1),
+		MinBalance: claimAmount,
+		Enabled:    false,
+	}, nil)
+	faucet.monitor = monitor
+
+	status, err := faucet.Status(nil)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !status.Enabled || status.RemainingClaims == nil || *status.RemainingClaims != 3 {
+		t.Errorf("expected 3 remaining claims, got %+v", status)
+	}
+
+	status, err = faucet.Status(&erc20)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Enabled || status.RemainingClaims != nil || status.Balance.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("unexpected erc20 status %+v", status)
+	}
+}
+
 // test that the amount of tokens is converted to wei correctly.
 func TestFaucet_GetTokensAmountInWei(t *testing.T) {
 	wei := getTokensAmountInWei(0.5)
@@ -364,6 +567,12 @@ func TestFaucet_GetTokensAmountInWei(t *testing.T) {
 
 // createFaucet creates a new faucet instance for testing.
 func createFaucet(t *testing.T) (*Faucet, *MockFaucetPhraseGenerator, *MockFaucetWallet, *MockFaucetWallet, *repository.MockRepository) {
+	t.Helper()
+	return createFaucetWithConfig(t, func(*config.Faucet) {})
+}
+
+// createFaucetWithConfig creates a new faucet instance for testing with the configuration adjusted by the given function.
+func createFaucetWithConfig(t *testing.T, adjust func(*config.Faucet)) (*Faucet, *MockFaucetPhraseGenerator, *MockFaucetWallet, *MockFaucetWallet, *repository.MockRepository) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
 	mockRepository := repository.NewMockRepository(ctrl)
@@ -374,15 +583,22 @@ func createFaucet(t *testing.T) (*Faucet, *MockFaucetPhraseGenerator, *MockFauce
 		ClaimTokensAmount:  kClaimTokensAmount,
 		ClaimsPerDay:       3,
 		Erc20MintAmountHex: hexutil.EncodeUint64(kErc20MintAmount),
+		Erc20s:             []config.FaucetErc20{{Address: kErc20Address}},
 	}
+	adjust(cfg)
 	mockErc20Wallet := NewMockFaucetWallet(ctrl)
+	mockWallet.EXPECT().OnReplaced(gomock.Any())
+	mockErc20Wallet.EXPECT().OnReplaced(gomock.Any())
 	erc20s := []FaucetErc20{
 		{
 			address: common.HexToAddress(kErc20Address),
 			wallet:  mockErc20Wallet,
 		},
 	}
-	f, err := NewFaucet(cfg, mockPhraseGenerator, mockWallet, erc20s, mockRepository)
+	// the wallets have enough funds unless a test replaces the monitor
+	mockMonitor := NewMockFaucetMonitor(ctrl)
+	mockMonitor.EXPECT().Check(gomock.Any()).Return(nil).AnyTimes()
+	f, err := NewFaucet(cfg, mockPhraseGenerator, mockWallet, erc20s, mockMonitor, openGate{}, mockRepository)
 	if err != nil {
 		t.Fatalf("NewFaucet failed: %v", err)
 	}
@@ -0,0 +1,175 @@
+package faucet
+
+import (
+	"fmt"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// kFeeHistoryBlocks is the number of latest blocks the priority fee is derived from.
+	kFeeHistoryBlocks = 10
+
+	// kTipPercentile is the percentile of the priority fees paid in a block used as the tip.
+	kTipPercentile = 50
+
+	// kBaseFeeMultiplier is the multiple of the base fee covered by the fee cap,
+	// so the transaction stays valid through several blocks of growing base fee.
+	kBaseFeeMultiplier = 2
+)
+
+// kDefaultTipCap is the priority fee used if the latest blocks paid no priority fees.
+var kDefaultTipCap = big.NewInt(params.GWei)
+
+// txFees represents the fees of a transaction, either the legacy gas price
+// or the EIP-1559 tip and fee caps of a dynamic fee transaction.
+type txFees struct {
+	gasPrice *big.Int
+	tipCap   *big.Int
+	feeCap   *big.Int
+}
+
+// isDynamic returns true if the fees are of a dynamic fee transaction.
+func (f txFees) isDynamic() bool {
+	return f.feeCap != nil
+}
+
+// maxPrice returns the maximal price of a unit of gas paid by the transaction.
+func (f txFees) maxPrice() *big.Int {
+	if f.isDynamic() {
+		return f.feeCap
+	}
+	return f.gasPrice
+}
+
+// newTx creates a new unsigned transaction paying the fees. The chain id
+// of the dynamic fee transaction is left zero to be set by the signer.
+func (f txFees) newTx(nonce uint64, to common.Address, value *big.Int, gas uint64, data []byte) *types.Transaction {
+	if !f.isDynamic() {
+		return types.NewTransaction(nonce, to, value, gas, f.gasPrice, data)
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   new(big.Int),
+		Nonce:     nonce,
+		GasTipCap: f.tipCap,
+		GasFeeCap: f.feeCap,
+		Gas:       gas,
+		To:        &to,
+		Value:     value,
+		Data:      data,
+	})
+}
+
+// txFeesOf returns the fees paid by the given transaction.
+func txFeesOf(tx *types.Transaction) txFees {
+	if tx.Type() == types.DynamicFeeTxType {
+		return txFees{tipCap: tx.GasTipCap(), feeCap: tx.GasFeeCap()}
+	}
+	return txFees{gasPrice: tx.GasPrice()}
+}
+
+// bumped returns the fees increased by kGasPriceBumpPercent, at least the given current fees
+// of the same kind, so the replacement transaction is accepted by the transaction pool.
+func (f txFees) bumped(current *txFees) txFees {
+	if !f.isDynamic() {
+		res := txFees{gasPrice: bumpGasPrice(f.gasPrice)}
+		if current != nil && !current.isDynamic() {
+			res.gasPrice = maxBig(res.gasPrice, current.gasPrice)
+		}
+		return res
+	}
+
+	res := txFees{tipCap: bumpGasPrice(f.tipCap), feeCap: bumpGasPrice(f.feeCap)}
+	if current != nil && current.isDynamic() {
+		res.tipCap = maxBig(res.tipCap, current.tipCap)
+		res.feeCap = maxBig(res.feeCap, current.feeCap)
+	}
+	return res
+}
+
+// feeOracle provides the fees of the wallet transactions. Dynamic fee transactions are used
+// if the chain supports London, the legacy gas price otherwise.
+type feeOracle struct {
+	repo repository.IRepository
+	log  logger.ILogger
+
+	// london tells if the chain supports London, nil until detected.
+	london *bool
+}
+
+// newFeeOracle creates a new fee oracle.
+func newFeeOracle(repo repository.IRepository, log logger.ILogger) *feeOracle {
+	return &feeOracle{repo: repo, log: log}
+}
+
+// fees returns the fees of a new transaction.
+func (fo *feeOracle) fees() (txFees, error) {
+	if !fo.isLondon() {
+		gasPrice, err := fo.repo.SuggestGasPrice()
+		if err != nil {
+			return txFees{}, fmt.Errorf("error getting gas price: %v", err)
+		}
+		return txFees{gasPrice: gasPrice}, nil
+	}
+
+	history, err := fo.repo.FeeHistory(kFeeHistoryBlocks, []float64{kTipPercentile})
+	if err != nil {
+		return txFees{}, fmt.Errorf("error getting fee history: %v", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return txFees{}, fmt.Errorf("error getting fee history: no base fee")
+	}
+
+	// the last base fee is the one of the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	tipCap := medianTip(history.Reward)
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(kBaseFeeMultiplier))
+	return txFees{tipCap: tipCap, feeCap: feeCap.Add(feeCap, tipCap)}, nil
+}
+
+// isLondon detects whether the chain supports London. The detection is repeated
+// until it succeeds, the legacy transactions are used meanwhile.
+func (fo *feeOracle) isLondon() bool {
+	if fo.london != nil {
+		return *fo.london
+	}
+
+	baseFee, err := fo.repo.BaseFee()
+	if err != nil {
+		fo.log.Warningf("error detecting london support, using legacy transactions: %v", err)
+		return false
+	}
+	london := baseFee != nil
+	fo.london = &london
+	fo.log.Noticef("london supported: %v", london)
+	return london
+}
+
+// medianTip returns the median of the non-zero priority fees paid in the latest blocks.
+func medianTip(rewards [][]*big.Int) *big.Int {
+	tips := make([]*big.Int, 0, len(rewards))
+	for _, r := range rewards {
+		if len(r) > 0 && r[0] != nil && r[0].Sign() > 0 {
+			tips = append(tips, r[0])
+		}
+	}
+	if len(tips) == 0 {
+		return new(big.Int).Set(kDefaultTipCap)
+	}
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	return new(big.Int).Set(tips[len(tips)/2])
+}
+
+// maxBig returns the greater of the given values.
+func maxBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
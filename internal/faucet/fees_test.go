@@ -0,0 +1,113 @@
+package faucet
+
+import (
+	"fmt"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/mock/gomock"
+)
+
+// Test that the london support is detected once and the failed detection is repeated.
+func TestFeeOracle_DetectLondon(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	fo := newFeeOracle(repo, logger.NewMockLogger())
+
+	// the legacy gas price is used until the detection succeeds
+	gomock.InOrder(
+		repo.EXPECT().BaseFee().Return(nil, fmt.Errorf("connection refused")),
+		repo.EXPECT().BaseFee().Return(big.NewInt(1_000), nil),
+	)
+	repo.EXPECT().SuggestGasPrice().Return(big.NewInt(5_000), nil)
+	fees, err := fo.fees()
+	if err != nil || fees.isDynamic() || fees.gasPrice.Cmp(big.NewInt(5_000)) != 0 {
+		t.Fatalf("expected legacy fees, got %+v, %v", fees, err)
+	}
+
+	repo.EXPECT().FeeHistory(uint64(kFeeHistoryBlocks), []float64{kTipPercentile}).Return(&ethereum.FeeHistory{
+		BaseFee: []*big.Int{big.NewInt(900), big.NewInt(1_000)},
+		Reward:  [][]*big.Int{{big.NewInt(0)}, {big.NewInt(30)}, {big.NewInt(10)}, {big.NewInt(20)}},
+	}, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		fees, err = fo.fees()
+		if err != nil || !fees.isDynamic() {
+			t.Fatalf("expected dynamic fees, got %+v, %v", fees, err)
+		}
+		if fees.tipCap.Cmp(big.NewInt(20)) != 0 || fees.feeCap.Cmp(big.NewInt(2_020)) != 0 {
+			t.Errorf("expected tip 20 and fee cap 2020, got %d and %d", fees.tipCap, fees.feeCap)
+		}
+	}
+}
+
+// Test that the legacy transactions are used if the chain does not support london.
+func TestFeeOracle_Legacy(t *testing.T) {
+	repo := repository.NewMockRepository(gomock.NewController(t))
+	fo := newFeeOracle(repo, logger.NewMockLogger())
+
+	repo.EXPECT().BaseFee().Return(nil, nil)
+	repo.EXPECT().SuggestGasPrice().Return(big.NewInt(5_000), nil).Times(2)
+	for i := 0; i < 2; i++ {
+		fees, err := fo.fees()
+		if err != nil || fees.isDynamic() {
+			t.Fatalf("expected legacy fees, got %+v, %v", fees, err)
+		}
+	}
+
+	tx := txFees{gasPrice: big.NewInt(5_000)}.newTx(1, [20]byte{0x01}, big.NewInt(1), 21_000, nil)
+	if tx.Type() != types.LegacyTxType || tx.GasPrice().Cmp(big.NewInt(5_000)) != 0 {
+		t.Errorf("unexpected legacy transaction: type %d, gas price %d", tx.Type(), tx.GasPrice())
+	}
+}
+
+// Test that the default tip is used if the latest blocks paid no priority fees.
+func TestFeeOracle_MedianTip(t *testing.T) {
+	if tip := medianTip([][]*big.Int{{big.NewInt(0)}, {}}); tip.Cmp(kDefaultTipCap) != 0 {
+		t.Errorf("expected default tip, got %d", tip)
+	}
+	if tip := medianTip([][]*big.Int{{big.NewInt(3)}, {big.NewInt(1)}, {big.NewInt(2)}}); tip.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("expected median tip 2, got %d", tip)
+	}
+}
+
+// Test that the bumped fees are at least the current fees of the same kind.
+func TestFeeOracle_Bumped(t *testing.T) {
+	legacy := txFees{gasPrice: big.NewInt(1_000)}
+	if fees := legacy.bumped(&txFees{gasPrice: big.NewInt(5_000)}); fees.gasPrice.Cmp(big.NewInt(5_000)) != 0 {
+		t.Errorf("expected current gas price, got %d", fees.gasPrice)
+	}
+	if fees := legacy.bumped(&txFees{tipCap: big.NewInt(5_000), feeCap: big.NewInt(5_000)}); fees.isDynamic() || fees.gasPrice.Cmp(big.NewInt(1_200)) != 0 {
+		t.Errorf("expected bumped legacy gas price, got %+v", fees)
+	}
+
+	dynamic := txFees{tipCap: big.NewInt(100), feeCap: big.NewInt(1_000)}
+	fees := dynamic.bumped(&txFees{tipCap: big.NewInt(50), feeCap: big.NewInt(3_000)})
+	if fees.tipCap.Cmp(big.NewInt(120)) != 0 || fees.feeCap.Cmp(big.NewInt(3_000)) != 0 {
+		t.Errorf("expected tip 120 and fee cap 3000, got %d and %d", fees.tipCap, fees.feeCap)
+	}
+}
+
+// Test that the signer sets the chain id of the dynamic fee transaction.
+func TestFeeOracle_SignDynamic(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	tx := txFees{tipCap: big.NewInt(100), feeCap: big.NewInt(1_000)}.newTx(1, [20]byte{0x01}, big.NewInt(1), 21_000, nil)
+	signer := types.NewLondonSigner(big.NewInt(4002))
+
+	signed, err := types.SignTx(tx, signer, key)
+	if err != nil {
+		t.Fatalf("error signing transaction: %v", err)
+	}
+	if signed.ChainId().Cmp(big.NewInt(4002)) != 0 {
+		t.Errorf("expected chain id 4002, got %d", signed.ChainId())
+	}
+	if from, err := types.Sender(signer, signed); err != nil || from != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("unexpected sender %s: %v", from.Hex(), err)
+	}
+}
@@ -0,0 +1,64 @@
+package faucet
+
+import (
+	"fmt"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/repository"
+)
+
+const (
+	// kGateNone is the kind of the gate letting all the requests through.
+	kGateNone = "none"
+
+	// kGatePow is the kind of the gate requiring a hashcash proof-of-work stamp.
+	kGatePow = "pow"
+
+	// kGateCaptcha is the kind of the gate requiring a response of an external captcha provider.
+	kGateCaptcha = "captcha"
+)
+
+// GateParams represents the parameters the clients need to pass a faucet gate.
+type GateParams struct {
+	// Kind is the kind of the gate - none, pow or captcha.
+	Kind string
+
+	// Difficulty is the number of leading zero bits of the proof-of-work stamp hash, nil for other gates.
+	Difficulty *uint
+
+	// Resource is the resource of the proof-of-work stamp.
+	Resource string
+
+	// SiteKey is the public key of the site used to render the captcha.
+	SiteKey string
+}
+
+// NewGate creates the faucet gate of the configured kind.
+// The repository keeps the spent proof-of-work stamps.
+func NewGate(cfg *config.FaucetGate, repo repository.IRepository) (IFaucetGate, error) {
+	switch cfg.Kind {
+	case "", kGateNone:
+		return openGate{}, nil
+	case kGatePow:
+		return newPowGate(cfg.PowDifficulty, repo), nil
+	case kGateCaptcha:
+		if cfg.CaptchaVerifyUrl == "" {
+			return nil, fmt.Errorf("captcha gate requires verify url")
+		}
+		return newCaptchaGate(cfg.CaptchaVerifyUrl, cfg.CaptchaSiteKey, cfg.CaptchaSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown faucet gate %s", cfg.Kind)
+	}
+}
+
+// openGate represents a gate letting all the requests through.
+type openGate struct{}
+
+// Params returns the parameters of the open gate.
+func (openGate) Params() GateParams {
+	return GateParams{Kind: kGateNone}
+}
+
+// Verify accepts any proof.
+func (openGate) Verify(string, string) error {
+	return nil
+}
@@ -0,0 +1,25 @@
+package faucet
+
+import (
+	"ftm-explorer/internal/config"
+	"testing"
+)
+
+// Test that the gate is created by the configured kind.
+func TestNewGate(t *testing.T) {
+	for kind, expected := range map[string]string{"": kGateNone, kGateNone: kGateNone, kGatePow: kGatePow} {
+		gate, err := NewGate(&config.FaucetGate{Kind: kind, PowDifficulty: 8}, nil)
+		if err != nil {
+			t.Fatalf("NewGate(%q) failed: %v", kind, err)
+		}
+		if gate.Params().Kind != expected {
+			t.Errorf("expected %s gate, got %s", expected, gate.Params().Kind)
+		}
+	}
+	if _, err := NewGate(&config.FaucetGate{Kind: kGateCaptcha}, nil); err == nil {
+		t.Errorf("expected captcha gate without verify url to be rejected")
+	}
+	if _, err := NewGate(&config.FaucetGate{Kind: "unknown"}, nil); err == nil {
+		t.Errorf("expected unknown gate to be rejected")
+	}
+}
@@ -1,6 +1,6 @@
 package faucet
 
-//go:generate mockgen -source=interface.go -destination=faucet_mock.go -package=faucet -mock_names=IFaucet=MockFaucet,IFaucetPhraseGenerator=MockFaucetPhraseGenerator,IFaucetWallet=MockFaucetWallet
+//go:generate mockgen -source=interface.go -destination=faucet_mock.go -package=faucet -mock_names=IFaucet=MockFaucet,IFaucetPhraseGenerator=MockFaucetPhraseGenerator,IFaucetWallet=MockFaucetWallet,IFaucetMonitor=MockFaucetMonitor,IFaucetGate=MockFaucetGate
 
 import (
 	"math/big"
@@ -11,11 +11,20 @@ import (
 // IFaucet represents a faucet interface. It provides access to the
 // faucet functionality. It is used to request and claim tokens.
 type IFaucet interface {
-	// RequestTokens requests tokens for the given ip address.
-	RequestTokens(string, *string) (string, error)
+	// RequestTokens requests tokens for the given ip address, which passed the gate by the given proof.
+	RequestTokens(ip string, symbol *string, proof string) (string, error)
+
+	// GateParams returns the parameters the clients need to pass the gate of the faucet.
+	GateParams() GateParams
 
 	// ClaimTokens claims tokens for the given phrase and receiver address.
 	ClaimTokens(ip string, phrase string, receiver common.Address, erc20 *common.Address) error
+
+	// ClaimTokensToAddress claims tokens for the receiver address authenticated by a sign-in session.
+	ClaimTokensToAddress(ip string, receiver common.Address, erc20 *common.Address, proof string) error
+
+	// Status returns the funding status of the faucet for the given erc20 token, nil for the native token.
+	Status(erc20 *common.Address) (*Status, error)
 }
 
 // IFaucetPhraseGenerator represents a faucet phrase generator interface.
@@ -28,9 +37,41 @@ type IFaucetPhraseGenerator interface {
 // IFaucetWallet represents a faucet wallet interface.
 // It is used to send wei to the given address.
 type IFaucetWallet interface {
-	// SendWeiToAddress sends wei to the given address.
-	SendWeiToAddress(amount *big.Int, receiver common.Address) error
+	// SendWeiToAddress sends wei to the given address and returns the transaction hash.
+	SendWeiToAddress(amount *big.Int, receiver common.Address) (common.Hash, error)
+
+	// MintErc20TokensToAddress mints erc20 tokens to the given address and returns the transaction hash.
+	MintErc20TokensToAddress(common.Address, common.Address, *big.Int) (common.Hash, error)
+
+	// TransferErc20TokensToAddress transfers erc20 tokens of the wallet to the given address and returns the transaction hash.
+	TransferErc20TokensToAddress(common.Address, common.Address, *big.Int) (common.Hash, error)
+
+	// SendWeiToAddresses sends wei to the given addresses by the multisend contract and returns the transaction hash.
+	SendWeiToAddresses(contract common.Address, amounts []*big.Int, receivers []common.Address) (common.Hash, error)
+
+	// OnReplaced sets the callback called when a stuck transaction is replaced.
+	OnReplaced(func(old common.Hash, new common.Hash))
+
+	// Address returns the address of the wallet.
+	Address() common.Address
+}
+
+// IFaucetMonitor represents a faucet monitor interface.
+// It tracks the funds of the faucet wallets.
+type IFaucetMonitor interface {
+	// Check returns an error if the wallet paying the claims of the given erc20 token is low on funds.
+	Check(erc20 *common.Address) error
+
+	// Status returns the observed funds of the wallet paying the claims of the given erc20 token.
+	Status(erc20 *common.Address) (*WalletStatus, error)
+}
+
+// IFaucetGate represents a faucet gate interface.
+// It protects the faucet requests against bots.
+type IFaucetGate interface {
+	// Params returns the parameters the clients need to pass the gate.
+	Params() GateParams
 
-	// MintErc20TokensToAddress sends erc20 tokens to the given address.
-	MintErc20TokensToAddress(common.Address, common.Address, *big.Int) error
+	// Verify verifies the proof of passing the gate sent from the given ip address.
+	Verify(ip string, proof string) error
 }
@@ -0,0 +1,110 @@
+package faucet
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// kHour is the window of hourly budgets.
+	kHour = time.Hour
+
+	// kDay is the window of daily limits and budgets.
+	kDay = 24 * time.Hour
+)
+
+// claimBudget represents the amount of a token the faucet can give away within a time window.
+type claimBudget struct {
+	// name describes the budget in error messages.
+	name string
+
+	// window is the time window of the budget.
+	window time.Duration
+
+	// claims is the number of claims fitting into the budget.
+	claims uint64
+}
+
+// newClaimBudgets returns the hourly and daily budgets of a token decoded from the given hex amounts.
+// Empty amounts mean unlimited budgets. Each budget must fit at least a single claim of the given amount.
+func newClaimBudgets(hourlyHex string, dailyHex string, claimAmount *big.Int) ([]claimBudget, error) {
+	budgets := make([]claimBudget, 0, 2)
+	for _, b := range []struct {
+		name   string
+		window time.Duration
+		hex    string
+	}{
+		{"hourly", kHour, hourlyHex},
+		{"daily", kDay, dailyHex},
+	} {
+		if b.hex == "" {
+			continue
+		}
+		amount, err := hexutil.DecodeBig(b.hex)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s budget: %v", b.name, err)
+		}
+		if claimAmount.Sign() <= 0 {
+			return nil, fmt.Errorf("%s budget requires positive claim amount", b.name)
+		}
+		claims := new(big.Int).Div(amount, claimAmount)
+		if claims.Sign() == 0 {
+			return nil, fmt.Errorf("%s budget %s is smaller than a single claim", b.name, b.hex)
+		}
+		budgets = append(budgets, claimBudget{name: b.name, window: b.window, claims: claims.Uint64()})
+	}
+	return budgets, nil
+}
+
+// checkLimits checks the claim of the given token to the given receiver fits into the receiver limit
+// and the budgets of the token. The erc20 is nil for the native token.
+func (f *Faucet) checkLimits(receiver common.Address, erc20 *common.Address) error {
+	now := time.Now()
+
+	// check the claims of the token to the receiver
+	if f.cfg.ReceiverClaimsPerDay > 0 {
+		times, err := f.repo.GetTokensRequestClaimTimes(&receiver, erc20, now.Add(-kDay).Unix())
+		if err != nil {
+			return fmt.Errorf("error getting claims of receiver: %v", err)
+		}
+		if next, ok := nextClaimTime(times, uint64(f.cfg.ReceiverClaimsPerDay), kDay); !ok {
+			return fmt.Errorf("receiver %s has reached the limit of %d claims per day, next claim allowed at %s",
+				receiver.Hex(), f.cfg.ReceiverClaimsPerDay, formatClaimTime(next))
+		}
+	}
+
+	// check the budgets of the token
+	budgets := f.budgets
+	if erc20 != nil {
+		budgets = f.erc20s[*erc20].budgets
+	}
+	for _, b := range budgets {
+		times, err := f.repo.GetTokensRequestClaimTimes(nil, erc20, now.Add(-b.window).Unix())
+		if err != nil {
+			return fmt.Errorf("error getting claims of token: %v", err)
+		}
+		if next, ok := nextClaimTime(times, b.claims, b.window); !ok {
+			return fmt.Errorf("%s budget of the faucet is exhausted, next claim allowed at %s", b.name, formatClaimTime(next))
+		}
+	}
+	return nil
+}
+
+// nextClaimTime checks another claim fits into the limit of the given number of claims within the window.
+// If it does not, it returns the time when enough of the given claim times, the oldest first, leave the window.
+func nextClaimTime(times []int64, limit uint64, window time.Duration) (time.Time, bool) {
+	count := uint64(len(times))
+	if count < limit {
+		return time.Time{}, true
+	}
+	return time.Unix(times[count-limit], 0).Add(window), false
+}
+
+// formatClaimTime formats the time of the next allowed claim.
+func formatClaimTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
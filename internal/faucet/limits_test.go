@@ -0,0 +1,90 @@
+package faucet
+
+import (
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/types"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+)
+
+// Test that the next claim time is derived from the oldest claims in the window.
+func TestLimits_NextClaimTime(t *testing.T) {
+	if _, ok := nextClaimTime([]int64{100}, 2, time.Hour); !ok {
+		t.Errorf("expected claim to fit into the limit")
+	}
+	next, ok := nextClaimTime([]int64{100, 200, 300}, 2, time.Hour)
+	if ok || next.Unix() != 200+3600 {
+		t.Errorf("expected next claim at %d, got %d", 200+3600, next.Unix())
+	}
+}
+
+// Test that budgets are decoded into the number of claims and validated.
+func TestLimits_NewClaimBudgets(t *testing.T) {
+	amount := getTokensAmountInWei(kClaimTokensAmount)
+	budgets, err := newClaimBudgets("", hexutil.EncodeBig(getTokensAmountInWei(10*kClaimTokensAmount)), amount)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(budgets) != 1 || budgets[0].name != "daily" || budgets[0].claims != 10 || budgets[0].window != kDay {
+		t.Errorf("unexpected budgets: %+v", budgets)
+	}
+
+	if _, err := newClaimBudgets(hexutil.EncodeBig(getTokensAmountInWei(kClaimTokensAmount/2)), "", amount); err == nil {
+		t.Errorf("expected error for budget smaller than a single claim")
+	}
+	if _, err := newClaimBudgets("invalid", "", amount); err == nil {
+		t.Errorf("expected error for invalid budget")
+	}
+}
+
+// Test that the claims limit of the receiver applies with the next allowed time.
+func TestFaucet_ClaimTokensReceiverLimitReached(t *testing.T) {
+	faucet, _, _, _, repo := createFaucetWithConfig(t, func(cfg *config.Faucet) {
+		cfg.ReceiverClaimsPerDay = 1
+	})
+	ipAddress := "192.168.0.1"
+	phrase := "test-phrase"
+	receiver := common.Address{0x01}
+
+	repo.EXPECT().GetLatestUnclaimedTokensRequest(ipAddress).Return(&types.TokensRequest{
+		IpAddress: ipAddress,
+		Phrase:    phrase,
+	}, nil)
+	claimedAt := time.Now().Add(-time.Hour).Unix()
+	repo.EXPECT().GetTokensRequestClaimTimes(&receiver, nil, gomock.Any()).Return([]int64{claimedAt}, nil)
+
+	err := faucet.ClaimTokens(ipAddress, generatePrefix(kClaimTokensAmount, nil)+phrase, receiver, nil)
+	next := formatClaimTime(time.Unix(claimedAt, 0).Add(kDay))
+	if err == nil || !strings.Contains(err.Error(), "limit of 1 claims per day") || !strings.Contains(err.Error(), next) {
+		t.Fatalf("expected receiver limit error with next time %s, got %v", next, err)
+	}
+}
+
+// Test that the budget of erc20 token applies with the next allowed time.
+func TestFaucet_ClaimTokensErc20BudgetExhausted(t *testing.T) {
+	faucet, _, _, _, repo := createFaucetWithConfig(t, func(cfg *config.Faucet) {
+		cfg.Erc20s[0].HourlyBudgetHex = hexutil.EncodeUint64(2 * kErc20MintAmount)
+	})
+	ipAddress := "192.168.0.1"
+	phrase := "test-phrase"
+	receiver := common.Address{0x01}
+	erc20 := common.HexToAddress(kErc20Address)
+
+	repo.EXPECT().GetLatestUnclaimedTokensRequest(ipAddress).Return(&types.TokensRequest{
+		IpAddress: ipAddress,
+		Phrase:    phrase,
+	}, nil)
+	now := time.Now().Unix()
+	repo.EXPECT().GetTokensRequestClaimTimes(nil, &erc20, gomock.Any()).Return([]int64{now - 60, now - 30}, nil)
+
+	err := faucet.ClaimTokens(ipAddress, generatePrefix(kClaimTokensAmount, nil)+phrase, receiver, &erc20)
+	next := formatClaimTime(time.Unix(now-60, 0).Add(kHour))
+	if err == nil || !strings.Contains(err.Error(), "hourly budget") || !strings.Contains(err.Error(), next) {
+		t.Fatalf("expected hourly budget error with next time %s, got %v", next, err)
+	}
+}
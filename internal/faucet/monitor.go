@@ -0,0 +1,246 @@
+package faucet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// kWebhookTimeout is the timeout of the low balance webhook request.
+const kWebhookTimeout = 10 * time.Second
+
+// WalletStatus represents the observed funds of a faucet wallet.
+type WalletStatus struct {
+	// Address is the address of the wallet.
+	Address common.Address
+
+	// Balance is the last observed native balance of the wallet, nil if not observed yet.
+	Balance *big.Int
+
+	// MinBalance is the balance below which the claims are disabled.
+	MinBalance *big.Int
+
+	// Enabled tells if the claims paid by the wallet are enabled.
+	Enabled bool
+}
+
+// monitoredWallet represents a wallet whose native balance is tracked by the monitor.
+type monitoredWallet struct {
+	name       string
+	address    common.Address
+	minBalance *big.Int
+
+	// balance is the last observed balance, nil if not observed yet.
+	balance *big.Int
+
+	// low is true if the last observed balance is below the minimal balance.
+	low bool
+}
+
+// lowBalanceNotification is the payload posted to the low balance webhook.
+type lowBalanceNotification struct {
+	Text       string         `json:"text"`
+	Wallet     string         `json:"wallet"`
+	Address    common.Address `json:"address"`
+	Balance    *hexutil.Big   `json:"balance"`
+	MinBalance *hexutil.Big   `json:"minBalance"`
+}
+
+// Monitor tracks the native balances of the faucet wallet and the erc20 minter wallets.
+// Claims of a token are disabled while the balance of the wallet paying them is below
+// the configured minimum, and the webhook is notified when a wallet runs low.
+type Monitor struct {
+	repo     repository.IRepository
+	log      logger.ILogger
+	webhook  string
+	client   *http.Client
+	interval time.Duration
+	sigClose chan struct{}
+
+	// mu guards the observed balances of the wallets.
+	mu     sync.RWMutex
+	native *monitoredWallet
+	erc20s map[common.Address]*monitoredWallet
+}
+
+// NewMonitor creates a new monitor of the given faucet wallet and erc20 minter wallets.
+func NewMonitor(cfg *config.Faucet, repo repository.IRepository, log logger.ILogger, w IFaucetWallet, erc20s []FaucetErc20) (*Monitor, error) {
+	minBalance, err := hexutil.DecodeBig(cfg.MinBalanceHex)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding faucet min balance: %v", err)
+	}
+	minMinterBalance, err := hexutil.DecodeBig(cfg.MinMinterBalanceHex)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding faucet min minter balance: %v", err)
+	}
+
+	m := &Monitor{
+		repo:     repo,
+		log:      log.ModuleLogger("faucet_monitor"),
+		webhook:  cfg.LowBalanceWebhookUrl,
+		client:   &http.Client{Timeout: kWebhookTimeout},
+		interval: time.Duration(cfg.BalanceCheckSeconds) * time.Second,
+		sigClose: make(chan struct{}, 1),
+		native:   &monitoredWallet{name: "faucet", address: w.Address(), minBalance: minBalance},
+		erc20s:   make(map[common.Address]*monitoredWallet, len(erc20s)),
+	}
+	for _, erc20 := range erc20s {
+		m.erc20s[erc20.address] = &monitoredWallet{
+			name:       fmt.Sprintf("erc20 %s minter", erc20.address.Hex()),
+			address:    erc20.wallet.Address(),
+			minBalance: minMinterBalance,
+		}
+	}
+	return m, nil
+}
+
+// Start starts checking the balances periodically.
+func (m *Monitor) Start() {
+	go m.run()
+}
+
+// Close stops checking the balances.
+func (m *Monitor) Close() {
+	m.sigClose <- struct{}{}
+}
+
+// run checks the balances right away and then every interval until closed.
+func (m *Monitor) run() {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	m.CheckBalances()
+	for {
+		select {
+		case <-m.sigClose:
+			return
+		case <-ticker.C:
+			m.CheckBalances()
+		}
+	}
+}
+
+// CheckBalances updates the observed balances of all the monitored wallets right away.
+func (m *Monitor) CheckBalances() {
+	m.checkBalance(m.native)
+	for _, w := range m.erc20s {
+		m.checkBalance(w)
+	}
+}
+
+// checkBalance updates the observed balance of the given wallet. The webhook is notified
+// when the balance falls below the minimum, not again until the wallet is refilled.
+func (m *Monitor) checkBalance(w *monitoredWallet) {
+	balance, err := m.repo.AccountBalance(w.address)
+	if err != nil {
+		m.log.Errorf("error getting balance of %s wallet %s: %v", w.name, w.address.Hex(), err)
+		return
+	}
+
+	low := balance.ToInt().Cmp(w.minBalance) < 0
+
+	m.mu.Lock()
+	wasLow := w.low
+	w.balance = balance.ToInt()
+	w.low = low
+	m.mu.Unlock()
+
+	if low && !wasLow {
+		m.log.Warningf("%s wallet %s is low on funds, balance %s", w.name, w.address.Hex(), balance.String())
+		m.notify(w, balance)
+	}
+	if !low && wasLow {
+		m.log.Noticef("%s wallet %s has been refilled, balance %s", w.name, w.address.Hex(), balance.String())
+	}
+}
+
+// notify posts the low balance of the given wallet to the webhook, if configured.
+func (m *Monitor) notify(w *monitoredWallet, balance *hexutil.Big) {
+	if m.webhook == "" {
+		return
+	}
+
+	body, err := json.Marshal(lowBalanceNotification{
+		Text:       fmt.Sprintf("Faucet %s wallet %s is low on funds, claims are disabled", w.name, w.address.Hex()),
+		Wallet:     w.name,
+		Address:    w.address,
+		Balance:    balance,
+		MinBalance: (*hexutil.Big)(w.minBalance),
+	})
+	if err != nil {
+		m.log.Errorf("error encoding low balance notification: %v", err)
+		return
+	}
+
+	resp, err := m.client.Post(m.webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		m.log.Errorf("error posting low balance notification: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		m.log.Errorf("low balance notification rejected with status %d", resp.StatusCode)
+	}
+}
+
+// Check returns an error if the wallet paying the claims of the given token
+// is low on funds. The erc20 is nil for the native token.
+func (m *Monitor) Check(erc20 *common.Address) error {
+	w, err := m.wallet(erc20)
+	if err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if w.low {
+		return fmt.Errorf("faucet empty, claims are disabled until the faucet is refilled")
+	}
+	return nil
+}
+
+// Status returns the observed funds of the wallet paying the claims of the given token.
+// The erc20 is nil for the native token.
+func (m *Monitor) Status(erc20 *common.Address) (*WalletStatus, error) {
+	w, err := m.wallet(erc20)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	status := &WalletStatus{
+		Address:    w.address,
+		MinBalance: new(big.Int).Set(w.minBalance),
+		Enabled:    !w.low,
+	}
+	if w.balance != nil {
+		status.Balance = new(big.Int).Set(w.balance)
+	}
+	return status, nil
+}
+
+// wallet returns the monitored wallet paying the claims of the given token.
+func (m *Monitor) wallet(erc20 *common.Address) (*monitoredWallet, error) {
+	if erc20 == nil {
+		return m.native, nil
+	}
+	w, ok := m.erc20s[*erc20]
+	if !ok {
+		return nil, fmt.Errorf("unknown erc20 token")
+	}
+	return w, nil
+}
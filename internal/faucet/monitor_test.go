@@ -0,0 +1,127 @@
+package faucet
+
+import (
+	"encoding/json"
+	"fmt"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+)
+
+// createMonitor creates a monitor of a faucet wallet and an erc20 minter wallet with a mocked repository.
+func createMonitor(t *testing.T, webhook string) (*Monitor, *repository.MockRepository) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(ctrl)
+	wallet := NewMockFaucetWallet(ctrl)
+	wallet.EXPECT().Address().Return(common.HexToAddress("0x1"))
+	erc20Wallet := NewMockFaucetWallet(ctrl)
+	erc20Wallet.EXPECT().Address().Return(common.HexToAddress("0x2"))
+
+	cfg := &config.Faucet{
+		MinBalanceHex:        "0x64",
+		MinMinterBalanceHex:  "0xa",
+		BalanceCheckSeconds:  60,
+		LowBalanceWebhookUrl: webhook,
+	}
+	erc20s := []FaucetErc20{{address: common.HexToAddress(kErc20Address), wallet: erc20Wallet}}
+	m, err := NewMonitor(cfg, repo, logger.NewMockLogger(), wallet, erc20s)
+	if err != nil {
+		t.Fatalf("NewMonitor failed: %v", err)
+	}
+	return m, repo
+}
+
+// Test that the claims are disabled while the balance is below the minimum.
+func TestMonitor_Check(t *testing.T) {
+	m, repo := createMonitor(t, "")
+	erc20 := common.HexToAddress(kErc20Address)
+
+	// the claims are enabled until the balances are observed
+	if err := m.Check(nil); err != nil {
+		t.Fatalf("expected claims enabled before the first check, got %v", err)
+	}
+
+	repo.EXPECT().AccountBalance(common.HexToAddress("0x1")).Return((*hexutil.Big)(big.NewInt(99)), nil)
+	repo.EXPECT().AccountBalance(common.HexToAddress("0x2")).Return((*hexutil.Big)(big.NewInt(10)), nil)
+	m.CheckBalances()
+
+	if err := m.Check(nil); err == nil || !strings.Contains(err.Error(), "faucet empty") {
+		t.Errorf("expected faucet empty error, got %v", err)
+	}
+	if err := m.Check(&erc20); err != nil {
+		t.Errorf("expected erc20 claims enabled, got %v", err)
+	}
+	if err := m.Check(&common.Address{0x03}); err == nil {
+		t.Errorf("expected error of unknown erc20 token")
+	}
+
+	status, err := m.Status(nil)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Enabled || status.Balance.Cmp(big.NewInt(99)) != 0 || status.MinBalance.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	// the claims are enabled again once the wallet is refilled
+	repo.EXPECT().AccountBalance(common.HexToAddress("0x1")).Return((*hexutil.Big)(big.NewInt(100)), nil)
+	repo.EXPECT().AccountBalance(common.HexToAddress("0x2")).Return((*hexutil.Big)(big.NewInt(10)), nil)
+	m.CheckBalances()
+
+	if err := m.Check(nil); err != nil {
+		t.Errorf("expected claims enabled after refill, got %v", err)
+	}
+}
+
+// Test that the balance is kept if it can not be loaded.
+func TestMonitor_CheckBalanceError(t *testing.T) {
+	m, repo := createMonitor(t, "")
+
+	repo.EXPECT().AccountBalance(common.HexToAddress("0x1")).Return((*hexutil.Big)(big.NewInt(1)), nil)
+	m.checkBalance(m.native)
+
+	repo.EXPECT().AccountBalance(common.HexToAddress("0x1")).Return(nil, fmt.Errorf("rpc error"))
+	m.checkBalance(m.native)
+
+	if err := m.Check(nil); err == nil {
+		t.Errorf("expected claims to stay disabled")
+	}
+}
+
+// Test that the webhook is notified once when a wallet runs low.
+func TestMonitor_Webhook(t *testing.T) {
+	var notifications []lowBalanceNotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n lowBalanceNotification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			t.Errorf("error decoding notification: %v", err)
+		}
+		notifications = append(notifications, n)
+	}))
+	defer server.Close()
+
+	m, repo := createMonitor(t, server.URL)
+	minter := m.erc20s[common.HexToAddress(kErc20Address)]
+
+	repo.EXPECT().AccountBalance(common.HexToAddress("0x2")).Return((*hexutil.Big)(big.NewInt(5)), nil).Times(2)
+	m.checkBalance(minter)
+	m.checkBalance(minter)
+
+	if len(notifications) != 1 {
+		t.Fatalf("expected single notification, got %d", len(notifications))
+	}
+	n := notifications[0]
+	if n.Address != common.HexToAddress("0x2") || n.Balance.ToInt().Cmp(big.NewInt(5)) != 0 || n.MinBalance.ToInt().Cmp(big.NewInt(10)) != 0 {
+		t.Errorf("unexpected notification %+v", n)
+	}
+}
@@ -0,0 +1,269 @@
+package faucet
+
+import (
+	"fmt"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// kTxQueueSize is the number of transactions waiting in the send queue of a wallet.
+	kTxQueueSize = 64
+
+	// kStuckTxTimeout is the time after which a transaction not mined yet is considered stuck.
+	kStuckTxTimeout = 2 * time.Minute
+
+	// kGasPriceBumpPercent is the gas price increase of replacement transactions,
+	// the transaction pool accepts replacements only if priced at least 10 % higher.
+	kGasPriceBumpPercent = 20
+
+	// kMaxSendAttempts is the number of attempts to send a transaction if the nonce is out of sync.
+	kMaxSendAttempts = 3
+)
+
+// txRequest represents a transaction waiting in the send queue.
+type txRequest struct {
+	to     common.Address
+	value  *big.Int
+	gas    uint64
+	data   []byte
+	result chan txResult
+
+	// sweep is true if the value is the whole balance of the wallet left after paying the fee.
+	sweep bool
+}
+
+// txResult represents the result of sending a queued transaction.
+type txResult struct {
+	tx  *types.Transaction
+	err error
+}
+
+// pendingTx represents a transaction sent to the network, but not mined yet.
+type pendingTx struct {
+	tx     *types.Transaction
+	sentAt time.Time
+}
+
+// nonceManager sends transactions of a wallet one by one through a serialized send queue.
+// The nonce is tracked locally and synchronized with the network only when needed,
+// so concurrent claims never race for the same nonce.
+type nonceManager struct {
+	repo  repository.IRepository
+	log   logger.ILogger
+	from  common.Address
+	sign  func(*types.Transaction) (*types.Transaction, error)
+	fees  *feeOracle
+	queue chan *txRequest
+
+	// nonce is the next nonce to be used, valid only if synced is true.
+	nonce  uint64
+	synced bool
+
+	// pending are the sent transactions not known to be mined yet, ordered by nonce.
+	pending []*pendingTx
+
+	// onReplaced is called when a stuck transaction is replaced, it must be set before sending.
+	onReplaced func(old common.Hash, new common.Hash)
+
+	// now returns the current time, it is replaced in tests.
+	now func() time.Time
+}
+
+// newNonceManager creates a new nonce manager of the given address and starts its send queue.
+func newNonceManager(repo repository.IRepository, log logger.ILogger, from common.Address, sign func(*types.Transaction) (*types.Transaction, error)) *nonceManager {
+	nm := &nonceManager{
+		repo:  repo,
+		log:   log,
+		from:  from,
+		sign:  sign,
+		fees:  newFeeOracle(repo, log),
+		queue: make(chan *txRequest, kTxQueueSize),
+		now:   time.Now,
+	}
+	go nm.run()
+	return nm
+}
+
+// Send queues the transaction with the given parameters and waits until it is sent.
+// It returns the signed transaction accepted by the network.
+func (nm *nonceManager) Send(to common.Address, value *big.Int, gas uint64, data []byte) (*types.Transaction, error) {
+	return nm.enqueue(&txRequest{
+		to:    to,
+		value: value,
+		gas:   gas,
+		data:  data,
+	})
+}
+
+// Sweep queues the transaction sending the whole balance of the wallet left after paying
+// the maximal fee to the given address and waits until it is sent.
+func (nm *nonceManager) Sweep(to common.Address) (*types.Transaction, error) {
+	return nm.enqueue(&txRequest{
+		to:    to,
+		gas:   21_000,
+		sweep: true,
+	})
+}
+
+// enqueue queues the given transaction request and waits for its result.
+func (nm *nonceManager) enqueue(req *txRequest) (*types.Transaction, error) {
+	req.result = make(chan txResult, 1)
+	nm.queue <- req
+	res := <-req.result
+	return res.tx, res.err
+}
+
+// run sends the queued transactions one by one.
+func (nm *nonceManager) run() {
+	for req := range nm.queue {
+		tx, err := nm.send(req)
+		req.result <- txResult{tx: tx, err: err}
+	}
+}
+
+// send signs and sends the given transaction request using the next nonce.
+// The nonce is resynchronized with the network and the transaction sent again
+// if the network rejects the nonce as too low.
+func (nm *nonceManager) send(req *txRequest) (*types.Transaction, error) {
+	// transactions stuck in the pool block all the following ones, replace them first
+	nm.replaceStuck()
+
+	for i := 0; i < kMaxSendAttempts; i++ {
+		if err := nm.sync(); err != nil {
+			return nil, err
+		}
+
+		fees, err := nm.fees.fees()
+		if err != nil {
+			return nil, err
+		}
+
+		value := req.value
+		if req.sweep {
+			value, err = nm.sweepValue(req.gas, fees)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		tx, err := nm.sign(fees.newTx(nm.nonce, req.to, value, req.gas, req.data))
+		if err != nil {
+			return nil, fmt.Errorf("error signing transaction: %v", err)
+		}
+
+		err = nm.repo.SendSignedTransaction(tx)
+		if err == nil {
+			nm.nonce++
+			nm.pending = append(nm.pending, &pendingTx{tx: tx, sentAt: nm.now()})
+			return tx, nil
+		}
+		if !isNonceTooLow(err) {
+			// the nonce was not used, it will be used by the next transaction
+			return nil, fmt.Errorf("error sending transaction: %v", err)
+		}
+
+		// the nonce was used outside of the manager, resynchronize and try again
+		nm.log.Warningf("nonce %d of %s is too low, resynchronizing", nm.nonce, nm.from.Hex())
+		nm.synced = false
+		nm.pending = nil
+	}
+	return nil, fmt.Errorf("error sending transaction: nonce out of sync after %d attempts", kMaxSendAttempts)
+}
+
+// sweepValue returns the balance of the wallet left after paying the maximal fee of the given gas.
+func (nm *nonceManager) sweepValue(gas uint64, fees txFees) (*big.Int, error) {
+	balance, err := nm.repo.AccountBalance(nm.from)
+	if err != nil {
+		return nil, fmt.Errorf("error getting balance: %v", err)
+	}
+	fee := new(big.Int).Mul(fees.maxPrice(), new(big.Int).SetUint64(gas))
+	value := new(big.Int).Sub(balance.ToInt(), fee)
+	if value.Sign() <= 0 {
+		return nil, fmt.Errorf("balance %s does not cover the fee %s", balance.ToInt(), fee)
+	}
+	return value, nil
+}
+
+// sync loads the next nonce from the network if it is not tracked locally.
+func (nm *nonceManager) sync() error {
+	if nm.synced {
+		return nil
+	}
+	nonce, err := nm.repo.PendingNonceAt(nm.from)
+	if err != nil {
+		return fmt.Errorf("error getting nonce: %v", err)
+	}
+	nm.nonce = nonce
+	nm.synced = true
+	return nil
+}
+
+// replaceStuck forgets the mined transactions and replaces the oldest pending transaction
+// with the same one priced higher, if it has not been mined for too long.
+func (nm *nonceManager) replaceStuck() {
+	if len(nm.pending) == 0 {
+		return
+	}
+
+	mined, err := nm.repo.NonceAt(nm.from)
+	if err != nil {
+		nm.log.Errorf("error getting mined nonce of %s: %v", nm.from.Hex(), err)
+		return
+	}
+	for len(nm.pending) > 0 && nm.pending[0].tx.Nonce() < mined {
+		nm.pending = nm.pending[1:]
+	}
+
+	// only the oldest transaction can be stuck, the following ones wait for it
+	if len(nm.pending) == 0 || nm.now().Sub(nm.pending[0].sentAt) < kStuckTxTimeout {
+		return
+	}
+	stuck := nm.pending[0]
+
+	// the replacement pays the bumped fees, or the current ones if higher
+	var current *txFees
+	if fees, err := nm.fees.fees(); err == nil {
+		current = &fees
+	}
+	fees := txFeesOf(stuck.tx).bumped(current)
+
+	tx, err := nm.sign(fees.newTx(stuck.tx.Nonce(), *stuck.tx.To(), stuck.tx.Value(), stuck.tx.Gas(), stuck.tx.Data()))
+	if err != nil {
+		nm.log.Errorf("error signing replacement of %s: %v", stuck.tx.Hash().Hex(), err)
+		return
+	}
+	if err := nm.repo.SendSignedTransaction(tx); err != nil {
+		if isNonceTooLow(err) {
+			// the stuck transaction has been mined meanwhile
+			nm.pending = nm.pending[1:]
+			return
+		}
+		nm.log.Errorf("error sending replacement of %s: %v", stuck.tx.Hash().Hex(), err)
+		return
+	}
+
+	nm.log.Warningf("replaced stuck transaction %s with %s", stuck.tx.Hash().Hex(), tx.Hash().Hex())
+	if nm.onReplaced != nil {
+		nm.onReplaced(stuck.tx.Hash(), tx.Hash())
+	}
+	stuck.tx = tx
+	stuck.sentAt = nm.now()
+}
+
+// bumpGasPrice returns the gas price, or a fee cap, increased by kGasPriceBumpPercent.
+func bumpGasPrice(gasPrice *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(gasPrice, big.NewInt(100+kGasPriceBumpPercent))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// isNonceTooLow returns true if the error means the nonce has already been used.
+func isNonceTooLow(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
@@ -0,0 +1,242 @@
+package faucet
+
+import (
+	"fmt"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+)
+
+// createNonceManager creates a nonce manager of legacy unsigned transactions with a mocked repository.
+func createNonceManager(t *testing.T) (*nonceManager, *repository.MockRepository) {
+	ctrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(ctrl)
+	sign := func(tx *types.Transaction) (*types.Transaction, error) {
+		return tx, nil
+	}
+	nm := newNonceManager(repo, logger.NewMockLogger(), common.HexToAddress("0x1"), sign)
+	london := false
+	nm.fees.london = &london
+	return nm, repo
+}
+
+// Test that concurrent transactions get consecutive nonces synchronized only once.
+func TestNonceManager_SendConcurrent(t *testing.T) {
+	nm, repo := createNonceManager(t)
+	receiver := common.HexToAddress("0x2")
+
+	var mu sync.Mutex
+	var nonces []uint64
+	repo.EXPECT().PendingNonceAt(nm.from).Return(uint64(5), nil).Times(1)
+	repo.EXPECT().NonceAt(nm.from).Return(uint64(5), nil).AnyTimes()
+	repo.EXPECT().SuggestGasPrice().Return(big.NewInt(1_000), nil).Times(10)
+	repo.EXPECT().SendSignedTransaction(gomock.Any()).DoAndReturn(func(tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		nonces = append(nonces, tx.Nonce())
+		return nil
+	}).Times(10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := nm.Send(receiver, big.NewInt(1), 21_000, nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	for i, nonce := range nonces {
+		if nonce != uint64(5+i) {
+			t.Fatalf("expected consecutive nonces, got %v", nonces)
+		}
+	}
+}
+
+// Test that the nonce is resynchronized if the network rejects it as too low.
+func TestNonceManager_SendNonceTooLow(t *testing.T) {
+	nm, repo := createNonceManager(t)
+	receiver := common.HexToAddress("0x2")
+
+	gomock.InOrder(
+		repo.EXPECT().PendingNonceAt(nm.from).Return(uint64(1), nil),
+		repo.EXPECT().PendingNonceAt(nm.from).Return(uint64(3), nil),
+	)
+	repo.EXPECT().SuggestGasPrice().Return(big.NewInt(1_000), nil).Times(2)
+	gomock.InOrder(
+		repo.EXPECT().SendSignedTransaction(gomock.Any()).Return(fmt.Errorf("nonce too low")),
+		repo.EXPECT().SendSignedTransaction(gomock.Any()).Return(nil),
+	)
+
+	tx, err := nm.Send(receiver, big.NewInt(1), 21_000, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.Nonce() != 3 || nm.nonce != 4 {
+		t.Fatalf("expected resynchronized nonce 3, got %d, next %d", tx.Nonce(), nm.nonce)
+	}
+}
+
+// Test that a failed send does not consume the nonce.
+func TestNonceManager_SendFailed(t *testing.T) {
+	nm, repo := createNonceManager(t)
+	receiver := common.HexToAddress("0x2")
+
+	repo.EXPECT().PendingNonceAt(nm.from).Return(uint64(7), nil)
+	repo.EXPECT().SuggestGasPrice().Return(big.NewInt(1_000), nil).Times(2)
+	gomock.InOrder(
+		repo.EXPECT().SendSignedTransaction(gomock.Any()).Return(fmt.Errorf("insufficient funds")),
+		repo.EXPECT().SendSignedTransaction(gomock.Any()).Return(nil),
+	)
+
+	if _, err := nm.Send(receiver, big.NewInt(1), 21_000, nil); err == nil {
+		t.Fatalf("expected error")
+	}
+	tx, err := nm.Send(receiver, big.NewInt(1), 21_000, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.Nonce() != 7 {
+		t.Fatalf("expected nonce 7 to be reused, got %d", tx.Nonce())
+	}
+}
+
+// Test that the stuck transaction is replaced with bumped gas price before the next one is sent.
+func TestNonceManager_ReplaceStuck(t *testing.T) {
+	nm, repo := createNonceManager(t)
+	receiver := common.HexToAddress("0x2")
+	now := time.Now()
+	nm.now = func() time.Time { return now }
+
+	// send the first transaction
+	repo.EXPECT().PendingNonceAt(nm.from).Return(uint64(0), nil)
+	repo.EXPECT().SuggestGasPrice().Return(big.NewInt(1_000), nil)
+	repo.EXPECT().SendSignedTransaction(gomock.Any()).Return(nil)
+	stuck, err := nm.Send(receiver, big.NewInt(1), 21_000, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the first transaction is not mined for too long
+	now = now.Add(kStuckTxTimeout)
+	var sent []*types.Transaction
+	repo.EXPECT().NonceAt(nm.from).Return(uint64(0), nil)
+	repo.EXPECT().SuggestGasPrice().Return(big.NewInt(1_000), nil).Times(2)
+	repo.EXPECT().SendSignedTransaction(gomock.Any()).DoAndReturn(func(tx *types.Transaction) error {
+		sent = append(sent, tx)
+		return nil
+	}).Times(2)
+	if _, err := nm.Send(receiver, big.NewInt(2), 21_000, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Fatalf("expected replacement and new transaction, got %d", len(sent))
+	}
+	if sent[0].Nonce() != stuck.Nonce() || sent[0].Value().Cmp(stuck.Value()) != 0 || sent[0].GasPrice().Cmp(big.NewInt(1_200)) != 0 {
+		t.Errorf("unexpected replacement: nonce %d, value %d, gas price %d", sent[0].Nonce(), sent[0].Value(), sent[0].GasPrice())
+	}
+	if sent[1].Nonce() != 1 {
+		t.Errorf("expected nonce 1 of the new transaction, got %d", sent[1].Nonce())
+	}
+
+	// mined transactions are forgotten
+	repo.EXPECT().NonceAt(nm.from).Return(uint64(2), nil)
+	repo.EXPECT().SuggestGasPrice().Return(big.NewInt(1_000), nil)
+	repo.EXPECT().SendSignedTransaction(gomock.Any()).Return(nil)
+	if _, err := nm.Send(receiver, big.NewInt(3), 21_000, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nm.pending) != 1 || nm.pending[0].tx.Nonce() != 2 {
+		t.Errorf("expected only the last transaction pending, got %d", len(nm.pending))
+	}
+}
+
+// Test that the stuck dynamic fee transaction is replaced with both caps bumped.
+func TestNonceManager_ReplaceStuckDynamic(t *testing.T) {
+	nm, repo := createNonceManager(t)
+	receiver := common.HexToAddress("0x2")
+	now := time.Now()
+	nm.now = func() time.Time { return now }
+	london := true
+	nm.fees.london = &london
+
+	history := &ethereum.FeeHistory{
+		BaseFee: []*big.Int{big.NewInt(1_000), big.NewInt(1_000)},
+		Reward:  [][]*big.Int{{big.NewInt(100)}},
+	}
+
+	// send the first transaction
+	repo.EXPECT().PendingNonceAt(nm.from).Return(uint64(0), nil)
+	repo.EXPECT().FeeHistory(uint64(kFeeHistoryBlocks), []float64{kTipPercentile}).Return(history, nil)
+	repo.EXPECT().SendSignedTransaction(gomock.Any()).Return(nil)
+	stuck, err := nm.Send(receiver, big.NewInt(1), 21_000, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stuck.Type() != types.DynamicFeeTxType || stuck.GasTipCap().Cmp(big.NewInt(100)) != 0 || stuck.GasFeeCap().Cmp(big.NewInt(2_100)) != 0 {
+		t.Fatalf("unexpected dynamic fee transaction: type %d, tip %d, fee cap %d", stuck.Type(), stuck.GasTipCap(), stuck.GasFeeCap())
+	}
+
+	// the first transaction is not mined for too long
+	now = now.Add(kStuckTxTimeout)
+	var sent []*types.Transaction
+	repo.EXPECT().NonceAt(nm.from).Return(uint64(0), nil)
+	repo.EXPECT().FeeHistory(uint64(kFeeHistoryBlocks), []float64{kTipPercentile}).Return(history, nil).Times(2)
+	repo.EXPECT().SendSignedTransaction(gomock.Any()).DoAndReturn(func(tx *types.Transaction) error {
+		sent = append(sent, tx)
+		return nil
+	}).Times(2)
+	if _, err := nm.Send(receiver, big.NewInt(2), 21_000, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Fatalf("expected replacement and new transaction, got %d", len(sent))
+	}
+	if sent[0].Nonce() != 0 || sent[0].GasTipCap().Cmp(big.NewInt(120)) != 0 || sent[0].GasFeeCap().Cmp(big.NewInt(2_520)) != 0 {
+		t.Errorf("unexpected replacement: nonce %d, tip %d, fee cap %d", sent[0].Nonce(), sent[0].GasTipCap(), sent[0].GasFeeCap())
+	}
+}
+
+// Test that the sweep sends the whole balance except the fee.
+func TestNonceManager_Sweep(t *testing.T) {
+	nm, repo := createNonceManager(t)
+	receiver := common.HexToAddress("0x2")
+
+	repo.EXPECT().PendingNonceAt(nm.from).Return(uint64(0), nil)
+	repo.EXPECT().NonceAt(nm.from).Return(uint64(0), nil).AnyTimes()
+	repo.EXPECT().SuggestGasPrice().Return(big.NewInt(10), nil).Times(2)
+	gomock.InOrder(
+		repo.EXPECT().AccountBalance(nm.from).Return((*hexutil.Big)(big.NewInt(1_000_000)), nil),
+		repo.EXPECT().AccountBalance(nm.from).Return((*hexutil.Big)(big.NewInt(100_000)), nil),
+	)
+	repo.EXPECT().SendSignedTransaction(gomock.Any()).Return(nil)
+
+	tx, err := nm.Sweep(receiver)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.Value().Cmp(big.NewInt(1_000_000-210_000)) != 0 || *tx.To() != receiver {
+		t.Errorf("expected balance without fee sent to receiver, got %d to %s", tx.Value(), tx.To().Hex())
+	}
+
+	// the balance must cover the fee
+	if _, err := nm.Sweep(receiver); err == nil {
+		t.Errorf("expected error of balance not covering the fee")
+	}
+}
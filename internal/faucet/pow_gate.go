@@ -0,0 +1,108 @@
+package faucet
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"ftm-explorer/internal/repository"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+const (
+	// kPowResource is the resource the proof-of-work stamps must be minted for.
+	kPowResource = "ftm-explorer-faucet"
+
+	// kPowDateFormat is the format of the stamp date, YYMMDDhhmmss in UTC.
+	kPowDateFormat = "060102150405"
+
+	// kPowStampTtl is the time a stamp is accepted after its date.
+	kPowStampTtl = 10 * time.Minute
+
+	// kPowStampSkew is the tolerance of stamps dated in the future due to clock skew.
+	kPowStampSkew = time.Minute
+)
+
+// powGate represents a gate requiring a hashcash version 1 stamp
+// "1:bits:date:resource:ext:rand:counter" whose SHA-1 hash starts
+// with the configured number of zero bits. Each stamp can be used only once,
+// the spent stamps are kept in the repository until they expire.
+type powGate struct {
+	difficulty uint
+	repo       repository.IRepository
+
+	// now returns the current time, it is replaced in tests.
+	now func() time.Time
+}
+
+// newPowGate creates a new proof-of-work gate of the given difficulty.
+func newPowGate(difficulty uint, repo repository.IRepository) *powGate {
+	return &powGate{
+		difficulty: difficulty,
+		repo:       repo,
+		now:        time.Now,
+	}
+}
+
+// Params returns the parameters of the proof-of-work stamps.
+func (g *powGate) Params() GateParams {
+	difficulty := g.difficulty
+	return GateParams{
+		Kind:       kGatePow,
+		Difficulty: &difficulty,
+		Resource:   kPowResource,
+	}
+}
+
+// Verify verifies the given hashcash stamp.
+func (g *powGate) Verify(_ string, stamp string) error {
+	if stamp == "" {
+		return fmt.Errorf("proof of work required")
+	}
+
+	parts := strings.Split(stamp, ":")
+	if len(parts) != 7 || parts[0] != "1" {
+		return fmt.Errorf("invalid proof of work stamp")
+	}
+	if parts[3] != kPowResource {
+		return fmt.Errorf("proof of work stamp must be minted for %s", kPowResource)
+	}
+
+	// check the stamp is fresh
+	date, err := time.ParseInLocation(kPowDateFormat, parts[2], time.UTC)
+	if err != nil {
+		return fmt.Errorf("invalid proof of work stamp date")
+	}
+	now := g.now()
+	if date.Before(now.Add(-kPowStampTtl)) || date.After(now.Add(kPowStampSkew)) {
+		return fmt.Errorf("proof of work stamp expired")
+	}
+
+	// check the work has been done
+	hash := sha1.Sum([]byte(stamp))
+	if leadingZeroBits(hash[:]) < g.difficulty {
+		return fmt.Errorf("insufficient proof of work, %d leading zero bits required", g.difficulty)
+	}
+
+	// check the stamp has not been used yet
+	ok, err := g.repo.UsePowStamp(stamp, date.Add(kPowStampTtl))
+	if err != nil {
+		return fmt.Errorf("failed to verify proof of work stamp; %v", err)
+	}
+	if !ok {
+		return fmt.Errorf("proof of work stamp already used")
+	}
+	return nil
+}
+
+// leadingZeroBits returns the number of leading zero bits of the given hash.
+func leadingZeroBits(hash []byte) uint {
+	var n uint
+	for _, b := range hash {
+		n += uint(bits.LeadingZeros8(b))
+		if b != 0 {
+			break
+		}
+	}
+	return n
+}
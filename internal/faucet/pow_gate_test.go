@@ -0,0 +1,106 @@
+package faucet
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"ftm-explorer/internal/repository"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// mintStamp mints a hashcash stamp of the given resource and date with the given number of leading zero bits.
+func mintStamp(t *testing.T, bits uint, date time.Time, resource string) string {
+	t.Helper()
+	prefix := fmt.Sprintf("1:%d:%s:%s::c2FsdA==:", bits, date.UTC().Format(kPowDateFormat), resource)
+	for counter := 0; counter < 1<<24; counter++ {
+		stamp := fmt.Sprintf("%s%x", prefix, counter)
+		hash := sha1.Sum([]byte(stamp))
+		if leadingZeroBits(hash[:]) >= bits {
+			return stamp
+		}
+	}
+	t.Fatalf("failed to mint stamp")
+	return ""
+}
+
+// Test that the valid stamp is accepted only once.
+func TestPowGate_Verify(t *testing.T) {
+	mockRepository := repository.NewMockRepository(gomock.NewController(t))
+	gate := newPowGate(12, mockRepository)
+	date := time.Now().UTC().Truncate(time.Second)
+	stamp := mintStamp(t, 12, date, kPowResource)
+
+	// the stamp is spent until it expires
+	gomock.InOrder(
+		mockRepository.EXPECT().UsePowStamp(gomock.Eq(stamp), gomock.Eq(date.Add(kPowStampTtl))).Return(true, nil),
+		mockRepository.EXPECT().UsePowStamp(gomock.Eq(stamp), gomock.Eq(date.Add(kPowStampTtl))).Return(false, nil),
+	)
+
+	if err := gate.Verify("192.168.0.1", stamp); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if err := gate.Verify("192.168.0.1", stamp); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Fatalf("expected used stamp error, got %v", err)
+	}
+}
+
+// Test that the stamp is rejected if it can not be spent.
+func TestPowGate_VerifyRepositoryError(t *testing.T) {
+	mockRepository := repository.NewMockRepository(gomock.NewController(t))
+	gate := newPowGate(12, mockRepository)
+	stamp := mintStamp(t, 12, time.Now(), kPowResource)
+
+	mockRepository.EXPECT().UsePowStamp(gomock.Eq(stamp), gomock.Any()).Return(false, fmt.Errorf("db down"))
+	if err := gate.Verify("192.168.0.1", stamp); err == nil {
+		t.Fatalf("expected error when the stamp can not be spent")
+	}
+}
+
+// Test that the invalid stamps are rejected.
+func TestPowGate_VerifyInvalid(t *testing.T) {
+	// invalid stamps are rejected before they are spent
+	gate := newPowGate(12, repository.NewMockRepository(gomock.NewController(t)))
+	now := time.Now()
+
+	for name, stamp := range map[string]string{
+		"missing":         "",
+		"malformed":       "1:12:ftm",
+		"version":         "2:12:" + now.UTC().Format(kPowDateFormat) + ":" + kPowResource + "::salt:1",
+		"resource":        mintStamp(t, 12, now, "other-resource"),
+		"date":            "1:12:yesterday:" + kPowResource + "::salt:1",
+		"expired":         mintStamp(t, 12, now.Add(-kPowStampTtl-time.Minute), kPowResource),
+		"future":          mintStamp(t, 12, now.Add(kPowStampSkew+time.Minute), kPowResource),
+		"not enough bits": mintStamp(t, 4, now, kPowResource),
+	} {
+		// a stamp of fewer bits may reach the difficulty by chance
+		if name == "not enough bits" {
+			hash := sha1.Sum([]byte(stamp))
+			if leadingZeroBits(hash[:]) >= 12 {
+				continue
+			}
+		}
+		if err := gate.Verify("192.168.0.1", stamp); err == nil {
+			t.Errorf("expected %s stamp to be rejected", name)
+		}
+	}
+}
+
+// Test that the leading zero bits are counted across bytes.
+func TestPowGate_LeadingZeroBits(t *testing.T) {
+	for _, tc := range []struct {
+		hash []byte
+		bits uint
+	}{
+		{[]byte{0x80, 0x00}, 0},
+		{[]byte{0x01, 0xff}, 7},
+		{[]byte{0x00, 0x10}, 11},
+		{[]byte{0x00, 0x00}, 16},
+	} {
+		if bits := leadingZeroBits(tc.hash); bits != tc.bits {
+			t.Errorf("expected %d leading zero bits of %x, got %d", tc.bits, tc.hash, bits)
+		}
+	}
+}
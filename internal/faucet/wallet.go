@@ -8,18 +8,35 @@ import (
 	"math/big"
 	"strings"
 
+	"github.com/ethereum/go-ethereum"
 	abi2 "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// kGasLimitMarginPercent is the margin added to the estimated gas limit of contract calls.
+const kGasLimitMarginPercent = 20
+
+// kErc20Abi is the abi definition of the erc20 functions used by the faucet.
+const kErc20Abi = `[
+  {"inputs":[{"internalType": "address","name": "recipient","type": "address"},{"internalType": "uint256","name": "amount","type": "uint256"}],"name": "mint","outputs": [],"stateMutability": "nonpayable","type": "function"},
+  {"inputs":[{"internalType": "address","name": "recipient","type": "address"},{"internalType": "uint256","name": "amount","type": "uint256"}],"name": "transfer","outputs": [{"internalType": "bool","name": "","type": "bool"}],"stateMutability": "nonpayable","type": "function"}
+]`
+
+// kMultisendAbi is the abi definition of the multisend contract function paying the native claims in batches.
+const kMultisendAbi = `[
+  {"inputs":[{"internalType": "address[]","name": "recipients","type": "address[]"},{"internalType": "uint256[]","name": "values","type": "uint256[]"}],"name": "disperseEther","outputs": [],"stateMutability": "payable","type": "function"}
+]`
+
 // Wallet represents a faucet wallet. It is used to send wei to the given address.
+// Transactions of the wallet are sent one by one by its nonce manager.
 type Wallet struct {
 	repo repository.IRepository
 	log  logger.ILogger
 	pk   *ecdsa.PrivateKey
 	from common.Address
+	nm   *nonceManager
 }
 
 // NewWallet returns a new wallet.
@@ -41,112 +58,153 @@ func NewWallet(repo repository.IRepository, log logger.ILogger, pk string) (*Wal
 	}
 	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
 
-	return &Wallet{
+	w := &Wallet{
 		repo: repo,
 		log:  wl,
 		pk:   privateKey,
 		from: fromAddress,
-	}, nil
+	}
+	w.nm = newNonceManager(repo, wl, fromAddress, w.signTx)
+	return w, nil
 }
 
-// SendWeiToAddress sends wei to the given address.
-func (w *Wallet) SendWeiToAddress(amount *big.Int, receiver common.Address) error {
-	// get nonce
-	nonce, err := w.repo.PendingNonceAt(w.from)
+// SendWeiToAddress sends wei to the given address and returns the transaction hash.
+func (w *Wallet) SendWeiToAddress(amount *big.Int, receiver common.Address) (common.Hash, error) {
+	// send transaction, set gas limit to 21000, which is the cost of a normal transaction
+	tx, err := w.nm.Send(receiver, amount, 21_000, nil)
 	if err != nil {
-		w.log.Criticalf("error getting nonce: %v", err)
+		w.log.Criticalf("error sending wei: %v", err)
+		return common.Hash{}, err
 	}
+	return tx.Hash(), nil
+}
 
-	// get gas price
-	gasPrice, err := w.repo.SuggestGasPrice()
+// MintErc20TokensToAddress mints erc20 tokens to the given address and returns the transaction hash.
+func (w *Wallet) MintErc20TokensToAddress(contract common.Address, receiver common.Address, amount *big.Int) (common.Hash, error) {
+	// get erc20 mint data
+	data, err := getErc20CallData("mint", receiver, amount)
 	if err != nil {
-		w.log.Criticalf("error getting gas price: %v", err)
-		return err
+		w.log.Criticalf("error getting erc20 mint data: %v", err)
+		return common.Hash{}, err
 	}
 
-	// get network id
-	chainID, err := w.repo.NetworkID()
+	hash, err := w.sendContractCall(contract, new(big.Int), data)
 	if err != nil {
-		w.log.Criticalf("error getting network id: %v", err)
-		return err
+		w.log.Criticalf("error minting erc20 tokens: %v", err)
+		return common.Hash{}, err
 	}
+	return hash, nil
+}
 
-	// create transaction, set gas limit to 21000, which is the cost of a normal transaction
-	tx := types.NewTransaction(nonce, receiver, amount, 21_000, gasPrice, nil)
-
-	// sign transaction
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), w.pk)
+// TransferErc20TokensToAddress transfers erc20 tokens of the wallet to the given address and returns the transaction hash.
+func (w *Wallet) TransferErc20TokensToAddress(contract common.Address, receiver common.Address, amount *big.Int) (common.Hash, error) {
+	// get erc20 transfer data
+	data, err := getErc20CallData("transfer", receiver, amount)
 	if err != nil {
-		w.log.Criticalf("error signing transaction: %v", err)
-		return err
+		w.log.Criticalf("error getting erc20 transfer data: %v", err)
+		return common.Hash{}, err
 	}
 
-	// send transaction
-	if err = w.repo.SendSignedTransaction(signedTx); err != nil {
-		w.log.Criticalf("error sending transaction: %v", err)
-		return err
+	hash, err := w.sendContractCall(contract, new(big.Int), data)
+	if err != nil {
+		w.log.Criticalf("error transferring erc20 tokens: %v", err)
+		return common.Hash{}, err
 	}
-
-	return nil
+	return hash, nil
 }
 
-// MintErc20TokensToAddress mints erc20 tokens to the given address.
-func (w *Wallet) MintErc20TokensToAddress(contract common.Address, receiver common.Address, amount *big.Int) error {
-	// get nonce
-	nonce, err := w.repo.PendingNonceAt(w.from)
+// SendWeiToAddresses sends the given amounts of wei to the receivers in a single call
+// of the multisend contract and returns the transaction hash.
+func (w *Wallet) SendWeiToAddresses(contract common.Address, amounts []*big.Int, receivers []common.Address) (common.Hash, error) {
+	// get multisend data
+	abi, err := abi2.JSON(strings.NewReader(kMultisendAbi))
 	if err != nil {
-		w.log.Criticalf("error getting nonce: %v", err)
+		w.log.Criticalf("error parsing multisend abi: %v", err)
+		return common.Hash{}, err
 	}
-
-	// get gas price
-	gasPrice, err := w.repo.SuggestGasPrice()
+	data, err := abi.Pack("disperseEther", receivers, amounts)
 	if err != nil {
-		w.log.Criticalf("error getting gas price: %v", err)
-		return err
+		w.log.Criticalf("error getting multisend data: %v", err)
+		return common.Hash{}, err
 	}
 
-	// get network id
-	chainID, err := w.repo.NetworkID()
+	// the contract forwards the whole value of the call to the receivers
+	value := new(big.Int)
+	for _, amount := range amounts {
+		value.Add(value, amount)
+	}
+
+	hash, err := w.sendContractCall(contract, value, data)
 	if err != nil {
-		w.log.Criticalf("error getting network id: %v", err)
-		return err
+		w.log.Criticalf("error sending wei to %d addresses: %v", len(receivers), err)
+		return common.Hash{}, err
 	}
+	return hash, nil
+}
 
-	// get erc20 mint data
-	data, err := getErc20MintData(receiver, amount)
+// sendContractCall sends the given call of the contract with the gas limit estimated by the network.
+// The estimation fails if the call would revert, e.g. if the wallet has not enough tokens to transfer.
+func (w *Wallet) sendContractCall(contract common.Address, value *big.Int, data []byte) (common.Hash, error) {
+	gas, err := w.repo.EstimateGas(ethereum.CallMsg{
+		From:  w.from,
+		To:    &contract,
+		Value: value,
+		Data:  data,
+	})
 	if err != nil {
-		w.log.Criticalf("error getting erc20 mint data: %v", err)
-		return err
+		return common.Hash{}, fmt.Errorf("error estimating gas: %v", err)
 	}
 
-	// create transaction
-	tx := types.NewTransaction(nonce, contract, new(big.Int).SetUint64(0), 100_000, gasPrice, data)
+	// the state may change until the transaction is mined, add a margin to the estimation
+	gas += gas * kGasLimitMarginPercent / 100
 
-	// sign transaction
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), w.pk)
+	tx, err := w.nm.Send(contract, value, gas, data)
 	if err != nil {
-		w.log.Criticalf("error signing transaction: %v", err)
-		return err
+		return common.Hash{}, err
 	}
+	return tx.Hash(), nil
+}
 
-	// send transaction
-	if err = w.repo.SendSignedTransaction(signedTx); err != nil {
-		w.log.Criticalf("error sending transaction: %v", err)
-		return err
+// Drain sends the whole balance of the wallet, except the fee of the transaction,
+// to the given address and returns the transaction hash.
+func (w *Wallet) Drain(receiver common.Address) (common.Hash, error) {
+	tx, err := w.nm.Sweep(receiver)
+	if err != nil {
+		w.log.Criticalf("error draining wallet: %v", err)
+		return common.Hash{}, err
 	}
+	return tx.Hash(), nil
+}
 
-	return nil
+// OnReplaced sets the callback called when a stuck transaction of the wallet is replaced.
+func (w *Wallet) OnReplaced(fn func(old common.Hash, new common.Hash)) {
+	w.nm.onReplaced = fn
+}
+
+// Address returns the address of the wallet.
+func (w *Wallet) Address() common.Address {
+	return w.from
+}
+
+// signTx signs the given transaction by the wallet key.
+func (w *Wallet) signTx(tx *types.Transaction) (*types.Transaction, error) {
+	// get network id
+	chainID, err := w.repo.NetworkID()
+	if err != nil {
+		return nil, fmt.Errorf("error getting network id: %v", err)
+	}
+	// the london signer signs both the legacy and the dynamic fee transactions
+	return types.SignTx(tx, types.NewLondonSigner(chainID), w.pk)
 }
 
-// getErc20MintData returns the erc20 mint data.
-func getErc20MintData(receiver common.Address, amount *big.Int) ([]byte, error) {
-	definition := `[{"inputs":[{"internalType": "address","name": "recipient","type": "address"},{"internalType": "uint256","name": "amount","type": "uint256"}],"name": "mint","outputs": [],"stateMutability": "nonpayable","type": "function"}]`
-	abi, err := abi2.JSON(strings.NewReader(definition))
+// getErc20CallData returns the data of the given erc20 call sending the amount to the receiver.
+func getErc20CallData(method string, receiver common.Address, amount *big.Int) ([]byte, error) {
+	abi, err := abi2.JSON(strings.NewReader(kErc20Abi))
 	if err != nil {
 		return nil, err
 	}
-	// pack constructor params
-	data, err := abi.Pack("mint", receiver, amount)
+	// pack call params
+	data, err := abi.Pack(method, receiver, amount)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +49,7 @@ func TestFaucetWallet_SendWeiToAddress(t *testing.T) {
 	}
 
 	// initialize repository
-	repo := repository.NewRepository(10_000, client, database, nil)
+	repo := repository.NewRepository(10_000, 10_000, client, database, nil)
 
 	// initialize wallet
 	wallet, err := NewWallet(repo, log, "bb39aa88008bc6260ff9ebc816178c47a01c44efe55810ea1f271c00f5878812")
@@ -69,7 +69,7 @@ func TestFaucetWallet_SendWeiToAddress(t *testing.T) {
 
 	// send 2.5 eth to receiver
 	wei := getTokensAmountInWei(2.5)
-	err = wallet.SendWeiToAddress(wei, receiver)
+	_, err = wallet.SendWeiToAddress(wei, receiver)
 
 	// get updated balances
 	senderBalanceUpdated, err := ethClient.BalanceAt(context.Background(), wallet.from, nil)
@@ -111,7 +111,7 @@ func TestFaucetWallet_MintErc20Tokens(t *testing.T) {
 	}
 
 	// initialize repository
-	repo := repository.NewRepository(10_000, client, database, nil)
+	repo := repository.NewRepository(10_000, 10_000, client, database, nil)
 
 	// initialize wallet
 	wallet, err := NewWallet(repo, log, "bb39aa88008bc6260ff9ebc816178c47a01c44efe55810ea1f271c00f5878812")
@@ -123,7 +123,7 @@ func TestFaucetWallet_MintErc20Tokens(t *testing.T) {
 	contractAddress := deployErc20Contract(t, client)
 
 	// mint erc 20 tokens
-	err = wallet.MintErc20TokensToAddress(contractAddress, receiver, big.NewInt(1000))
+	_, err = wallet.MintErc20TokensToAddress(contractAddress, receiver, big.NewInt(1000))
 	if err != nil {
 		t.Fatal(err)
 	}
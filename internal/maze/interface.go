@@ -20,4 +20,6 @@ type IMaze interface {
 	TileToPosition(common.Address, int32) (*types.MazePosition, error)
 	// GenerateChallenge generates a challenge for the maze.
 	GenerateChallenge() (string, error)
+	// UseChallenge marks the given challenge as used by the given player.
+	UseChallenge(string, common.Address) error
 }
@@ -3,15 +3,23 @@ package maze
 import (
 	"fmt"
 	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/repository"
 	"ftm-explorer/internal/types"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/tyler-smith/go-bip39"
 )
 
-// kMazeChallengePrefix is prefix of message to be signed using Metamask.
-const kMazeChallengePrefix = "Please sign following text to obtain your maze position:\n\n"
+const (
+	// kMazeChallengePrefix is prefix of message to be signed using Metamask.
+	kMazeChallengePrefix = "Please sign following text to obtain your maze position:\n\n"
+
+	// kMazeDefaultChallengeTtl is the default time an issued challenge can be used.
+	kMazeDefaultChallengeTtl = 5 * time.Minute
+)
 
 // mazeTile represents the path of the maze.
 type mazeTile struct {
@@ -27,7 +35,8 @@ type mazeTile struct {
 
 // Maze represents a maze.
 type Maze struct {
-	cfg *config.Maze
+	cfg  *config.Maze
+	repo repository.IRepository
 	// address => maze
 	mazes map[string]*types.Maze
 	// address => tile id => maze tile
@@ -35,9 +44,10 @@ type Maze struct {
 }
 
 // NewMaze creates a new maze.
-func NewMaze(cfg *config.Maze) *Maze {
+func NewMaze(cfg *config.Maze, repo repository.IRepository) *Maze {
 	m := &Maze{
-		cfg: cfg,
+		cfg:  cfg,
+		repo: repo,
 	}
 	// initialize mazes
 	m.mazes = make(map[string]*types.Maze)
@@ -203,15 +213,46 @@ func (m *Maze) TileToPosition(mazeAddress common.Address, tileId int32) (*types.
 	}, nil
 }
 
-// GenerateChallenge generates a challenge for the maze.
+// GenerateChallenge generates a challenge for the maze. The challenge is stored,
+// so it can be used only once and only until it expires.
 func (m *Maze) GenerateChallenge() (string, error) {
 	phrase, err := m.generatePhrase()
 	if err != nil {
 		return "", fmt.Errorf("error generating phrase: %v", err)
 	}
+
+	ttl := time.Duration(m.cfg.ChallengeTtl) * time.Second
+	if ttl == 0 {
+		ttl = kMazeDefaultChallengeTtl
+	}
+	if err := m.repo.AddMazeChallenge(&types.MazeChallenge{
+		Phrase:    phrase,
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", fmt.Errorf("error storing challenge: %v", err)
+	}
+
 	return kMazeChallengePrefix + phrase, nil
 }
 
+// UseChallenge marks the given challenge as used by the given player. It returns an error
+// if the challenge was not issued by the maze, has expired or has already been used.
+func (m *Maze) UseChallenge(challenge string, player common.Address) error {
+	phrase, ok := strings.CutPrefix(challenge, kMazeChallengePrefix)
+	if !ok {
+		return fmt.Errorf("invalid challenge")
+	}
+
+	mc, err := m.repo.UseMazeChallenge(phrase, player)
+	if err != nil {
+		return fmt.Errorf("error using challenge: %v", err)
+	}
+	if mc == nil {
+		return fmt.Errorf("challenge is unknown, expired or already used")
+	}
+	return nil
+}
+
 // generatePhrase generates a phrase for the maze.
 func (m *Maze) generatePhrase() (string, error) {
 	// generate phrase based on bip-39 standard
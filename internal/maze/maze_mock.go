@@ -106,3 +106,17 @@ func (mr *MockMazeMockRecorder) TileToPosition(arg0, arg1 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TileToPosition", reflect.TypeOf((*MockMaze)(nil).TileToPosition), arg0, arg1)
 }
+
+// UseChallenge mocks base method.
+func (m *MockMaze) UseChallenge(arg0 string, arg1 common.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseChallenge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseChallenge indicates an expected call of UseChallenge.
+func (mr *MockMazeMockRecorder) UseChallenge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseChallenge", reflect.TypeOf((*MockMaze)(nil).UseChallenge), arg0, arg1)
+}
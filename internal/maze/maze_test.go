@@ -3,15 +3,18 @@ package maze
 import (
 	"encoding/json"
 	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/repository"
 	"ftm-explorer/internal/types"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
 )
 
 // Test that the phrase generator returns a valid phrase.
 func TestMaze_GeneratePhrase(t *testing.T) {
-	m := createMaze(t)
+	m, _ := createMaze(t)
 	phrase, err := m.generatePhrase()
 	if err != nil {
 		t.Fatalf("GeneratePhrase failed: %v", err)
@@ -23,19 +26,53 @@ func TestMaze_GeneratePhrase(t *testing.T) {
 
 // Test that the challenge generator returns a valid challenge.
 func TestMaze_GenerateChallenge(t *testing.T) {
-	m := createMaze(t)
+	m, repo := createMaze(t)
+
+	// expect the challenge to be stored with the default expiration
+	var stored *types.MazeChallenge
+	repo.EXPECT().AddMazeChallenge(gomock.Any()).DoAndReturn(func(mc *types.MazeChallenge) error {
+		stored = mc
+		return nil
+	})
+
 	challenge, err := m.GenerateChallenge()
 	if err != nil {
 		t.Fatalf("GenerateChallenge failed: %v", err)
 	}
-	if len(challenge) == 0 {
+	if challenge != kMazeChallengePrefix+stored.Phrase {
 		t.Fatalf("Invalid challenge returned: %s", challenge)
 	}
+	if ttl := time.Until(stored.ExpiresAt); ttl <= 0 || ttl > kMazeDefaultChallengeTtl {
+		t.Fatalf("Invalid challenge expiration: %v", stored.ExpiresAt)
+	}
+}
+
+// Test that the challenge can be used only once.
+func TestMaze_UseChallenge(t *testing.T) {
+	m, repo := createMaze(t)
+	player := common.Address{0x01}
+
+	// expect the challenge to be used
+	repo.EXPECT().UseMazeChallenge("0x1234", player).Return(&types.MazeChallenge{Phrase: "0x1234"}, nil)
+	if err := m.UseChallenge(kMazeChallengePrefix+"0x1234", player); err != nil {
+		t.Fatalf("UseChallenge failed: %v", err)
+	}
+
+	// unknown, expired or used challenges are rejected
+	repo.EXPECT().UseMazeChallenge("0x1234", player).Return(nil, nil)
+	if err := m.UseChallenge(kMazeChallengePrefix+"0x1234", player); err == nil {
+		t.Fatalf("UseChallenge did not return error for used challenge")
+	}
+
+	// challenges without prefix are rejected without reaching the repository
+	if err := m.UseChallenge("0x1234", player); err == nil {
+		t.Fatalf("UseChallenge did not return error for invalid challenge")
+	}
 }
 
 // Test that the maze exists.
 func TestMaze_GetMaze(t *testing.T) {
-	m := createMaze(t)
+	m, _ := createMaze(t)
 	maze := m.GetMaze(common.HexToAddress("0x6dDb82e5B91e5941f4633Cb3ee49560Fb4582EFA"))
 	if maze == nil {
 		t.Fatalf("GetMaze returned nil")
@@ -44,7 +81,7 @@ func TestMaze_GetMaze(t *testing.T) {
 
 // Test that the maze does not exist.
 func TestMaze_GetMaze_NotFound(t *testing.T) {
-	m := createMaze(t)
+	m, _ := createMaze(t)
 	maze := m.GetMaze(common.HexToAddress("0x6dDb82e5B91e5941f4633Cb3ee49560Fb4582EFB"))
 	if maze != nil {
 		t.Fatalf("GetMaze returned maze")
@@ -53,7 +90,7 @@ func TestMaze_GetMaze_NotFound(t *testing.T) {
 
 // Test that the first tile is returned correctly.
 func TestMaze_First_Tile(t *testing.T) {
-	m := createMaze(t)
+	m, _ := createMaze(t)
 	tile, err := m.TileToPosition(common.HexToAddress("0x6dDb82e5B91e5941f4633Cb3ee49560Fb4582EFA"), 3145)
 	if err != nil {
 		t.Fatalf("TileToPosition failed: %v", err)
@@ -83,7 +120,7 @@ func TestMaze_First_Tile(t *testing.T) {
 
 // Test that the exit tile is returned correctly.
 func TestMaze_Exit_Tile(t *testing.T) {
-	m := createMaze(t)
+	m, _ := createMaze(t)
 	tile, err := m.TileToPosition(common.HexToAddress("0x6dDb82e5B91e5941f4633Cb3ee49560Fb4582EFA"), 2145)
 	if err != nil {
 		t.Fatalf("TileToPosition failed: %v", err)
@@ -110,7 +147,7 @@ func TestMaze_Exit_Tile(t *testing.T) {
 
 // Test that the tile with many paths is returned correctly.
 func TestMaze_Tile_With_Many_Paths(t *testing.T) {
-	m := createMaze(t)
+	m, _ := createMaze(t)
 	tile, err := m.TileToPosition(common.HexToAddress("0x6dDb82e5B91e5941f4633Cb3ee49560Fb4582EFA"), 882)
 	if err != nil {
 		t.Fatalf("TileToPosition failed: %v", err)
@@ -160,19 +197,20 @@ func TestMaze_Tile_With_Many_Paths(t *testing.T) {
 }
 
 // Create a maze and test that it exists.
-func createMaze(t *testing.T) *Maze {
+func createMaze(t *testing.T) (*Maze, *repository.MockRepository) {
 	t.Helper()
+	repo := repository.NewMockRepository(gomock.NewController(t))
 	cfg := config.Maze{
 		VisibilityRange: 3,
 		Configs: []config.MazeConfig{
 			getMazeCfg(t),
 		},
 	}
-	maze := NewMaze(&cfg)
+	maze := NewMaze(&cfg, repo)
 	if maze == nil {
 		t.Fatalf("Failed to create maze")
 	}
-	return maze
+	return maze, repo
 }
 
 // Get the maze configuration.
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"ftm-explorer/internal/types"
+)
+
+// clientCtxKey is the key of the rate limited client in the context values.
+type clientCtxKey struct{}
+
+// client represents a rate limited client together with its limiter.
+type client struct {
+	limiter *Limiter
+	key     string
+}
+
+// SetClient adds the given limiter and client key to the provided context,
+// so the operations executed within the context are charged to the client.
+func SetClient(ctx context.Context, l *Limiter, key string) context.Context {
+	return context.WithValue(ctx, clientCtxKey{}, &client{limiter: l, key: key})
+}
+
+// CopyClient copies the rate limited client of the source context into the provided context.
+// It is used to charge operations received over a websocket connection, which are not executed
+// within the context of the upgraded request.
+func CopyClient(ctx context.Context, src context.Context) context.Context {
+	if c, ok := src.Value(clientCtxKey{}).(*client); ok {
+		return context.WithValue(ctx, clientCtxKey{}, c)
+	}
+	return ctx
+}
+
+// TakeFromContext takes a token from the bucket of the client of the given context in the given budget.
+// Operations executed within a context without client are not limited.
+func TakeFromContext(ctx context.Context, budget Budget) (types.RateLimitResult, error) {
+	c, ok := ctx.Value(clientCtxKey{}).(*client)
+	if !ok {
+		return types.RateLimitResult{Allowed: true}, nil
+	}
+	return c.limiter.Take(budget, c.key)
+}
+
+// OperationBudget returns the budget of the given GraphQL operation type,
+// i.e. "query", "mutation" or "subscription". Unknown operations are charged as queries.
+func OperationBudget(kind string) Budget {
+	switch kind {
+	case "mutation":
+		return BudgetMutation
+	case "subscription":
+		return BudgetSubscription
+	default:
+		return BudgetQuery
+	}
+}
@@ -0,0 +1,30 @@
+package ratelimit
+
+import (
+	"context"
+	"ftm-explorer/internal/types"
+	"testing"
+)
+
+// Test that operations are charged to the client copied into another context.
+func TestTakeFromContext(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), map[Budget]types.RateLimit{BudgetMutation: {Rate: 0.01, Burst: 1}})
+
+	// contexts without client are not limited
+	for i := 0; i < 2; i++ {
+		if res, err := TakeFromContext(context.Background(), BudgetMutation); err != nil || !res.Allowed {
+			t.Fatalf("expected operation without client to be allowed; %v", err)
+		}
+	}
+
+	ctx := CopyClient(context.Background(), SetClient(context.Background(), l, "client"))
+	if res, err := TakeFromContext(ctx, OperationBudget("mutation")); err != nil || !res.Allowed {
+		t.Fatalf("expected first mutation to be allowed; %v", err)
+	}
+	if res, err := TakeFromContext(ctx, OperationBudget("mutation")); err != nil || res.Allowed {
+		t.Fatalf("expected second mutation to be limited; %v", err)
+	}
+	if res, err := TakeFromContext(ctx, OperationBudget("query")); err != nil || !res.Allowed {
+		t.Fatalf("expected query to be allowed; %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+package ratelimit
+
+import (
+	"ftm-explorer/internal/types"
+)
+
+// IStore represents a storage of rate limit token buckets.
+type IStore interface {
+	// Take takes a token from the bucket identified by the given key.
+	Take(string, types.RateLimit) (types.RateLimitResult, error)
+}
@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"fmt"
+	"ftm-explorer/internal/types"
+)
+
+// Budget represents a kind of requests sharing the same rate limit.
+type Budget string
+
+const (
+	// BudgetQuery is the budget of GraphQL queries.
+	BudgetQuery Budget = "query"
+	// BudgetMutation is the budget of GraphQL mutations.
+	BudgetMutation Budget = "mutation"
+	// BudgetSubscription is the budget of websocket subscriptions.
+	BudgetSubscription Budget = "subscription"
+)
+
+// Limiter represents a rate limiter with separate budgets for different kinds of requests.
+// Each client has its own token bucket per budget.
+type Limiter struct {
+	store  IStore
+	limits map[Budget]types.RateLimit
+}
+
+// NewLimiter creates a new rate limiter using the given store and budget limits.
+// Budgets without limit, or with zero rate or burst, are not limited.
+func NewLimiter(store IStore, limits map[Budget]types.RateLimit) *Limiter {
+	return &Limiter{
+		store:  store,
+		limits: limits,
+	}
+}
+
+// Take takes a token from the bucket of the given client in the given budget.
+func (l *Limiter) Take(budget Budget, client string) (types.RateLimitResult, error) {
+	limit, ok := l.limits[budget]
+	if !ok || limit.Rate <= 0 || limit.Burst <= 0 {
+		return types.RateLimitResult{Allowed: true}, nil
+	}
+
+	return l.store.Take(fmt.Sprintf("%s:%s", budget, client), limit)
+}
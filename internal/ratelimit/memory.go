@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"ftm-explorer/internal/types"
+	"math"
+	"sync"
+	"time"
+)
+
+// kMemoryStoreSweepInterval represents how often idle buckets are removed from the memory store.
+const kMemoryStoreSweepInterval = time.Minute
+
+// bucket represents a token bucket.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   types.RateLimit
+}
+
+// MemoryStore represents an in-memory storage of token buckets.
+// It is suitable for a single instance deployment. The store is thread-safe.
+type MemoryStore struct {
+	mtx       sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates a new in-memory token buckets storage.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Take takes a token from the bucket identified by the given key.
+func (ms *MemoryStore) Take(key string, limit types.RateLimit) (types.RateLimitResult, error) {
+	ms.mtx.Lock()
+	defer ms.mtx.Unlock()
+
+	now := ms.now()
+	ms.sweep(now)
+
+	// new buckets start full
+	b, ok := ms.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		ms.buckets[key] = b
+	}
+	b.limit = limit
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return types.RateLimitResult{
+			Allowed:    false,
+			RetryAfter: time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second)),
+		}, nil
+	}
+
+	b.tokens--
+	return types.RateLimitResult{Allowed: true}, nil
+}
+
+// sweep removes buckets which are full again, they are equal to new buckets.
+// The caller is responsible for holding the lock.
+func (ms *MemoryStore) sweep(now time.Time) {
+	if now.Sub(ms.lastSweep) < kMemoryStoreSweepInterval {
+		return
+	}
+	ms.lastSweep = now
+
+	for key, b := range ms.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(ms.buckets, key)
+		}
+	}
+}
+
+// refill adds tokens accumulated since the last update of the bucket.
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+		b.updated = now
+	}
+}
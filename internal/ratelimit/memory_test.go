@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"ftm-explorer/internal/types"
+	"testing"
+	"time"
+)
+
+// Test that tokens are taken from the bucket and refilled over time.
+func TestMemoryStore_Take(t *testing.T) {
+	ms, now := createMemoryStore(t)
+	limit := types.RateLimit{Rate: 2, Burst: 3}
+
+	// the bucket starts full
+	for i := 0; i < 3; i++ {
+		if res, _ := ms.Take("client", limit); !res.Allowed {
+			t.Fatalf("expected token %d to be taken", i)
+		}
+	}
+
+	// the bucket is empty, next token is available in half a second
+	res, err := ms.Take("client", limit)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Allowed {
+		t.Fatalf("expected token to not be taken")
+	}
+	if res.RetryAfter != 500*time.Millisecond {
+		t.Errorf("expected retry after 500ms, got %v", res.RetryAfter)
+	}
+
+	// other clients have their own buckets
+	if res, _ := ms.Take("other", limit); !res.Allowed {
+		t.Errorf("expected token of other client to be taken")
+	}
+
+	// the bucket is refilled over time
+	*now = now.Add(500 * time.Millisecond)
+	if res, _ := ms.Take("client", limit); !res.Allowed {
+		t.Errorf("expected refilled token to be taken")
+	}
+	if res, _ := ms.Take("client", limit); res.Allowed {
+		t.Errorf("expected token to not be taken")
+	}
+}
+
+// Test that full buckets are removed from the store.
+func TestMemoryStore_Sweep(t *testing.T) {
+	ms, now := createMemoryStore(t)
+	limit := types.RateLimit{Rate: 1, Burst: 10}
+
+	_, _ = ms.Take("idle", limit)
+	*now = now.Add(kMemoryStoreSweepInterval - time.Second)
+	_, _ = ms.Take("active", limit)
+
+	// the idle bucket is full again, the active bucket is not
+	*now = now.Add(time.Second)
+	_, _ = ms.Take("active", limit)
+
+	if _, ok := ms.buckets["idle"]; ok {
+		t.Errorf("expected idle bucket to be removed")
+	}
+	if _, ok := ms.buckets["active"]; !ok {
+		t.Errorf("expected active bucket to be kept")
+	}
+}
+
+// Test that the limiter keeps separate budgets and skips unlimited budgets.
+func TestLimiter_Take(t *testing.T) {
+	ms, _ := createMemoryStore(t)
+	limiter := NewLimiter(ms, map[Budget]types.RateLimit{
+		BudgetQuery:    {Rate: 1, Burst: 1},
+		BudgetMutation: {Rate: 1, Burst: 1},
+	})
+
+	if res, _ := limiter.Take(BudgetQuery, "client"); !res.Allowed {
+		t.Errorf("expected query to be allowed")
+	}
+	if res, _ := limiter.Take(BudgetQuery, "client"); res.Allowed {
+		t.Errorf("expected query to be limited")
+	}
+	if res, _ := limiter.Take(BudgetMutation, "client"); !res.Allowed {
+		t.Errorf("expected mutation to be allowed")
+	}
+	for i := 0; i < 10; i++ {
+		if res, _ := limiter.Take(BudgetSubscription, "client"); !res.Allowed {
+			t.Errorf("expected unlimited subscription to be allowed")
+		}
+	}
+}
+
+// createMemoryStore creates a memory store with a controllable clock.
+func createMemoryStore(t *testing.T) (*MemoryStore, *time.Time) {
+	t.Helper()
+	now := time.Unix(1_700_000_000, 0)
+	ms := NewMemoryStore()
+	ms.lastSweep = now
+	ms.now = func() time.Time { return now }
+	return ms, &now
+}
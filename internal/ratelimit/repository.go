@@ -0,0 +1,23 @@
+package ratelimit
+
+import (
+	"ftm-explorer/internal/repository"
+	"ftm-explorer/internal/types"
+)
+
+// RepositoryStore represents a storage of token buckets persisted by the repository.
+// Buckets are shared by all instances using the same database, so it is suitable
+// for a multi-instance deployment.
+type RepositoryStore struct {
+	repo repository.IRepository
+}
+
+// NewRepositoryStore creates a new token buckets storage backed by the given repository.
+func NewRepositoryStore(repo repository.IRepository) *RepositoryStore {
+	return &RepositoryStore{repo: repo}
+}
+
+// Take takes a token from the bucket identified by the given key.
+func (rs *RepositoryStore) Take(key string, limit types.RateLimit) (types.RateLimitResult, error) {
+	return rs.repo.TakeRateLimitToken(key, limit)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"ftm-explorer/internal/types"
+)
+
+// UseApiKey returns the active api key with the given hash and tracks its usage.
+// It returns nil if there is no such key or the key has been revoked.
+func (r *Repository) UseApiKey(hash string) (*types.ApiKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.UseApiKey(ctx, hash)
+}
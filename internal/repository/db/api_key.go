@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ftm-explorer/internal/types"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// kCoApiKey is the name of the api key collection.
+	kCoApiKey = "api_key"
+
+	// kFiApiKeyPk is the name of the primary key of the api key collection.
+	kFiApiKeyPk = "_id"
+
+	// kFiApiKeyName is the name of the key holder name field.
+	kFiApiKeyName = "name"
+
+	// kFiApiKeyHash is the name of the key hash field.
+	kFiApiKeyHash = "hash"
+
+	// kFiApiKeyLastUsedAt is the name of the last used at field.
+	kFiApiKeyLastUsedAt = "last_used_at"
+
+	// kFiApiKeyUsage is the name of the usage counter field.
+	kFiApiKeyUsage = "usage"
+
+	// kFiApiKeyRevokedAt is the name of the revoked at field.
+	kFiApiKeyRevokedAt = "revoked_at"
+)
+
+// AddApiKey adds a new api key to the database.
+func (db *MongoDb) AddApiKey(ctx context.Context, key *types.ApiKey) error {
+	if key == nil {
+		return fmt.Errorf("can not add empty api key")
+	}
+
+	// try to do the insert
+	key.Id = primitive.NewObjectID()
+	if _, err := db.apiKeyCollection().InsertOne(ctx, key); err != nil {
+		key.Id = primitive.NilObjectID
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("active api key %s already exists", key.Name)
+		}
+		db.log.Critical(err)
+		return err
+	}
+
+	db.log.Debugf("api key added. name: %s", key.Name)
+	return nil
+}
+
+// UseApiKey returns the active api key with the given hash and tracks its usage.
+// It returns nil if there is no such key or the key has been revoked.
+func (db *MongoDb) UseApiKey(ctx context.Context, hash string) (*types.ApiKey, error) {
+	var key types.ApiKey
+
+	filter := bson.M{
+		kFiApiKeyHash:      hash,
+		kFiApiKeyRevokedAt: nil,
+	}
+	update := bson.M{
+		"$inc": bson.M{kFiApiKeyUsage: 1},
+		"$set": bson.M{kFiApiKeyLastUsedAt: time.Now().Unix()},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := db.apiKeyCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		db.log.Criticalf("failed to use api key. err: %v", err)
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// ApiKeys returns all api keys including the revoked ones.
+func (db *MongoDb) ApiKeys(ctx context.Context) ([]types.ApiKey, error) {
+	opts := options.Find().SetSort(bson.D{{kFiApiKeyPk, 1}})
+	cursor, err := db.apiKeyCollection().Find(ctx, bson.M{}, opts)
+	if err != nil {
+		db.log.Criticalf("failed to get api keys. err: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Criticalf("failed to close cursor for api keys. err: %v", err)
+		}
+	}()
+
+	keys := make([]types.ApiKey, 0)
+	if err := cursor.All(ctx, &keys); err != nil {
+		db.log.Criticalf("failed to decode api keys. err: %v", err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// RevokeApiKey revokes the active api key of the given key holder.
+func (db *MongoDb) RevokeApiKey(ctx context.Context, name string) error {
+	filter := bson.M{
+		kFiApiKeyName:      name,
+		kFiApiKeyRevokedAt: nil,
+	}
+	update := bson.M{"$set": bson.M{kFiApiKeyRevokedAt: time.Now().Unix()}}
+
+	res, err := db.apiKeyCollection().UpdateOne(ctx, filter, update)
+	if err != nil {
+		db.log.Critical(err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("no active api key found with name: %s", name)
+	}
+
+	db.log.Debugf("api key revoked. name: %s", name)
+	return nil
+}
+
+// initApiKeyCollection initializes the api key collection with indexes.
+func (db *MongoDb) initApiKeyCollection() {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+
+	// key hashes are unique, names are unique among active keys, so a revoked key can be issued again
+	activeKeys := bson.M{kFiApiKeyRevokedAt: bson.M{"$type": "null"}}
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: kFiApiKeyName, Value: 1}}, Options: options.Index().SetUnique(true).SetPartialFilterExpression(activeKeys)})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: kFiApiKeyHash, Value: 1}}, Options: options.Index().SetUnique(true)})
+
+	// create indexes
+	ctx, cancel := context.WithTimeout(context.Background(), kMongoDefaultTimeout)
+	defer cancel()
+	if _, err := db.apiKeyCollection().Indexes().CreateMany(ctx, ix); err != nil {
+		db.log.Panicf("can not create indexes for api key collection; %v", err)
+	}
+
+	db.log.Debugf("api key collection initialized")
+}
+
+// apiKeyCollection returns the api key collection.
+func (db *MongoDb) apiKeyCollection() *mongo.Collection {
+	return db.db.Collection(kCoApiKey)
+}
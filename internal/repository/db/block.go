@@ -26,6 +26,9 @@ const (
 
 	// kFiBlockTimestamp is the name of the block timestamp field.
 	kFiBlockTimestamp = "timestamp"
+
+	// kFiBlockEpoch is the name of the block epoch field.
+	kFiBlockEpoch = "epoch"
 )
 
 // TrxCountAggByTimestamp returns aggregation of transactions in given time range.
@@ -83,6 +86,7 @@ func (db *MongoDb) AddBlock(ctx context.Context, block *types.Block) error {
 	// try to do the insert
 	if _, err := db.blockCollection().InsertOne(ctx, &db_types.Block{
 		Number:    int64(block.Number),
+		Epoch:     int64(block.Epoch),
 		TxsCount:  int32(len(block.Transactions)),
 		GasUsed:   int64(block.GasUsed),
 		Timestamp: int64(block.Timestamp),
@@ -121,6 +125,9 @@ func (db *MongoDb) initBlockCollection() {
 	// index the timestamp
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: kFiBlockTimestamp, Value: 1}}})
 
+	// index the epoch
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: kFiBlockEpoch, Value: 1}, {Key: kFiBlockTimestamp, Value: -1}}})
+
 	// create indexes
 	ctx, cancel := context.WithTimeout(context.Background(), kMongoDefaultTimeout)
 	defer cancel()
@@ -9,9 +9,11 @@ import (
 	db_types "ftm-explorer/internal/repository/db/types"
 	types "ftm-explorer/internal/types"
 	reflect "reflect"
+	time "time"
 
 	common "github.com/ethereum/go-ethereum/common"
 	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MockDatabase is a mock of IDatabase interface.
@@ -51,6 +53,20 @@ func (mr *MockDatabaseMockRecorder) AddAccounts(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccounts", reflect.TypeOf((*MockDatabase)(nil).AddAccounts), arg0, arg1, arg2)
 }
 
+// AddApiKey mocks base method.
+func (m *MockDatabase) AddApiKey(arg0 context.Context, arg1 *types.ApiKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddApiKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddApiKey indicates an expected call of AddApiKey.
+func (mr *MockDatabaseMockRecorder) AddApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApiKey", reflect.TypeOf((*MockDatabase)(nil).AddApiKey), arg0, arg1)
+}
+
 // AddBlock mocks base method.
 func (m *MockDatabase) AddBlock(arg0 context.Context, arg1 *types.Block) error {
 	m.ctrl.T.Helper()
@@ -65,6 +81,76 @@ func (mr *MockDatabaseMockRecorder) AddBlock(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlock", reflect.TypeOf((*MockDatabase)(nil).AddBlock), arg0, arg1)
 }
 
+// AddEpochStats mocks base method.
+func (m *MockDatabase) AddEpochStats(arg0 context.Context, arg1 *types.EpochStats) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEpochStats", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEpochStats indicates an expected call of AddEpochStats.
+func (mr *MockDatabaseMockRecorder) AddEpochStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEpochStats", reflect.TypeOf((*MockDatabase)(nil).AddEpochStats), arg0, arg1)
+}
+
+// AddGasUsage mocks base method.
+func (m *MockDatabase) AddGasUsage(arg0 context.Context, arg1 int64, arg2 []types.GasUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGasUsage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGasUsage indicates an expected call of AddGasUsage.
+func (mr *MockDatabaseMockRecorder) AddGasUsage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGasUsage", reflect.TypeOf((*MockDatabase)(nil).AddGasUsage), arg0, arg1, arg2)
+}
+
+// AddMazeChallenge mocks base method.
+func (m *MockDatabase) AddMazeChallenge(arg0 context.Context, arg1 *types.MazeChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMazeChallenge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMazeChallenge indicates an expected call of AddMazeChallenge.
+func (mr *MockDatabaseMockRecorder) AddMazeChallenge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMazeChallenge", reflect.TypeOf((*MockDatabase)(nil).AddMazeChallenge), arg0, arg1)
+}
+
+// AddSiweNonce mocks base method.
+func (m *MockDatabase) AddSiweNonce(arg0 context.Context, arg1 *types.SiweNonce) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSiweNonce", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSiweNonce indicates an expected call of AddSiweNonce.
+func (mr *MockDatabaseMockRecorder) AddSiweNonce(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSiweNonce", reflect.TypeOf((*MockDatabase)(nil).AddSiweNonce), arg0, arg1)
+}
+
+// AddSiweSession mocks base method.
+func (m *MockDatabase) AddSiweSession(arg0 context.Context, arg1 *types.SiweSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSiweSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSiweSession indicates an expected call of AddSiweSession.
+func (mr *MockDatabaseMockRecorder) AddSiweSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSiweSession", reflect.TypeOf((*MockDatabase)(nil).AddSiweSession), arg0, arg1)
+}
+
 // AddTimeToFinality mocks base method.
 func (m *MockDatabase) AddTimeToFinality(arg0 context.Context, arg1 *types.Ttf) error {
 	m.ctrl.T.Helper()
@@ -107,6 +193,21 @@ func (mr *MockDatabaseMockRecorder) AddTransactions(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransactions", reflect.TypeOf((*MockDatabase)(nil).AddTransactions), arg0, arg1)
 }
 
+// ApiKeys mocks base method.
+func (m *MockDatabase) ApiKeys(arg0 context.Context) ([]types.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApiKeys", arg0)
+	ret0, _ := ret[0].([]types.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApiKeys indicates an expected call of ApiKeys.
+func (mr *MockDatabaseMockRecorder) ApiKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApiKeys", reflect.TypeOf((*MockDatabase)(nil).ApiKeys), arg0)
+}
+
 // Block mocks base method.
 func (m *MockDatabase) Block(arg0 context.Context, arg1 uint64) (*db_types.Block, error) {
 	m.ctrl.T.Helper()
@@ -134,6 +235,36 @@ func (mr *MockDatabaseMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDatabase)(nil).Close))
 }
 
+// EpochStats mocks base method.
+func (m *MockDatabase) EpochStats(arg0 context.Context, arg1 *uint64, arg2 int64) ([]types.EpochStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EpochStats", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.EpochStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EpochStats indicates an expected call of EpochStats.
+func (mr *MockDatabaseMockRecorder) EpochStats(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EpochStats", reflect.TypeOf((*MockDatabase)(nil).EpochStats), arg0, arg1, arg2)
+}
+
+// EpochStatsFromBlocks mocks base method.
+func (m *MockDatabase) EpochStatsFromBlocks(arg0 context.Context, arg1 uint64) (*types.EpochStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EpochStatsFromBlocks", arg0, arg1)
+	ret0, _ := ret[0].(*types.EpochStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EpochStatsFromBlocks indicates an expected call of EpochStatsFromBlocks.
+func (mr *MockDatabaseMockRecorder) EpochStatsFromBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EpochStatsFromBlocks", reflect.TypeOf((*MockDatabase)(nil).EpochStatsFromBlocks), arg0, arg1)
+}
+
 // GasUsedAggByTimestamp mocks base method.
 func (m *MockDatabase) GasUsedAggByTimestamp(arg0 context.Context, arg1 uint64, arg2, arg3 uint) ([]types.HexUintTick, error) {
 	m.ctrl.T.Helper()
@@ -181,7 +312,7 @@ func (mr *MockDatabaseMockRecorder) LastTransactionsWhereAddress(arg0, arg1, arg
 // LatestClaimedTokensRequests mocks base method.
 func (m *MockDatabase) LatestClaimedTokensRequests(arg0 context.Context, arg1 string, arg2 uint64) ([]types.TokensRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLatestClaimedTokensRequests", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "LatestClaimedTokensRequests", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]types.TokensRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -190,7 +321,7 @@ func (m *MockDatabase) LatestClaimedTokensRequests(arg0 context.Context, arg1 st
 // LatestClaimedTokensRequests indicates an expected call of LatestClaimedTokensRequests.
 func (mr *MockDatabaseMockRecorder) LatestClaimedTokensRequests(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestClaimedTokensRequests", reflect.TypeOf((*MockDatabase)(nil).LatestClaimedTokensRequests), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestClaimedTokensRequests", reflect.TypeOf((*MockDatabase)(nil).LatestClaimedTokensRequests), arg0, arg1, arg2)
 }
 
 // LatestUnclaimedTokensRequest mocks base method.
@@ -223,6 +354,93 @@ func (mr *MockDatabaseMockRecorder) NumberOfAccoutns(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumberOfAccoutns", reflect.TypeOf((*MockDatabase)(nil).NumberOfAccoutns), arg0)
 }
 
+// PendingTokensRequests mocks base method.
+func (m *MockDatabase) PendingTokensRequests(arg0 context.Context) ([]types.TokensRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingTokensRequests", arg0)
+	ret0, _ := ret[0].([]types.TokensRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingTokensRequests indicates an expected call of PendingTokensRequests.
+func (mr *MockDatabaseMockRecorder) PendingTokensRequests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingTokensRequests", reflect.TypeOf((*MockDatabase)(nil).PendingTokensRequests), arg0)
+}
+
+// ReceiverTokensRequestClaims mocks base method.
+func (m *MockDatabase) ReceiverTokensRequestClaims(arg0 context.Context, arg1 common.Address, arg2 int64) ([]types.TokensRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiverTokensRequestClaims", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.TokensRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiverTokensRequestClaims indicates an expected call of ReceiverTokensRequestClaims.
+func (mr *MockDatabaseMockRecorder) ReceiverTokensRequestClaims(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiverTokensRequestClaims", reflect.TypeOf((*MockDatabase)(nil).ReceiverTokensRequestClaims), arg0, arg1, arg2)
+}
+
+// ReplaceTokensRequestTxHash mocks base method.
+func (m *MockDatabase) ReplaceTokensRequestTxHash(arg0 context.Context, arg1, arg2 common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTokensRequestTxHash", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTokensRequestTxHash indicates an expected call of ReplaceTokensRequestTxHash.
+func (mr *MockDatabaseMockRecorder) ReplaceTokensRequestTxHash(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTokensRequestTxHash", reflect.TypeOf((*MockDatabase)(nil).ReplaceTokensRequestTxHash), arg0, arg1, arg2)
+}
+
+// ResetTokensRequests mocks base method.
+func (m *MockDatabase) ResetTokensRequests(arg0 context.Context, arg1 string, arg2 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetTokensRequests", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetTokensRequests indicates an expected call of ResetTokensRequests.
+func (mr *MockDatabaseMockRecorder) ResetTokensRequests(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTokensRequests", reflect.TypeOf((*MockDatabase)(nil).ResetTokensRequests), arg0, arg1, arg2)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockDatabase) RevokeApiKey(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockDatabaseMockRecorder) RevokeApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockDatabase)(nil).RevokeApiKey), arg0, arg1)
+}
+
+// ShrinkGasUsage mocks base method.
+func (m *MockDatabase) ShrinkGasUsage(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShrinkGasUsage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShrinkGasUsage indicates an expected call of ShrinkGasUsage.
+func (mr *MockDatabaseMockRecorder) ShrinkGasUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShrinkGasUsage", reflect.TypeOf((*MockDatabase)(nil).ShrinkGasUsage), arg0, arg1)
+}
+
 // ShrinkTransactions mocks base method.
 func (m *MockDatabase) ShrinkTransactions(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -251,6 +469,96 @@ func (mr *MockDatabaseMockRecorder) ShrinkTtf(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShrinkTtf", reflect.TypeOf((*MockDatabase)(nil).ShrinkTtf), arg0, arg1)
 }
 
+// SiweSession mocks base method.
+func (m *MockDatabase) SiweSession(arg0 context.Context, arg1 string) (*types.SiweSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SiweSession", arg0, arg1)
+	ret0, _ := ret[0].(*types.SiweSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SiweSession indicates an expected call of SiweSession.
+func (mr *MockDatabaseMockRecorder) SiweSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SiweSession", reflect.TypeOf((*MockDatabase)(nil).SiweSession), arg0, arg1)
+}
+
+// TakeRateLimitToken mocks base method.
+func (m *MockDatabase) TakeRateLimitToken(arg0 context.Context, arg1 string, arg2 types.RateLimit) (types.RateLimitResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeRateLimitToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.RateLimitResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeRateLimitToken indicates an expected call of TakeRateLimitToken.
+func (mr *MockDatabaseMockRecorder) TakeRateLimitToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeRateLimitToken", reflect.TypeOf((*MockDatabase)(nil).TakeRateLimitToken), arg0, arg1, arg2)
+}
+
+// TokensRequest mocks base method.
+func (m *MockDatabase) TokensRequest(arg0 context.Context, arg1 primitive.ObjectID) (*types.TokensRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokensRequest", arg0, arg1)
+	ret0, _ := ret[0].(*types.TokensRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokensRequest indicates an expected call of TokensRequest.
+func (mr *MockDatabaseMockRecorder) TokensRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokensRequest", reflect.TypeOf((*MockDatabase)(nil).TokensRequest), arg0, arg1)
+}
+
+// TokensRequestClaimTimes mocks base method.
+func (m *MockDatabase) TokensRequestClaimTimes(arg0 context.Context, arg1, arg2 *common.Address, arg3 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokensRequestClaimTimes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokensRequestClaimTimes indicates an expected call of TokensRequestClaimTimes.
+func (mr *MockDatabaseMockRecorder) TokensRequestClaimTimes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokensRequestClaimTimes", reflect.TypeOf((*MockDatabase)(nil).TokensRequestClaimTimes), arg0, arg1, arg2, arg3)
+}
+
+// TokensRequestClaims mocks base method.
+func (m *MockDatabase) TokensRequestClaims(arg0 context.Context, arg1 string, arg2 int64) ([]types.TokensRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokensRequestClaims", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.TokensRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokensRequestClaims indicates an expected call of TokensRequestClaims.
+func (mr *MockDatabaseMockRecorder) TokensRequestClaims(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokensRequestClaims", reflect.TypeOf((*MockDatabase)(nil).TokensRequestClaims), arg0, arg1, arg2)
+}
+
+// TopGasConsumers mocks base method.
+func (m *MockDatabase) TopGasConsumers(arg0 context.Context, arg1, arg2, arg3 int64) ([]types.GasUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopGasConsumers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]types.GasUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopGasConsumers indicates an expected call of TopGasConsumers.
+func (mr *MockDatabaseMockRecorder) TopGasConsumers(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopGasConsumers", reflect.TypeOf((*MockDatabase)(nil).TopGasConsumers), arg0, arg1, arg2, arg3)
+}
+
 // TrxCount mocks base method.
 func (m *MockDatabase) TrxCount(arg0 context.Context) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -309,3 +617,63 @@ func (mr *MockDatabaseMockRecorder) UpdateTokensRequest(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTokensRequest", reflect.TypeOf((*MockDatabase)(nil).UpdateTokensRequest), arg0, arg1)
 }
+
+// UseApiKey mocks base method.
+func (m *MockDatabase) UseApiKey(arg0 context.Context, arg1 string) (*types.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseApiKey", arg0, arg1)
+	ret0, _ := ret[0].(*types.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseApiKey indicates an expected call of UseApiKey.
+func (mr *MockDatabaseMockRecorder) UseApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseApiKey", reflect.TypeOf((*MockDatabase)(nil).UseApiKey), arg0, arg1)
+}
+
+// UseMazeChallenge mocks base method.
+func (m *MockDatabase) UseMazeChallenge(arg0 context.Context, arg1 string, arg2 common.Address) (*types.MazeChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseMazeChallenge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.MazeChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseMazeChallenge indicates an expected call of UseMazeChallenge.
+func (mr *MockDatabaseMockRecorder) UseMazeChallenge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseMazeChallenge", reflect.TypeOf((*MockDatabase)(nil).UseMazeChallenge), arg0, arg1, arg2)
+}
+
+// UsePowStamp mocks base method.
+func (m *MockDatabase) UsePowStamp(arg0 context.Context, arg1 string, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePowStamp", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePowStamp indicates an expected call of UsePowStamp.
+func (mr *MockDatabaseMockRecorder) UsePowStamp(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePowStamp", reflect.TypeOf((*MockDatabase)(nil).UsePowStamp), arg0, arg1, arg2)
+}
+
+// UseSiweNonce mocks base method.
+func (m *MockDatabase) UseSiweNonce(arg0 context.Context, arg1 string, arg2 common.Address) (*types.SiweNonce, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseSiweNonce", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.SiweNonce)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseSiweNonce indicates an expected call of UseSiweNonce.
+func (mr *MockDatabaseMockRecorder) UseSiweNonce(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseSiweNonce", reflect.TypeOf((*MockDatabase)(nil).UseSiweNonce), arg0, arg1, arg2)
+}
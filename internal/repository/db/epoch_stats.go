@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	db_types "ftm-explorer/internal/repository/db/types"
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// kCoEpochStats is the name of the epoch statistics collection.
+	kCoEpochStats = "epoch_stats"
+
+	// kFiEpochStatsEpoch is the name of the epoch number field. It is also the primary key.
+	kFiEpochStatsEpoch = "_id"
+)
+
+// EpochStatsFromBlocks aggregates the stored blocks of the given epoch into the epoch statistics.
+// The average time to finality is calculated from the time to finality measured during the epoch.
+// It returns nil if there are no blocks of the epoch.
+func (db *MongoDb) EpochStatsFromBlocks(ctx context.Context, epoch uint64) (*types.EpochStats, error) {
+	// sum the blocks of the epoch
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.D{{kFiBlockEpoch, int64(epoch)}}}},
+		{{"$group", bson.D{
+			{"_id", nil},
+			{"blocks", bson.D{{"$sum", 1}}},
+			{"txsCount", bson.D{{"$sum", "$" + kFiBlockTxCount}}},
+			{"gasUsed", bson.D{{"$sum", "$" + kFiBlockGasUsed}}},
+			{"startTime", bson.D{{"$min", "$" + kFiBlockTimestamp}}},
+			{"endTime", bson.D{{"$max", "$" + kFiBlockTimestamp}}},
+		}}},
+	}
+	cursor, err := db.blockCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		db.log.Criticalf("failed to aggregate blocks of epoch %d. err: %v", epoch, err)
+		return nil, err
+	}
+	var results []db_types.EpochStats
+	if err := cursor.All(ctx, &results); err != nil {
+		db.log.Criticalf("failed to decode blocks aggregation of epoch %d. err: %v", epoch, err)
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	stats := results[0]
+	stats.Epoch = int64(epoch)
+
+	// the epoch starts with the last block of the previous epoch, if it is known
+	start := stats.StartTime
+	var prev db_types.Block
+	opts := options.FindOne().SetSort(bson.D{{Key: kFiBlockTimestamp, Value: -1}})
+	err = db.blockCollection().FindOne(ctx, bson.D{{Key: kFiBlockEpoch, Value: int64(epoch) - 1}}, opts).Decode(&prev)
+	switch {
+	case err == nil:
+		start = prev.Timestamp
+	case err != mongo.ErrNoDocuments:
+		db.log.Criticalf("failed to get last block of epoch %d. err: %v", epoch-1, err)
+		return nil, err
+	}
+	stats.Duration = stats.EndTime - start
+
+	// average the time to finality measured during the epoch
+	pipeline = mongo.Pipeline{
+		{{"$match", bson.D{
+			{kFiTimeToFinalityTimestamp, bson.D{{"$gt", start}, {"$lte", stats.EndTime}}},
+		}}},
+		{{"$group", bson.D{
+			{"_id", nil},
+			{"avgTtf", bson.D{{"$avg", "$" + kFiTimeToFinalityValue}}},
+		}}},
+	}
+	if cursor, err = db.timeToFinalityCollection().Aggregate(ctx, pipeline); err != nil {
+		db.log.Criticalf("failed to aggregate time to finality of epoch %d. err: %v", epoch, err)
+		return nil, err
+	}
+	var ttf []struct {
+		AvgTtf float64 `bson:"avgTtf"`
+	}
+	if err := cursor.All(ctx, &ttf); err != nil {
+		db.log.Criticalf("failed to decode time to finality of epoch %d. err: %v", epoch, err)
+		return nil, err
+	}
+	if len(ttf) > 0 {
+		stats.AvgTtf = ttf[0].AvgTtf
+	}
+
+	return toEpochStats(&stats), nil
+}
+
+// AddEpochStats adds or replaces the statistics of the sealed epoch.
+func (db *MongoDb) AddEpochStats(ctx context.Context, stats *types.EpochStats) error {
+	if stats == nil {
+		return fmt.Errorf("can not add empty epoch stats")
+	}
+
+	doc := db_types.EpochStats{
+		Epoch:        int64(stats.Epoch),
+		Blocks:       int64(stats.Blocks),
+		Transactions: int64(stats.Transactions),
+		GasUsed:      int64(stats.GasUsed),
+		StartTime:    int64(stats.StartTime),
+		EndTime:      int64(stats.EndTime),
+		Duration:     int64(stats.Duration),
+		AvgTtf:       stats.AvgTtf,
+	}
+	filter := bson.D{{Key: kFiEpochStatsEpoch, Value: doc.Epoch}}
+	if _, err := db.epochStatsCollection().ReplaceOne(ctx, filter, &doc, options.Replace().SetUpsert(true)); err != nil {
+		db.log.Criticalf("failed to add stats of epoch %d. err: %v", doc.Epoch, err)
+		return err
+	}
+
+	db.log.Debugf("stats of epoch %d added to database", doc.Epoch)
+	return nil
+}
+
+// EpochStats returns the statistics of the given number of sealed epochs ordered from the newest one.
+// If the before epoch is given, only the epochs older than it are returned.
+func (db *MongoDb) EpochStats(ctx context.Context, before *uint64, count int64) ([]types.EpochStats, error) {
+	filter := bson.D{}
+	if before != nil {
+		filter = bson.D{{Key: kFiEpochStatsEpoch, Value: bson.D{{Key: "$lt", Value: int64(*before)}}}}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: kFiEpochStatsEpoch, Value: -1}}).SetLimit(count)
+
+	cursor, err := db.epochStatsCollection().Find(ctx, filter, opts)
+	if err != nil {
+		db.log.Criticalf("failed to get epoch stats. err: %v", err)
+		return nil, err
+	}
+	var results []db_types.EpochStats
+	if err := cursor.All(ctx, &results); err != nil {
+		db.log.Criticalf("failed to decode epoch stats. err: %v", err)
+		return nil, err
+	}
+
+	stats := make([]types.EpochStats, len(results))
+	for i := range results {
+		stats[i] = *toEpochStats(&results[i])
+	}
+	return stats, nil
+}
+
+// epochStatsCollection returns the epoch statistics collection.
+func (db *MongoDb) epochStatsCollection() *mongo.Collection {
+	return db.db.Collection(kCoEpochStats)
+}
+
+// toEpochStats converts the database epoch statistics into the epoch statistics.
+func toEpochStats(stats *db_types.EpochStats) *types.EpochStats {
+	return &types.EpochStats{
+		Epoch:        hexutil.Uint64(stats.Epoch),
+		Blocks:       hexutil.Uint64(stats.Blocks),
+		Transactions: hexutil.Uint64(stats.Transactions),
+		GasUsed:      hexutil.Uint64(stats.GasUsed),
+		StartTime:    hexutil.Uint64(stats.StartTime),
+		EndTime:      hexutil.Uint64(stats.EndTime),
+		Duration:     hexutil.Uint64(stats.Duration),
+		AvgTtf:       stats.AvgTtf,
+	}
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// kCoGasUsage is the name of the gas usage collection.
+	kCoGasUsage = "gas_usage"
+
+	// kFiGasUsageBucket is the name of the time bucket field, the start of the bucket in unix seconds.
+	kFiGasUsageBucket = "bucket"
+
+	// kFiGasUsageContract is the name of the called contract field.
+	kFiGasUsageContract = "contract"
+
+	// kFiGasUsageSelector is the name of the called method selector field.
+	kFiGasUsageSelector = "selector"
+
+	// kFiGasUsageGas is the name of the gas used field.
+	kFiGasUsageGas = "gas"
+
+	// kFiGasUsageCalls is the name of the calls count field.
+	kFiGasUsageCalls = "calls"
+)
+
+// AddGasUsage adds the gas usage of the contract methods into the given time bucket.
+func (db *MongoDb) AddGasUsage(ctx context.Context, bucket int64, usage []types.GasUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, len(usage))
+	for i, u := range usage {
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.D{
+				{Key: kFiGasUsageBucket, Value: bucket},
+				{Key: kFiGasUsageContract, Value: u.Contract},
+				{Key: kFiGasUsageSelector, Value: u.Selector},
+			}).
+			SetUpdate(bson.M{"$inc": bson.M{
+				kFiGasUsageGas:   int64(u.GasUsed),
+				kFiGasUsageCalls: int64(u.Calls),
+			}}).
+			SetUpsert(true)
+	}
+
+	if _, err := db.gasUsageCollection().BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		db.log.Criticalf("failed to add gas usage. err: %v", err)
+		return err
+	}
+	return nil
+}
+
+// TopGasConsumers returns the contract methods which used the most gas in the time buckets
+// since the given time, exclusive, until the given time, inclusive.
+func (db *MongoDb) TopGasConsumers(ctx context.Context, from int64, to int64, limit int64) ([]types.GasUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.D{
+			{kFiGasUsageBucket, bson.D{{"$gt", from}, {"$lte", to}}},
+		}}},
+		{{"$group", bson.D{
+			{"_id", bson.D{
+				{kFiGasUsageContract, "$" + kFiGasUsageContract},
+				{kFiGasUsageSelector, "$" + kFiGasUsageSelector},
+			}},
+			{kFiGasUsageGas, bson.D{{"$sum", "$" + kFiGasUsageGas}}},
+			{kFiGasUsageCalls, bson.D{{"$sum", "$" + kFiGasUsageCalls}}},
+		}}},
+		{{"$sort", bson.D{{kFiGasUsageGas, -1}}}},
+		{{"$limit", limit}},
+	}
+
+	cursor, err := db.gasUsageCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		db.log.Criticalf("failed to aggregate gas usage. err: %v", err)
+		return nil, err
+	}
+
+	var results []struct {
+		Id struct {
+			Contract common.Address `bson:"contract"`
+			Selector string         `bson:"selector"`
+		} `bson:"_id"`
+		Gas   int64 `bson:"gas"`
+		Calls int64 `bson:"calls"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		db.log.Criticalf("failed to decode gas usage. err: %v", err)
+		return nil, err
+	}
+
+	usage := make([]types.GasUsage, len(results))
+	for i, r := range results {
+		usage[i] = types.GasUsage{
+			Contract: r.Id.Contract,
+			Selector: r.Id.Selector,
+			GasUsed:  uint64(r.Gas),
+			Calls:    uint64(r.Calls),
+		}
+	}
+	return usage, nil
+}
+
+// ShrinkGasUsage deletes the gas usage of the time buckets before the given time.
+func (db *MongoDb) ShrinkGasUsage(ctx context.Context, before int64) error {
+	if _, err := db.gasUsageCollection().DeleteMany(ctx, bson.M{kFiGasUsageBucket: bson.M{"$lt": before}}); err != nil {
+		db.log.Criticalf("failed to shrink gas usage. err: %v", err)
+		return err
+	}
+	return nil
+}
+
+// initGasUsageCollection initializes the gas usage collection with indexes.
+func (db *MongoDb) initGasUsageCollection() {
+	// a document per bucket and contract method
+	ix := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: kFiGasUsageBucket, Value: 1},
+			{Key: kFiGasUsageContract, Value: 1},
+			{Key: kFiGasUsageSelector, Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kMongoDefaultTimeout)
+	defer cancel()
+	if _, err := db.gasUsageCollection().Indexes().CreateOne(ctx, ix); err != nil {
+		db.log.Panicf("can not create indexes for gas usage collection; %v", err)
+	}
+
+	db.log.Debugf("gas usage collection initialized")
+}
+
+// gasUsageCollection returns the gas usage collection.
+func (db *MongoDb) gasUsageCollection() *mongo.Collection {
+	return db.db.Collection(kCoGasUsage)
+}
@@ -6,8 +6,10 @@ import (
 	"context"
 	"ftm-explorer/internal/repository/db/types"
 	"ftm-explorer/internal/types"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // IDatabase is the interface for database operations.
@@ -42,6 +44,27 @@ type IDatabase interface {
 	// LatestClaimedTokensRequests returns the latest claimed tokens requests for the given ip address.
 	LatestClaimedTokensRequests(context.Context, string, uint64) ([]types.TokensRequest, error)
 
+	// TokensRequest returns the tokens request with the given id.
+	TokensRequest(context.Context, primitive.ObjectID) (*types.TokensRequest, error)
+
+	// TokensRequestClaims returns the latest claims of the given ip address including the failed ones.
+	TokensRequestClaims(context.Context, string, int64) ([]types.TokensRequest, error)
+
+	// TokensRequestClaimTimes returns the times of claims of the given token and receiver made since the given time.
+	TokensRequestClaimTimes(context.Context, *common.Address, *common.Address, int64) ([]int64, error)
+
+	// PendingTokensRequests returns the claims waiting for their transactions to be mined.
+	PendingTokensRequests(context.Context) ([]types.TokensRequest, error)
+
+	// ReplaceTokensRequestTxHash updates the transaction hash of the claims whose transaction has been replaced.
+	ReplaceTokensRequestTxHash(context.Context, common.Hash, common.Hash) error
+
+	// ReceiverTokensRequestClaims returns the latest claims to the given receiver including the failed ones.
+	ReceiverTokensRequestClaims(context.Context, common.Address, int64) ([]types.TokensRequest, error)
+
+	// ResetTokensRequests marks the mined claims of the given ip address made since the given time as reset.
+	ResetTokensRequests(context.Context, string, int64) (int64, error)
+
 	// AddTimeToFinality adds the given time to finality.
 	AddTimeToFinality(context.Context, *types.Ttf) error
 
@@ -61,12 +84,68 @@ type IDatabase interface {
 	// ShrinkTtf shrinks the time to finality collection. It will persist the given number of ttfs.
 	ShrinkTtf(context.Context, int64) error
 
+	// AddGasUsage adds the gas usage of the contract methods into the given time bucket.
+	AddGasUsage(context.Context, int64, []types.GasUsage) error
+
+	// TopGasConsumers returns the contract methods which used the most gas in the given time range.
+	TopGasConsumers(context.Context, int64, int64, int64) ([]types.GasUsage, error)
+
+	// ShrinkGasUsage deletes the gas usage of the time buckets before the given time.
+	ShrinkGasUsage(context.Context, int64) error
+
+	// EpochStatsFromBlocks aggregates the stored blocks of the given epoch, nil if there are no blocks of the epoch.
+	EpochStatsFromBlocks(context.Context, uint64) (*types.EpochStats, error)
+
+	// AddEpochStats adds or replaces the statistics of the sealed epoch.
+	AddEpochStats(context.Context, *types.EpochStats) error
+
+	// EpochStats returns the statistics of the given number of sealed epochs ordered from the newest one,
+	// older than the given epoch if it is not nil.
+	EpochStats(context.Context, *uint64, int64) ([]types.EpochStats, error)
+
 	// AddAccounts adds accounts to the database.
 	AddAccounts(context.Context, []common.Address, int64) error
 
 	// NumberOfAccoutns returns the number of accounts in the database.
 	NumberOfAccoutns(context.Context) (uint64, error)
 
+	// TakeRateLimitToken takes a token from the rate limit bucket identified by the given key.
+	TakeRateLimitToken(context.Context, string, types.RateLimit) (types.RateLimitResult, error)
+
+	// AddApiKey adds a new api key to the database.
+	AddApiKey(context.Context, *types.ApiKey) error
+
+	// UseApiKey returns the active api key with the given hash and tracks its usage.
+	UseApiKey(context.Context, string) (*types.ApiKey, error)
+
+	// ApiKeys returns all api keys including the revoked ones.
+	ApiKeys(context.Context) ([]types.ApiKey, error)
+
+	// RevokeApiKey revokes the active api key of the given key holder.
+	RevokeApiKey(context.Context, string) error
+
+	// AddSiweNonce adds a nonce of an issued sign-in message to the database.
+	AddSiweNonce(context.Context, *types.SiweNonce) error
+
+	// UseSiweNonce marks the given nonce issued for the given address as used.
+	UseSiweNonce(context.Context, string, common.Address) (*types.SiweNonce, error)
+
+	// AddSiweSession adds a new sign-in session to the database.
+	AddSiweSession(context.Context, *types.SiweSession) error
+
+	// SiweSession returns the active sign-in session with the given token hash.
+	SiweSession(context.Context, string) (*types.SiweSession, error)
+
+	// AddMazeChallenge adds an issued maze challenge to the database.
+	AddMazeChallenge(context.Context, *types.MazeChallenge) error
+
+	// UseMazeChallenge marks the maze challenge with the given phrase as used by the given player.
+	UseMazeChallenge(context.Context, string, common.Address) (*types.MazeChallenge, error)
+
+	// UsePowStamp marks the given proof-of-work stamp as spent until the given time.
+	// It returns false if the stamp has already been spent.
+	UsePowStamp(context.Context, string, time.Time) (bool, error)
+
 	// Close terminates the database connection.
 	Close()
 }
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ftm-explorer/internal/types"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// kCoMazeChallenge is the name of the maze challenge collection.
+	kCoMazeChallenge = "maze_challenge"
+
+	// kFiMazeChallengePk is the name of the primary key of the maze challenge collection.
+	kFiMazeChallengePk = "_id"
+
+	// kFiMazeChallengeExpiresAt is the name of the expiration time field.
+	kFiMazeChallengeExpiresAt = "expires_at"
+
+	// kFiMazeChallengeUsedAt is the name of the used at field.
+	kFiMazeChallengeUsedAt = "used_at"
+
+	// kFiMazeChallengeUsedBy is the name of the used by field.
+	kFiMazeChallengeUsedBy = "used_by"
+)
+
+// AddMazeChallenge adds an issued maze challenge to the database.
+func (db *MongoDb) AddMazeChallenge(ctx context.Context, mc *types.MazeChallenge) error {
+	if mc == nil {
+		return fmt.Errorf("can not add empty maze challenge")
+	}
+	if _, err := db.mazeChallengeCollection().InsertOne(ctx, mc); err != nil {
+		db.log.Critical(err)
+		return err
+	}
+	return nil
+}
+
+// UseMazeChallenge marks the maze challenge with the given phrase as used by the given player.
+// It returns nil if the challenge is unknown, expired or has already been used.
+func (db *MongoDb) UseMazeChallenge(ctx context.Context, phrase string, player common.Address) (*types.MazeChallenge, error) {
+	var mc types.MazeChallenge
+
+	now := time.Now()
+	filter := bson.M{
+		kFiMazeChallengePk:        phrase,
+		kFiMazeChallengeUsedAt:    nil,
+		kFiMazeChallengeExpiresAt: bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{
+		kFiMazeChallengeUsedAt: now,
+		kFiMazeChallengeUsedBy: player,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := db.mazeChallengeCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&mc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		db.log.Criticalf("failed to use maze challenge: %s. err: %v", phrase, err)
+		return nil, err
+	}
+
+	return &mc, nil
+}
+
+// initMazeChallengeCollection initializes the maze challenge collection with indexes.
+func (db *MongoDb) initMazeChallengeCollection() {
+	// expire challenges at the given time
+	ix := mongo.IndexModel{
+		Keys:    bson.D{{Key: kFiMazeChallengeExpiresAt, Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kMongoDefaultTimeout)
+	defer cancel()
+	if _, err := db.mazeChallengeCollection().Indexes().CreateOne(ctx, ix); err != nil {
+		db.log.Panicf("can not create indexes for maze challenge collection; %v", err)
+	}
+
+	db.log.Debugf("maze challenge collection initialized")
+}
+
+// mazeChallengeCollection returns the maze challenge collection.
+func (db *MongoDb) mazeChallengeCollection() *mongo.Collection {
+	return db.db.Collection(kCoMazeChallenge)
+}
@@ -46,6 +46,13 @@ func NewMongoDb(cfg *config.MongoDb, log logger.ILogger) (*MongoDb, error) {
 	db.initBlockCollection()
 	db.initTransactionCollection()
 	db.initTtfCollection()
+	db.initRateLimitCollection()
+	db.initApiKeyCollection()
+	db.initSiweCollections()
+	db.initMazeChallengeCollection()
+	db.initPowStampCollection()
+	db.initTokensRequestCollection()
+	db.initGasUsageCollection()
 
 	return db, nil
 }
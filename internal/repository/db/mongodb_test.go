@@ -14,6 +14,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Test connection to MongoDB
@@ -426,6 +427,98 @@ func TestMongoDb_UpdateTokensRequest(t *testing.T) {
 	}
 }
 
+// Test tracking of claim transactions.
+func TestMongoDb_TokensRequestClaims(t *testing.T) {
+	db := startMongoDb(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	// add pending, mined and failed claims
+	now := time.Now().Unix()
+	receiver := common.Address{0x01}
+	hashes := []common.Hash{{0x01}, {0x02}, {0x03}}
+	statuses := []string{types.TokensRequestPending, types.TokensRequestMined, types.TokensRequestFailed}
+	ids := make([]primitive.ObjectID, len(hashes))
+	for i := range hashes {
+		tr := types.TokensRequest{
+			IpAddress: "192.168.0.1",
+			Phrase:    "test phrase",
+			Receiver:  &receiver,
+			ClaimedAt: &now,
+			TxHash:    &hashes[i],
+			Status:    statuses[i],
+		}
+		if err := db.AddTokensRequest(ctx, &tr); err != nil {
+			t.Fatalf("failed to add tokens request: %v", err)
+		}
+		ids[i] = tr.Id
+	}
+
+	// failed claims do not count into the limit, but are listed
+	counted, err := db.LatestClaimedTokensRequests(ctx, "192.168.0.1", uint64(now))
+	if err != nil || len(counted) != 2 {
+		t.Fatalf("expected 2 counted claims, got %d; %v", len(counted), err)
+	}
+	claims, err := db.TokensRequestClaims(ctx, "192.168.0.1", 10)
+	if err != nil || len(claims) != 3 || claims[0].Id != ids[2] {
+		t.Fatalf("expected 3 claims, the latest first, got %d; %v", len(claims), err)
+	}
+
+	// claim times of the native token skip failed claims
+	times, err := db.TokensRequestClaimTimes(ctx, &receiver, nil, now)
+	if err != nil || len(times) != 2 {
+		t.Fatalf("expected 2 claim times of the receiver, got %d; %v", len(times), err)
+	}
+	erc20 := common.Address{0x02}
+	if times, err := db.TokensRequestClaimTimes(ctx, nil, &erc20, now); err != nil || len(times) != 0 {
+		t.Fatalf("expected no claim times of the erc20 token, got %d; %v", len(times), err)
+	}
+
+	// only the pending claim is watched
+	pending, err := db.PendingTokensRequests(ctx)
+	if err != nil || len(pending) != 1 || pending[0].Id != ids[0] {
+		t.Fatalf("expected single pending claim, got %d; %v", len(pending), err)
+	}
+
+	// replaced transaction is updated
+	replacement := common.Hash{0x04}
+	if err := db.ReplaceTokensRequestTxHash(ctx, hashes[0], replacement); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	tr, err := db.TokensRequest(ctx, ids[0])
+	if err != nil || tr == nil || *tr.TxHash != replacement {
+		t.Fatalf("expected replaced transaction, got %+v; %v", tr, err)
+	}
+
+	// unknown request is not found
+	if tr, err := db.TokensRequest(ctx, primitive.NewObjectID()); err != nil || tr != nil {
+		t.Fatalf("expected nil for unknown request, got %+v; %v", tr, err)
+	}
+
+	// claims are listed by receiver
+	if claims, err := db.ReceiverTokensRequestClaims(ctx, receiver, 2); err != nil || len(claims) != 2 || claims[0].Id != ids[2] {
+		t.Fatalf("expected 2 latest claims of the receiver, got %d; %v", len(claims), err)
+	}
+
+	// reset marks only the mined claim, which does not count into the limit of the ip address anymore
+	reset, err := db.ResetTokensRequests(ctx, "192.168.0.1", now-60)
+	if err != nil || reset != 1 {
+		t.Fatalf("expected 1 reset claim, got %d; %v", reset, err)
+	}
+	if counted, err := db.LatestClaimedTokensRequests(ctx, "192.168.0.1", uint64(now)); err != nil || len(counted) != 1 || counted[0].Id != ids[0] {
+		t.Fatalf("expected only the pending claim counted, got %d; %v", len(counted), err)
+	}
+
+	// reset claims are kept for the budgets and the history
+	if times, err := db.TokensRequestClaimTimes(ctx, &receiver, nil, now); err != nil || len(times) != 2 {
+		t.Fatalf("expected 2 claim times after reset, got %d; %v", len(times), err)
+	}
+	if claims, err := db.TokensRequestClaims(ctx, "192.168.0.1", 10); err != nil || len(claims) != 3 || claims[1].Status != types.TokensRequestReset {
+		t.Fatalf("expected the reset claim listed, got %d; %v", len(claims), err)
+	}
+}
+
 // Test adding time to finality.
 func TestMongoDb_AddTimeToFinality(t *testing.T) {
 	db := startMongoDb(t)
@@ -645,6 +738,327 @@ func TestMongoDb_AddAndGetNumberOfAccounts(t *testing.T) {
 	}
 }
 
+// Test taking rate limit tokens from MongoDB.
+func TestMongoDb_TakeRateLimitToken(t *testing.T) {
+	db := startMongoDb(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	// the bucket starts full, slow refill rate keeps it empty during the test
+	limit := types.RateLimit{Rate: 0.001, Burst: 2}
+	for i := 0; i < 2; i++ {
+		res, err := db.TakeRateLimitToken(ctx, "client", limit)
+		if err != nil {
+			t.Fatalf("failed to take rate limit token: %v", err)
+		}
+		if !res.Allowed {
+			t.Fatalf("expected token %d to be taken", i)
+		}
+	}
+
+	// the bucket is empty
+	res, err := db.TakeRateLimitToken(ctx, "client", limit)
+	if err != nil {
+		t.Fatalf("failed to take rate limit token: %v", err)
+	}
+	if res.Allowed {
+		t.Fatalf("expected token to not be taken")
+	}
+	if res.RetryAfter <= 0 || res.RetryAfter > 1000*time.Second {
+		t.Fatalf("expected retry after in (0, 1000s], got %v", res.RetryAfter)
+	}
+
+	// other keys have their own buckets
+	res, err = db.TakeRateLimitToken(ctx, "other", limit)
+	if err != nil {
+		t.Fatalf("failed to take rate limit token: %v", err)
+	}
+	if !res.Allowed {
+		t.Fatalf("expected token of other key to be taken")
+	}
+}
+
+// Test that api keys can be used, listed and revoked.
+func TestMongoDb_ApiKeys(t *testing.T) {
+	db := startMongoDb(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	key := types.ApiKey{Name: "admin", Hash: "hash", Roles: []string{"admin"}, CreatedAt: time.Now().Unix()}
+	if err := db.AddApiKey(ctx, &key); err != nil {
+		t.Fatalf("failed to add api key: %v", err)
+	}
+
+	// names are unique
+	if err := db.AddApiKey(ctx, &types.ApiKey{Name: "admin", Hash: "other"}); err == nil {
+		t.Fatalf("expected error when adding duplicate api key")
+	}
+
+	// usage is tracked
+	for i := 1; i <= 2; i++ {
+		used, err := db.UseApiKey(ctx, "hash")
+		if err != nil {
+			t.Fatalf("failed to use api key: %v", err)
+		}
+		if used == nil || used.Name != "admin" || used.Usage != uint64(i) || used.LastUsedAt == nil {
+			t.Fatalf("unexpected api key: %+v", used)
+		}
+	}
+
+	// unknown keys are not found
+	if used, err := db.UseApiKey(ctx, "unknown"); err != nil || used != nil {
+		t.Fatalf("expected unknown api key to not be found, got %+v; %v", used, err)
+	}
+
+	// revoked keys can not be used
+	if err := db.RevokeApiKey(ctx, "admin"); err != nil {
+		t.Fatalf("failed to revoke api key: %v", err)
+	}
+	if used, err := db.UseApiKey(ctx, "hash"); err != nil || used != nil {
+		t.Fatalf("expected revoked api key to not be found, got %+v; %v", used, err)
+	}
+	if err := db.RevokeApiKey(ctx, "admin"); err == nil {
+		t.Fatalf("expected error when revoking revoked api key")
+	}
+
+	// the name of a revoked key can be issued again
+	if err := db.AddApiKey(ctx, &types.ApiKey{Name: "admin", Hash: "other", CreatedAt: time.Now().Unix()}); err != nil {
+		t.Fatalf("failed to add api key with the name of a revoked key: %v", err)
+	}
+	if used, err := db.UseApiKey(ctx, "other"); err != nil || used == nil || used.Name != "admin" {
+		t.Fatalf("expected reissued api key to be found, got %+v; %v", used, err)
+	}
+
+	// revoked keys are listed
+	keys, err := db.ApiKeys(ctx)
+	if err != nil {
+		t.Fatalf("failed to list api keys: %v", err)
+	}
+	if len(keys) != 2 || keys[0].RevokedAt == nil || keys[0].Usage != 2 || keys[1].RevokedAt != nil {
+		t.Fatalf("unexpected api keys: %+v", keys)
+	}
+}
+
+// Test that sign-in nonces can be used once and sessions expire.
+func TestMongoDb_Siwe(t *testing.T) {
+	db := startMongoDb(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	address := common.Address{0x01}
+	nonces := []types.SiweNonce{
+		{Nonce: "active", Address: address, ExpiresAt: time.Now().Add(time.Minute)},
+		{Nonce: "expired", Address: address, ExpiresAt: time.Now().Add(-time.Minute)},
+	}
+	for i := range nonces {
+		if err := db.AddSiweNonce(ctx, &nonces[i]); err != nil {
+			t.Fatalf("failed to add nonce: %v", err)
+		}
+	}
+
+	// nonce is bound to the address
+	if sn, err := db.UseSiweNonce(ctx, "active", common.Address{0x02}); err != nil || sn != nil {
+		t.Fatalf("expected nonce of other address to not be used, got %+v; %v", sn, err)
+	}
+	// nonce can be used once
+	if sn, err := db.UseSiweNonce(ctx, "active", address); err != nil || sn == nil || sn.UsedAt == nil {
+		t.Fatalf("expected nonce to be used, got %+v; %v", sn, err)
+	}
+	if sn, err := db.UseSiweNonce(ctx, "active", address); err != nil || sn != nil {
+		t.Fatalf("expected used nonce to not be used again, got %+v; %v", sn, err)
+	}
+	// expired nonce can not be used
+	if sn, err := db.UseSiweNonce(ctx, "expired", address); err != nil || sn != nil {
+		t.Fatalf("expected expired nonce to not be used, got %+v; %v", sn, err)
+	}
+
+	// active sessions are returned
+	sessions := []types.SiweSession{
+		{TokenHash: "active", Address: address, ChainId: 4002, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)},
+		{TokenHash: "expired", Address: address, ChainId: 4002, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(-time.Hour)},
+	}
+	for i := range sessions {
+		if err := db.AddSiweSession(ctx, &sessions[i]); err != nil {
+			t.Fatalf("failed to add session: %v", err)
+		}
+	}
+	if ss, err := db.SiweSession(ctx, "active"); err != nil || ss == nil || ss.Address != address || ss.ChainId != 4002 {
+		t.Fatalf("expected active session, got %+v; %v", ss, err)
+	}
+	if ss, err := db.SiweSession(ctx, "expired"); err != nil || ss != nil {
+		t.Fatalf("expected expired session to not be returned, got %+v; %v", ss, err)
+	}
+}
+
+// Test that maze challenges can be used once and only until they expire.
+func TestMongoDb_MazeChallenge(t *testing.T) {
+	db := startMongoDb(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	challenges := []types.MazeChallenge{
+		{Phrase: "active", ExpiresAt: time.Now().Add(time.Minute)},
+		{Phrase: "expired", ExpiresAt: time.Now().Add(-time.Minute)},
+	}
+	for i := range challenges {
+		if err := db.AddMazeChallenge(ctx, &challenges[i]); err != nil {
+			t.Fatalf("failed to add maze challenge: %v", err)
+		}
+	}
+
+	player := common.Address{0x01}
+	if mc, err := db.UseMazeChallenge(ctx, "active", player); err != nil || mc == nil || mc.UsedBy == nil || *mc.UsedBy != player {
+		t.Fatalf("expected challenge to be used, got %+v; %v", mc, err)
+	}
+	if mc, err := db.UseMazeChallenge(ctx, "active", player); err != nil || mc != nil {
+		t.Fatalf("expected used challenge to not be used again, got %+v; %v", mc, err)
+	}
+	if mc, err := db.UseMazeChallenge(ctx, "expired", player); err != nil || mc != nil {
+		t.Fatalf("expected expired challenge to not be used, got %+v; %v", mc, err)
+	}
+	if mc, err := db.UseMazeChallenge(ctx, "unknown", player); err != nil || mc != nil {
+		t.Fatalf("expected unknown challenge to not be used, got %+v; %v", mc, err)
+	}
+}
+
+// Test that proof-of-work stamps can be spent only once.
+func TestMongoDb_PowStamp(t *testing.T) {
+	db := startMongoDb(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	expiresAt := time.Now().Add(time.Minute)
+	if ok, err := db.UsePowStamp(ctx, "stamp", expiresAt); err != nil || !ok {
+		t.Fatalf("expected stamp to be spent; %v", err)
+	}
+	if ok, err := db.UsePowStamp(ctx, "stamp", expiresAt); err != nil || ok {
+		t.Fatalf("expected spent stamp to not be spent again; %v", err)
+	}
+	if ok, err := db.UsePowStamp(ctx, "other", expiresAt); err != nil || !ok {
+		t.Fatalf("expected other stamp to be spent; %v", err)
+	}
+}
+
+func TestMongoDb_GasUsage(t *testing.T) {
+	db := startMongoDb(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	contract, other := common.Address{0x01}, common.Address{0x02}
+	buckets := map[int64][]types.GasUsage{
+		60: {
+			{Contract: contract, Selector: "0xa9059cbb", GasUsed: 50_000, Calls: 1},
+			{Contract: other, Selector: "0x", GasUsed: 21_000, Calls: 1},
+		},
+		120: {
+			{Contract: contract, Selector: "0xa9059cbb", GasUsed: 30_000, Calls: 1},
+			{Contract: contract, Selector: "0x40c10f19", GasUsed: 70_000, Calls: 2},
+		},
+	}
+	for bucket, usage := range buckets {
+		if err := db.AddGasUsage(ctx, bucket, usage); err != nil {
+			t.Fatalf("failed to add gas usage: %v", err)
+		}
+	}
+	// the usage of the same method is accumulated in the bucket
+	if err := db.AddGasUsage(ctx, 120, buckets[120][:1]); err != nil {
+		t.Fatalf("failed to add gas usage: %v", err)
+	}
+
+	top, err := db.TopGasConsumers(ctx, 0, 120, 2)
+	if err != nil {
+		t.Fatalf("failed to get top gas consumers: %v", err)
+	}
+	if len(top) != 2 || top[0].Selector != "0xa9059cbb" || top[0].GasUsed != 110_000 || top[0].Calls != 3 || top[1].Selector != "0x40c10f19" {
+		t.Fatalf("unexpected top gas consumers: %+v", top)
+	}
+
+	// only the buckets within the range are aggregated
+	if top, err := db.TopGasConsumers(ctx, 60, 120, 10); err != nil || len(top) != 2 || top[0].GasUsed != 70_000 {
+		t.Fatalf("unexpected top gas consumers of the last bucket: %+v; %v", top, err)
+	}
+
+	// shrinking removes the old buckets
+	if err := db.ShrinkGasUsage(ctx, 120); err != nil {
+		t.Fatalf("failed to shrink gas usage: %v", err)
+	}
+	if top, err := db.TopGasConsumers(ctx, 0, 120, 10); err != nil || len(top) != 2 {
+		t.Fatalf("expected only the last bucket, got %+v; %v", top, err)
+	}
+}
+
+// Test that the epoch stats are aggregated from the blocks and the time to finality of the epoch.
+func TestMongoDb_EpochStats(t *testing.T) {
+	db := startMongoDb(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	// epoch 10 ends at 1_000, epoch 11 has three blocks
+	blocks := []types.Block{
+		{Number: 1, Epoch: 10, Timestamp: 1_000, GasUsed: 10},
+		{Number: 2, Epoch: 11, Timestamp: 1_002, GasUsed: 20, Transactions: []common.Hash{{0x01}}},
+		{Number: 3, Epoch: 11, Timestamp: 1_004, GasUsed: 30, Transactions: []common.Hash{{0x02}, {0x03}}},
+		{Number: 4, Epoch: 11, Timestamp: 1_005, GasUsed: 40},
+	}
+	for i := range blocks {
+		if err := db.AddBlock(ctx, &blocks[i]); err != nil {
+			t.Fatalf("failed to add block: %v", err)
+		}
+	}
+	for _, ttf := range []types.Ttf{{Timestamp: 999, Value: 9}, {Timestamp: 1_003, Value: 1}, {Timestamp: 1_005, Value: 2}} {
+		if err := db.AddTimeToFinality(ctx, &ttf); err != nil {
+			t.Fatalf("failed to add ttf: %v", err)
+		}
+	}
+
+	stats, err := db.EpochStatsFromBlocks(ctx, 11)
+	if err != nil {
+		t.Fatalf("failed to aggregate epoch stats: %v", err)
+	}
+	if stats == nil || stats.Blocks != 3 || stats.Transactions != 3 || stats.GasUsed != 90 ||
+		stats.StartTime != 1_002 || stats.EndTime != 1_005 || stats.Duration != 5 || stats.AvgTtf != 1.5 {
+		t.Fatalf("unexpected epoch stats: %+v", stats)
+	}
+
+	// the duration of the first known epoch is measured from its first block
+	if stats, err := db.EpochStatsFromBlocks(ctx, 10); err != nil || stats == nil || stats.Duration != 0 || stats.AvgTtf != 0 {
+		t.Fatalf("unexpected stats of epoch 10: %+v; %v", stats, err)
+	}
+
+	// there are no blocks of epoch 12
+	if stats, err := db.EpochStatsFromBlocks(ctx, 12); err != nil || stats != nil {
+		t.Fatalf("expected no stats of epoch 12, got %+v; %v", stats, err)
+	}
+
+	// stats are listed from the newest epoch and replaced when added again
+	for _, number := range []uint64{9, 10, 11} {
+		if err := db.AddEpochStats(ctx, &types.EpochStats{Epoch: hexutil.Uint64(number), Blocks: 1}); err != nil {
+			t.Fatalf("failed to add epoch stats: %v", err)
+		}
+	}
+	if err := db.AddEpochStats(ctx, stats); err != nil {
+		t.Fatalf("failed to replace epoch stats: %v", err)
+	}
+	list, err := db.EpochStats(ctx, nil, 2)
+	if err != nil {
+		t.Fatalf("failed to get epoch stats: %v", err)
+	}
+	if len(list) != 2 || list[0].Epoch != 11 || list[0].Blocks != 3 || list[1].Epoch != 10 {
+		t.Fatalf("unexpected epoch stats: %+v", list)
+	}
+	before := uint64(10)
+	if list, err := db.EpochStats(ctx, &before, 2); err != nil || len(list) != 1 || list[0].Epoch != 9 {
+		t.Fatalf("unexpected epoch stats before epoch 10: %+v; %v", list, err)
+	}
+}
+
 // startMongoDb starts MongoDB in a Docker container and returns the MongoDb instance.
 func startMongoDb(t *testing.T) *MongoDb {
 	t.Helper()
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// kCoPowStamp is the name of the spent proof-of-work stamp collection.
+	kCoPowStamp = "pow_stamp"
+
+	// kFiPowStampPk is the name of the primary key of the proof-of-work stamp collection.
+	kFiPowStampPk = "_id"
+
+	// kFiPowStampExpiresAt is the name of the expiration time field.
+	kFiPowStampExpiresAt = "expires_at"
+)
+
+// UsePowStamp marks the given proof-of-work stamp as spent until the given expiration time.
+// It returns false if the stamp has already been spent.
+func (db *MongoDb) UsePowStamp(ctx context.Context, stamp string, expiresAt time.Time) (bool, error) {
+	doc := bson.M{
+		kFiPowStampPk:        stamp,
+		kFiPowStampExpiresAt: expiresAt,
+	}
+	if _, err := db.powStampCollection().InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		db.log.Criticalf("failed to use proof-of-work stamp: %s. err: %v", stamp, err)
+		return false, err
+	}
+	return true, nil
+}
+
+// initPowStampCollection initializes the proof-of-work stamp collection with indexes.
+func (db *MongoDb) initPowStampCollection() {
+	// forget stamps once they expire, expired stamps are rejected anyway
+	ix := mongo.IndexModel{
+		Keys:    bson.D{{Key: kFiPowStampExpiresAt, Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kMongoDefaultTimeout)
+	defer cancel()
+	if _, err := db.powStampCollection().Indexes().CreateOne(ctx, ix); err != nil {
+		db.log.Panicf("can not create indexes for proof-of-work stamp collection; %v", err)
+	}
+
+	db.log.Debugf("proof-of-work stamp collection initialized")
+}
+
+// powStampCollection returns the proof-of-work stamp collection.
+func (db *MongoDb) powStampCollection() *mongo.Collection {
+	return db.db.Collection(kCoPowStamp)
+}
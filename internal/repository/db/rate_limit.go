@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"ftm-explorer/internal/types"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// kCoRateLimit is the name of the rate limit collection.
+	kCoRateLimit = "rate_limit"
+
+	// kFiRateLimitPk is the name of the primary key of the rate limit collection.
+	kFiRateLimitPk = "_id"
+
+	// kFiRateLimitTokens is the name of the available tokens field.
+	kFiRateLimitTokens = "tokens"
+
+	// kFiRateLimitUpdated is the name of the last update time field.
+	kFiRateLimitUpdated = "updated"
+
+	// kFiRateLimitAllowed is the name of the field indicating the last token was taken.
+	kFiRateLimitAllowed = "allowed"
+
+	// kFiRateLimitExpireAt is the name of the expiration time field.
+	kFiRateLimitExpireAt = "expire_at"
+)
+
+// TakeRateLimitToken takes a token from the rate limit bucket identified by the given key.
+// The bucket is refilled and the token is taken atomically using the database server time,
+// so the bucket can be shared by multiple instances. Full buckets expire automatically.
+func (db *MongoDb) TakeRateLimitToken(ctx context.Context, key string, limit types.RateLimit) (types.RateLimitResult, error) {
+	burst := float64(limit.Burst)
+
+	// seconds elapsed since the last update of the bucket
+	elapsed := bson.D{{"$divide", bson.A{
+		bson.D{{"$subtract", bson.A{"$$NOW", bson.D{{"$ifNull", bson.A{"$" + kFiRateLimitUpdated, "$$NOW"}}}}}},
+		1000,
+	}}}
+
+	// refill the bucket, new buckets start full
+	refilled := bson.D{{"$min", bson.A{
+		burst,
+		bson.D{{"$add", bson.A{
+			bson.D{{"$ifNull", bson.A{"$" + kFiRateLimitTokens, burst}}},
+			bson.D{{"$multiply", bson.A{elapsed, limit.Rate}}},
+		}}},
+	}}}
+
+	// the bucket expires once it would be full again
+	ttl := time.Duration(burst / limit.Rate * float64(time.Second))
+
+	pipeline := mongo.Pipeline{
+		{{"$set", bson.D{
+			{kFiRateLimitTokens, refilled},
+			{kFiRateLimitUpdated, "$$NOW"},
+		}}},
+		{{"$set", bson.D{
+			{kFiRateLimitAllowed, bson.D{{"$gte", bson.A{"$" + kFiRateLimitTokens, 1}}}},
+		}}},
+		{{"$set", bson.D{
+			{kFiRateLimitTokens, bson.D{{"$cond", bson.A{
+				"$" + kFiRateLimitAllowed,
+				bson.D{{"$subtract", bson.A{"$" + kFiRateLimitTokens, 1}}},
+				"$" + kFiRateLimitTokens,
+			}}}},
+			{kFiRateLimitExpireAt, bson.D{{"$add", bson.A{"$$NOW", ttl.Milliseconds()}}}},
+		}}},
+	}
+
+	var bucket struct {
+		Tokens  float64 `bson:"tokens"`
+		Allowed bool    `bson:"allowed"`
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := db.rateLimitCollection().FindOneAndUpdate(ctx, bson.M{kFiRateLimitPk: key}, pipeline, opts).Decode(&bucket)
+	if err != nil {
+		db.log.Errorf("failed to take rate limit token for key: %s. err: %v", key, err)
+		return types.RateLimitResult{}, err
+	}
+
+	if bucket.Allowed {
+		return types.RateLimitResult{Allowed: true}, nil
+	}
+	return types.RateLimitResult{
+		Allowed:    false,
+		RetryAfter: time.Duration((1 - bucket.Tokens) / limit.Rate * float64(time.Second)),
+	}, nil
+}
+
+// initRateLimitCollection initializes the rate limit collection with indexes.
+func (db *MongoDb) initRateLimitCollection() {
+	// expire buckets at the given time
+	ix := mongo.IndexModel{
+		Keys:    bson.D{{Key: kFiRateLimitExpireAt, Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kMongoDefaultTimeout)
+	defer cancel()
+	if _, err := db.rateLimitCollection().Indexes().CreateOne(ctx, ix); err != nil {
+		db.log.Panicf("can not create indexes for rate limit collection; %v", err)
+	}
+
+	db.log.Debugf("rate limit collection initialized")
+}
+
+// rateLimitCollection returns the rate limit collection.
+func (db *MongoDb) rateLimitCollection() *mongo.Collection {
+	return db.db.Collection(kCoRateLimit)
+}
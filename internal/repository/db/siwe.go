@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ftm-explorer/internal/types"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// kCoSiweNonce is the name of the sign-in nonce collection.
+	kCoSiweNonce = "siwe_nonce"
+
+	// kCoSiweSession is the name of the sign-in session collection.
+	kCoSiweSession = "siwe_session"
+
+	// kFiSiwePk is the name of the primary key of the sign-in collections.
+	kFiSiwePk = "_id"
+
+	// kFiSiweAddress is the name of the address field.
+	kFiSiweAddress = "address"
+
+	// kFiSiweExpiresAt is the name of the expiration time field.
+	kFiSiweExpiresAt = "expires_at"
+
+	// kFiSiweNonceUsedAt is the name of the nonce used at field.
+	kFiSiweNonceUsedAt = "used_at"
+)
+
+// AddSiweNonce adds a nonce of an issued sign-in message to the database.
+func (db *MongoDb) AddSiweNonce(ctx context.Context, nonce *types.SiweNonce) error {
+	if nonce == nil {
+		return fmt.Errorf("can not add empty sign-in nonce")
+	}
+	if _, err := db.siweNonceCollection().InsertOne(ctx, nonce); err != nil {
+		db.log.Critical(err)
+		return err
+	}
+	return nil
+}
+
+// UseSiweNonce marks the given nonce issued for the given address as used.
+// It returns nil if the nonce is unknown, expired or has already been used.
+func (db *MongoDb) UseSiweNonce(ctx context.Context, nonce string, address common.Address) (*types.SiweNonce, error) {
+	var sn types.SiweNonce
+
+	now := time.Now()
+	filter := bson.M{
+		kFiSiwePk:          nonce,
+		kFiSiweAddress:     address,
+		kFiSiweNonceUsedAt: nil,
+		kFiSiweExpiresAt:   bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{kFiSiweNonceUsedAt: now}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := db.siweNonceCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&sn); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		db.log.Criticalf("failed to use sign-in nonce: %s. err: %v", nonce, err)
+		return nil, err
+	}
+
+	return &sn, nil
+}
+
+// AddSiweSession adds a new sign-in session to the database.
+func (db *MongoDb) AddSiweSession(ctx context.Context, session *types.SiweSession) error {
+	if session == nil {
+		return fmt.Errorf("can not add empty sign-in session")
+	}
+	if _, err := db.siweSessionCollection().InsertOne(ctx, session); err != nil {
+		db.log.Critical(err)
+		return err
+	}
+
+	db.log.Debugf("sign-in session added. address: %s", session.Address.Hex())
+	return nil
+}
+
+// SiweSession returns the active sign-in session with the given token hash.
+// It returns nil if there is no such session or the session has expired.
+func (db *MongoDb) SiweSession(ctx context.Context, tokenHash string) (*types.SiweSession, error) {
+	var session types.SiweSession
+
+	filter := bson.M{
+		kFiSiwePk:        tokenHash,
+		kFiSiweExpiresAt: bson.M{"$gt": time.Now()},
+	}
+	if err := db.siweSessionCollection().FindOne(ctx, filter).Decode(&session); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		db.log.Criticalf("failed to get sign-in session. err: %v", err)
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// initSiweCollections initializes the sign-in collections with indexes.
+func (db *MongoDb) initSiweCollections() {
+	// expire nonces and sessions at the given time
+	ix := mongo.IndexModel{
+		Keys:    bson.D{{Key: kFiSiweExpiresAt, Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kMongoDefaultTimeout)
+	defer cancel()
+	for _, col := range []*mongo.Collection{db.siweNonceCollection(), db.siweSessionCollection()} {
+		if _, err := col.Indexes().CreateOne(ctx, ix); err != nil {
+			db.log.Panicf("can not create indexes for %s collection; %v", col.Name(), err)
+		}
+	}
+
+	db.log.Debugf("sign-in collections initialized")
+}
+
+// siweNonceCollection returns the sign-in nonce collection.
+func (db *MongoDb) siweNonceCollection() *mongo.Collection {
+	return db.db.Collection(kCoSiweNonce)
+}
+
+// siweSessionCollection returns the sign-in session collection.
+func (db *MongoDb) siweSessionCollection() *mongo.Collection {
+	return db.db.Collection(kCoSiweSession)
+}
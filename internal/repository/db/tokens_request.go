@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"ftm-explorer/internal/types"
 
+	"github.com/ethereum/go-ethereum/common"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -30,6 +31,15 @@ const (
 
 	// kFiTokensRequestClaimedAt is the name of the claimed at field.
 	kFiTokensRequestClaimedAt = "claimed_at"
+
+	// kFiTokensRequestErc20 is the name of the erc20 token field.
+	kFiTokensRequestErc20 = "erc20"
+
+	// kFiTokensRequestTxHash is the name of the transaction hash field.
+	kFiTokensRequestTxHash = "tx_hash"
+
+	// kFiTokensRequestStatus is the name of the claim status field.
+	kFiTokensRequestStatus = "status"
 )
 
 // AddTokensRequest adds a new tokens request to the database.
@@ -67,6 +77,9 @@ func (db *MongoDb) UpdateTokensRequest(ctx context.Context, tr *types.TokensRequ
 			kFiTokensRequestPhrase:    tr.Phrase,
 			kFiTokensRequestReceiver:  tr.Receiver,
 			kFiTokensRequestClaimedAt: tr.ClaimedAt,
+			kFiTokensRequestErc20:     tr.Erc20,
+			kFiTokensRequestTxHash:    tr.TxHash,
+			kFiTokensRequestStatus:    tr.Status,
 		},
 	}
 
@@ -108,7 +121,7 @@ func (db *MongoDb) LatestUnclaimedTokensRequest(ctx context.Context, ipAddress s
 }
 
 // LatestClaimedTokensRequests returns the latest claimed tokens requests for the given ip address.
-// The from parameter is used to determine the starting point of the query.
+// The from parameter is used to determine the starting point of the query. Failed claims are skipped.
 func (db *MongoDb) LatestClaimedTokensRequests(ctx context.Context, ipAddress string, from uint64) ([]types.TokensRequest, error) {
 	var requests []types.TokensRequest
 
@@ -116,6 +129,7 @@ func (db *MongoDb) LatestClaimedTokensRequests(ctx context.Context, ipAddress st
 	filter := bson.M{
 		kFiTokensRequestIp:        ipAddress,
 		kFiTokensRequestClaimedAt: bson.M{"$gte": from},
+		kFiTokensRequestStatus:    bson.M{"$nin": bson.A{types.TokensRequestFailed, types.TokensRequestReset}},
 	}
 	cursor, err := db.tokensRequestCollection().Find(ctx, filter, opts)
 	if err != nil {
@@ -145,6 +159,134 @@ func (db *MongoDb) LatestClaimedTokensRequests(ctx context.Context, ipAddress st
 	return requests, nil
 }
 
+// TokensRequest returns the tokens request with the given id, or nil if it does not exist.
+func (db *MongoDb) TokensRequest(ctx context.Context, id primitive.ObjectID) (*types.TokensRequest, error) {
+	var tr types.TokensRequest
+	if err := db.tokensRequestCollection().FindOne(ctx, bson.M{kFiTokensRequestPk: id}).Decode(&tr); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		db.log.Criticalf("failed to get tokens request: %s. err: %v", id.Hex(), err)
+		return nil, err
+	}
+	return &tr, nil
+}
+
+// TokensRequestClaims returns the latest claims of the given ip address including the failed ones.
+func (db *MongoDb) TokensRequestClaims(ctx context.Context, ipAddress string, limit int64) ([]types.TokensRequest, error) {
+	opts := options.Find().SetSort(bson.D{{kFiTokensRequestPk, -1}}).SetLimit(limit)
+	return db.findTokensRequests(ctx, bson.M{
+		kFiTokensRequestIp:        ipAddress,
+		kFiTokensRequestClaimedAt: bson.M{"$ne": nil},
+	}, opts)
+}
+
+// ReceiverTokensRequestClaims returns the latest claims to the given receiver including the failed ones.
+func (db *MongoDb) ReceiverTokensRequestClaims(ctx context.Context, receiver common.Address, limit int64) ([]types.TokensRequest, error) {
+	opts := options.Find().SetSort(bson.D{{kFiTokensRequestPk, -1}}).SetLimit(limit)
+	return db.findTokensRequests(ctx, bson.M{kFiTokensRequestReceiver: receiver}, opts)
+}
+
+// ResetTokensRequests marks the mined claims of the given ip address made since the given time as reset,
+// so they do not count into the claims limit of the ip address anymore. The claims are kept for the faucet
+// budgets, the limits of the receiver and the history.
+func (db *MongoDb) ResetTokensRequests(ctx context.Context, ipAddress string, from int64) (int64, error) {
+	res, err := db.tokensRequestCollection().UpdateMany(ctx, bson.M{
+		kFiTokensRequestIp:        ipAddress,
+		kFiTokensRequestClaimedAt: bson.M{"$gte": from},
+		kFiTokensRequestStatus:    types.TokensRequestMined,
+	}, bson.M{"$set": bson.M{kFiTokensRequestStatus: types.TokensRequestReset}})
+	if err != nil {
+		db.log.Criticalf("failed to reset tokens requests of ip address: %s. err: %v", ipAddress, err)
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// TokensRequestClaimTimes returns the times of claims of the given token made since the given time,
+// the oldest first. The erc20 is nil for the native token, the receiver is nil for claims to any receiver.
+// Failed claims are skipped.
+func (db *MongoDb) TokensRequestClaimTimes(ctx context.Context, receiver *common.Address, erc20 *common.Address, from int64) ([]int64, error) {
+	filter := bson.M{
+		kFiTokensRequestClaimedAt: bson.M{"$gte": from},
+		kFiTokensRequestStatus:    bson.M{"$ne": types.TokensRequestFailed},
+		kFiTokensRequestErc20:     erc20,
+	}
+	if receiver != nil {
+		filter[kFiTokensRequestReceiver] = receiver
+	}
+	opts := options.Find().SetSort(bson.D{{kFiTokensRequestClaimedAt, 1}}).SetProjection(bson.M{kFiTokensRequestClaimedAt: 1})
+
+	requests, err := db.findTokensRequests(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	times := make([]int64, 0, len(requests))
+	for _, tr := range requests {
+		times = append(times, *tr.ClaimedAt)
+	}
+	return times, nil
+}
+
+// PendingTokensRequests returns the claims waiting for their transactions to be mined.
+func (db *MongoDb) PendingTokensRequests(ctx context.Context) ([]types.TokensRequest, error) {
+	opts := options.Find().SetSort(bson.D{{kFiTokensRequestPk, 1}})
+	return db.findTokensRequests(ctx, bson.M{kFiTokensRequestStatus: types.TokensRequestPending}, opts)
+}
+
+// ReplaceTokensRequestTxHash updates the transaction hash of the claims whose transaction has been replaced.
+// All the claims paid out in a batch by the transaction are updated.
+func (db *MongoDb) ReplaceTokensRequestTxHash(ctx context.Context, old common.Hash, new common.Hash) error {
+	filter := bson.M{kFiTokensRequestTxHash: old}
+	update := bson.M{"$set": bson.M{kFiTokensRequestTxHash: new}}
+	if _, err := db.tokensRequestCollection().UpdateMany(ctx, filter, update); err != nil {
+		db.log.Criticalf("failed to replace tokens request transaction: %s. err: %v", old.Hex(), err)
+		return err
+	}
+	return nil
+}
+
+// findTokensRequests returns the tokens requests matching the given filter.
+func (db *MongoDb) findTokensRequests(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]types.TokensRequest, error) {
+	cursor, err := db.tokensRequestCollection().Find(ctx, filter, opts)
+	if err != nil {
+		db.log.Criticalf("failed to get tokens requests. err: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Criticalf("failed to close cursor for tokens requests. err: %v", err)
+		}
+	}()
+
+	requests := make([]types.TokensRequest, 0)
+	if err := cursor.All(ctx, &requests); err != nil {
+		db.log.Criticalf("failed to decode tokens requests. err: %v", err)
+		return nil, err
+	}
+	return requests, nil
+}
+
+// initTokensRequestCollection initializes the tokens request collection with indexes.
+func (db *MongoDb) initTokensRequestCollection() {
+	ix := []mongo.IndexModel{
+		// pending claims are looked up by the claim watcher
+		{Keys: bson.D{{Key: kFiTokensRequestStatus, Value: 1}}},
+		// replaced transactions are looked up by hash
+		{Keys: bson.D{{Key: kFiTokensRequestTxHash, Value: 1}}, Options: options.Index().SetSparse(true)},
+		// claim limits and budgets are checked by claim time
+		{Keys: bson.D{{Key: kFiTokensRequestClaimedAt, Value: 1}}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kMongoDefaultTimeout)
+	defer cancel()
+	if _, err := db.tokensRequestCollection().Indexes().CreateMany(ctx, ix); err != nil {
+		db.log.Panicf("can not create indexes for tokens request collection; %v", err)
+	}
+
+	db.log.Debugf("tokens request collection initialized")
+}
+
 // blockCollection returns the tokens request collection.
 func (db *MongoDb) tokensRequestCollection() *mongo.Collection {
 	return db.db.Collection(kCoTokensRequest)
@@ -4,6 +4,7 @@ package db_types
 // We only need a few data, so we only define those fields.
 type Block struct {
 	Number    int64 `bson:"_id"`
+	Epoch     int64 `bson:"epoch"`
 	TxsCount  int32 `bson:"txsCount"`
 	GasUsed   int64 `bson:"gasUsed"`
 	Timestamp int64 `bson:"timestamp"`
@@ -0,0 +1,13 @@
+package db_types
+
+// EpochStats represents the statistics of a sealed epoch in database.
+type EpochStats struct {
+	Epoch        int64   `bson:"_id"`
+	Blocks       int64   `bson:"blocks"`
+	Transactions int64   `bson:"txsCount"`
+	GasUsed      int64   `bson:"gasUsed"`
+	StartTime    int64   `bson:"startTime"`
+	EndTime      int64   `bson:"endTime"`
+	Duration     int64   `bson:"duration"`
+	AvgTtf       float64 `bson:"avgTtf"`
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"ftm-explorer/internal/types"
+)
+
+// SealEpoch aggregates the stored blocks of the sealed epoch and persists the epoch statistics.
+// Nothing is persisted if there are no stored blocks of the epoch.
+func (r *Repository) SealEpoch(epoch uint64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+
+	stats, err := r.db.EpochStatsFromBlocks(ctx, epoch)
+	if err != nil || stats == nil {
+		return err
+	}
+	return r.db.AddEpochStats(ctx, stats)
+}
+
+// GetEpochStats returns the statistics of the given number of sealed epochs ordered from the newest one.
+// If the before epoch is given, only the epochs older than it are returned.
+func (r *Repository) GetEpochStats(before *uint64, count uint) ([]types.EpochStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+
+	return r.db.EpochStats(ctx, before, int64(count))
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Erc20Decimals returns the number of decimals of the erc20 token.
+func (r *Repository) Erc20Decimals(token common.Address) (uint8, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+	return r.rpc.Erc20Decimals(ctx, token)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ftm-explorer/internal/types"
+)
+
+// kGasUsageBucket is the resolution of the time buckets the gas usage is aggregated into.
+const kGasUsageBucket = types.AggResolutionMinute
+
+// AddGasUsage adds the gas usage of the contract methods at the given time.
+func (r *Repository) AddGasUsage(timestamp int64, usage []types.GasUsage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+
+	seconds := int64(kGasUsageBucket.ToDuration())
+	return r.db.AddGasUsage(ctx, (timestamp/seconds)*seconds, usage)
+}
+
+// GetTopGasConsumers returns the contract methods which used the most gas
+// in the given number of resolution periods until the latest observed block.
+// The periods are rounded to whole minutes, the resolution of the stored gas usage.
+func (r *Repository) GetTopGasConsumers(resolution types.AggResolution, window uint, limit uint) ([]types.GasUsage, error) {
+	duration := resolution.ToDuration()
+	if duration == 0 {
+		return nil, fmt.Errorf("invalid resolution value")
+	}
+	last := r.getLastBlockTimestamp(nil)
+	if last == nil {
+		return []types.GasUsage{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+
+	to := int64(*last)
+	from := to - int64(duration*window)
+	return r.db.TopGasConsumers(ctx, from, to, int64(limit))
+}
+
+// ShrinkGasUsage deletes the gas usage older than the given time.
+func (r *Repository) ShrinkGasUsage(before int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+
+	return r.db.ShrinkGasUsage(ctx, before)
+}
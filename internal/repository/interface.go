@@ -6,10 +6,13 @@ import (
 	db_types "ftm-explorer/internal/repository/db/types"
 	"ftm-explorer/internal/types"
 	"math/big"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth "github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type IRepository interface {
@@ -29,11 +32,41 @@ type IRepository interface {
 	GetNewHeadersChannel() <-chan *eth.Header
 
 	// GetTransactionByHash returns the transaction identified by hash.
+	// The transactions cache is consulted first.
 	GetTransactionByHash(common.Hash) (*types.Transaction, error)
 
+	// FetchTransactionByHash returns the transaction identified by hash.
+	// This method will fetch data from remote host and populate the transactions cache.
+	FetchTransactionByHash(common.Hash) (*types.Transaction, error)
+
+	// GetTransactionCacheStats returns usage statistics of the transactions cache.
+	GetTransactionCacheStats() types.CacheStats
+
 	// GetNumberOfValidators returns the number of validators.
 	GetNumberOfValidators() (uint64, error)
 
+	// GetValidators returns the validators of the latest sealed epoch.
+	GetValidators() ([]types.Validator, error)
+
+	// GetValidator returns the validator with the given id, nil if it does not exist.
+	GetValidator(uint64) (*types.Validator, error)
+
+	// GetEpoch returns the sealed epoch with the given number, or the latest sealed epoch if the number is nil.
+	GetEpoch(*uint64) (*types.Epoch, error)
+
+	// GetDelegations returns the delegations of the account to all the validators.
+	GetDelegations(common.Address) ([]types.Delegation, error)
+
+	// GetStakingRewards returns the rewards of all the delegations of the account.
+	GetStakingRewards(common.Address) (*types.StakingRewards, error)
+
+	// SealEpoch aggregates the stored blocks of the sealed epoch and persists the epoch statistics.
+	SealEpoch(uint64) error
+
+	// GetEpochStats returns the statistics of the given number of sealed epochs ordered from the newest one,
+	// older than the given epoch if it is not nil.
+	GetEpochStats(*uint64, uint) ([]types.EpochStats, error)
+
 	// GetTrxCountAggByTimestamp returns aggregation of transactions in given time range.
 	GetTrxCountAggByTimestamp(types.AggResolution, uint, *uint64) ([]types.HexUintTick, error)
 
@@ -111,15 +144,48 @@ type IRepository interface {
 	// GetLatestClaimedTokensRequests returns the latest claimed tokens requests for the given ip address.
 	GetLatestClaimedTokensRequests(string, uint64) ([]types.TokensRequest, error)
 
+	// GetTokensRequest returns the tokens request with the given id.
+	GetTokensRequest(primitive.ObjectID) (*types.TokensRequest, error)
+
+	// GetTokensRequestClaims returns the latest claims of the given ip address including the failed ones.
+	GetTokensRequestClaims(string, int64) ([]types.TokensRequest, error)
+
+	// GetTokensRequestClaimTimes returns the times of claims of the given token and receiver made since the given time.
+	GetTokensRequestClaimTimes(receiver *common.Address, erc20 *common.Address, from int64) ([]int64, error)
+
+	// GetPendingTokensRequests returns the claims waiting for their transactions to be mined.
+	GetPendingTokensRequests() ([]types.TokensRequest, error)
+
+	// ReplaceTokensRequestTxHash updates the transaction hash of the claims whose transaction has been replaced.
+	ReplaceTokensRequestTxHash(common.Hash, common.Hash) error
+
+	// TransactionReceipt returns the receipt of the transaction, or nil if it is not mined yet.
+	TransactionReceipt(common.Hash) (*eth.Receipt, error)
+
 	// SendSignedTransaction sends the signed transaction.
 	SendSignedTransaction(*eth.Transaction) error
 
 	// PendingNonceAt returns the nonce of the account at the given block.
 	PendingNonceAt(common.Address) (uint64, error)
 
+	// NonceAt returns the nonce of the account at the latest block.
+	NonceAt(common.Address) (uint64, error)
+
 	// SuggestGasPrice suggests a gas price.
 	SuggestGasPrice() (*big.Int, error)
 
+	// BaseFee returns the base fee of the latest block, nil if the chain does not support London.
+	BaseFee() (*big.Int, error)
+
+	// FeeHistory returns the base fees and the priority fee percentiles of the given number of latest blocks.
+	FeeHistory(uint64, []float64) (*ethereum.FeeHistory, error)
+
+	// EstimateGas estimates the gas needed to execute the given call.
+	EstimateGas(ethereum.CallMsg) (uint64, error)
+
+	// Erc20Decimals returns the number of decimals of the erc20 token.
+	Erc20Decimals(common.Address) (uint8, error)
+
 	// NetworkID returns the network ID.
 	NetworkID() (*big.Int, error)
 
@@ -138,6 +204,13 @@ type IRepository interface {
 	// SetTimeToFinalityPer10Secs sets time to finality per 10 seconds.
 	SetTimeToFinalityPer10Secs([]types.FloatTick)
 
+	// GetGasPrice returns the gas price estimate of the gas price oracle,
+	// or the gas price suggested by the node if not estimated yet.
+	GetGasPrice() (*types.GasPrice, error)
+
+	// SetGasPrice sets the gas price estimate of the gas price oracle.
+	SetGasPrice(*types.GasPrice)
+
 	// AddTransactions adds transactions to the database.
 	AddTransactions([]db_types.Transaction) error
 
@@ -163,6 +236,16 @@ type IRepository interface {
 	// ShrinkTtf shrinks the time to finality collection. It will persist the given number of ttfs.
 	ShrinkTtf(int64) error
 
+	// AddGasUsage adds the gas usage of the contract methods at the given time.
+	AddGasUsage(int64, []types.GasUsage) error
+
+	// GetTopGasConsumers returns the contract methods which used the most gas
+	// in the given number of resolution periods until the latest observed block.
+	GetTopGasConsumers(types.AggResolution, uint, uint) ([]types.GasUsage, error)
+
+	// ShrinkGasUsage deletes the gas usage older than the given time.
+	ShrinkGasUsage(int64) error
+
 	// AddAccounts adds accounts to the database.
 	AddAccounts([]common.Address, int64) error
 
@@ -172,6 +255,37 @@ type IRepository interface {
 	// AccountBalance returns the balance of the account.
 	AccountBalance(common.Address) (*hexutil.Big, error)
 
+	// TakeRateLimitToken takes a token from the shared rate limit bucket identified by the given key.
+	TakeRateLimitToken(string, types.RateLimit) (types.RateLimitResult, error)
+
+	// UseApiKey returns the active api key with the given hash and tracks its usage.
+	UseApiKey(string) (*types.ApiKey, error)
+
+	// AddSiweNonce adds a nonce of an issued sign-in message to the database.
+	AddSiweNonce(*types.SiweNonce) error
+
+	// UseSiweNonce marks the given nonce issued for the given address as used.
+	UseSiweNonce(string, common.Address) (*types.SiweNonce, error)
+
+	// AddSiweSession adds a new sign-in session to the database.
+	AddSiweSession(*types.SiweSession) error
+
+	// GetSiweSession returns the active sign-in session with the given token hash.
+	GetSiweSession(string) (*types.SiweSession, error)
+
+	// AddMazeChallenge adds an issued maze challenge to the database.
+	AddMazeChallenge(*types.MazeChallenge) error
+
+	// UseMazeChallenge marks the maze challenge with the given phrase as used by the given player.
+	UseMazeChallenge(string, common.Address) (*types.MazeChallenge, error)
+
+	// UsePowStamp marks the given proof-of-work stamp as spent until the given time.
+	// It returns false if the stamp has already been spent.
+	UsePowStamp(string, time.Time) (bool, error)
+
+	// IsValidSignature verifies the signature of the hash by the EIP-1271 contract call.
+	IsValidSignature(common.Address, common.Hash, []byte) (bool, error)
+
 	// MazePlayerPosition returns the position of the player in the maze.
 	MazePlayerPosition(common.Address, common.Address) (uint16, error)
 }
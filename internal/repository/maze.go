@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"ftm-explorer/internal/types"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -12,3 +13,18 @@ func (r *Repository) MazePlayerPosition(mazeAddr common.Address, playerAddr comm
 	defer cancel()
 	return r.rpc.MazePlayerPosition(ctx, mazeAddr, playerAddr)
 }
+
+// AddMazeChallenge adds an issued maze challenge to the database.
+func (r *Repository) AddMazeChallenge(mc *types.MazeChallenge) error {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.AddMazeChallenge(ctx, mc)
+}
+
+// UseMazeChallenge marks the maze challenge with the given phrase as used by the given player.
+// It returns nil if the challenge is unknown, expired or has already been used.
+func (r *Repository) UseMazeChallenge(phrase string, player common.Address) (*types.MazeChallenge, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.UseMazeChallenge(ctx, phrase, player)
+}
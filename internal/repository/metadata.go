@@ -5,7 +5,9 @@ import (
 	"ftm-explorer/internal/types"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // GetNumberOfAccounts returns the number of accounts in the blockchain.
@@ -46,6 +48,14 @@ func (r *Repository) PendingNonceAt(address common.Address) (uint64, error) {
 	return r.rpc.PendingNonceAt(ctx, address)
 }
 
+// NonceAt returns the nonce of the account at the latest block.
+func (r *Repository) NonceAt(address common.Address) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+
+	return r.rpc.NonceAt(ctx, address)
+}
+
 // SuggestGasPrice suggests a gas price.
 func (r *Repository) SuggestGasPrice() (*big.Int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
@@ -54,6 +64,30 @@ func (r *Repository) SuggestGasPrice() (*big.Int, error) {
 	return r.rpc.SuggestGasPrice(ctx)
 }
 
+// BaseFee returns the base fee of the latest block, nil if the chain does not support London.
+func (r *Repository) BaseFee() (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+
+	return r.rpc.BaseFee(ctx)
+}
+
+// FeeHistory returns the base fees and the priority fee percentiles of the given number of latest blocks.
+func (r *Repository) FeeHistory(blocks uint64, percentiles []float64) (*ethereum.FeeHistory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+
+	return r.rpc.FeeHistory(ctx, blocks, percentiles)
+}
+
+// EstimateGas estimates the gas needed to execute the given call.
+func (r *Repository) EstimateGas(msg ethereum.CallMsg) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+
+	return r.rpc.EstimateGas(ctx, msg)
+}
+
 // NetworkID returns the network ID.
 func (r *Repository) NetworkID() (*big.Int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
@@ -125,6 +159,30 @@ func (r *Repository) SetTimeToFinalityPer10Secs(data []types.FloatTick) {
 	r.ttfPer10Secs = cpy
 }
 
+// GetGasPrice returns the gas price estimate of the gas price oracle,
+// or the gas price suggested by the node if not estimated yet.
+func (r *Repository) GetGasPrice() (*types.GasPrice, error) {
+	if r.gasPrice != nil {
+		return r.gasPrice, nil
+	}
+
+	price, err := r.SuggestGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	return &types.GasPrice{
+		SafeLow: hexutil.Big(*price),
+		Average: hexutil.Big(*price),
+		Fast:    hexutil.Big(*price),
+		Fastest: hexutil.Big(*price),
+	}, nil
+}
+
+// SetGasPrice sets the gas price estimate of the gas price oracle.
+func (r *Repository) SetGasPrice(gp *types.GasPrice) {
+	r.gasPrice = gp
+}
+
 // IsIdle returns isIdle.
 func (r *Repository) IsIdle() bool {
 	return r.isIdle
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// UsePowStamp marks the given proof-of-work stamp as spent until the given time.
+// It returns false if the stamp has already been spent.
+func (r *Repository) UsePowStamp(stamp string, expiresAt time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.UsePowStamp(ctx, stamp, expiresAt)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"ftm-explorer/internal/types"
+)
+
+// TakeRateLimitToken takes a token from the shared rate limit bucket identified by the given key.
+// The bucket is stored in the database, so it is shared by all instances of the explorer.
+func (r *Repository) TakeRateLimitToken(key string, limit types.RateLimit) (types.RateLimitResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+
+	return r.db.TakeRateLimitToken(ctx, key, limit)
+}
@@ -7,6 +7,8 @@ import (
 	"ftm-explorer/internal/repository/rpc"
 	"ftm-explorer/internal/types"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // kRpcTimeout represents the timeout for RPC calls.
@@ -15,14 +17,32 @@ const kRpcTimeout = 5 * time.Second
 // kDbTimeout represents the timeout for DB calls.
 const kDbTimeout = 5 * time.Second
 
+// kSfcEpochsCacheSize represents the number of sealed epochs kept in the SFC cache.
+const kSfcEpochsCacheSize = 100
+
+// kDelegationsCacheSize represents the number of accounts with delegations kept in the delegations cache.
+const kDelegationsCacheSize = 1_000
+
+// kDelegationsCacheTtl represents the time the delegations of an account are cached for within a sealed epoch.
+const kDelegationsCacheTtl = 30 * time.Second
+
 // Repository represents the repository.
-// It contains the RPC client and a buffer for blocks.
-// The buffer is used to store the latest observed blocks.
+// It contains the RPC client, a buffer for blocks and caches for transactions and SFC data.
+// The buffer is used to store the latest observed blocks, the transactions cache is used
+// to store recently observed or requested transactions, the SFC cache is used to store
+// validators and epochs read from the SFC contract and the delegations cache is used to store
+// delegations of accounts read in the latest sealed epoch.
 type Repository struct {
 	rpc         rpc.IRpc
 	db          db.IDatabase
 	metaFetcher meta_fetcher.IMetaFetcher
 	blkBuffer   *buffer.BlocksBuffer
+	trxCache    *buffer.TransactionsCache
+	sfcCache    *buffer.SfcCache
+	dlgCache    *buffer.DelegationsCache
+
+	// dlgGroup coalesces concurrent reads of delegations of the same account.
+	dlgGroup singleflight.Group
 
 	// numberOfAccounts is the number of accounts in the blockchain.
 	numberOfAccounts uint64
@@ -37,6 +57,8 @@ type Repository struct {
 	gasUsedPer10Secs []types.HexUintTick
 	// ttfPer10Secs is the time to finality per 10 seconds.
 	ttfPer10Secs []types.FloatTick
+	// gasPrice is the gas price estimate of the gas price oracle, nil until estimated.
+	gasPrice *types.GasPrice
 
 	// isIdle indicates if the chain is idle.
 	isIdle bool
@@ -46,12 +68,15 @@ type Repository struct {
 }
 
 // NewRepository creates a new repository.
-func NewRepository(blkBufferSize uint, rpc rpc.IRpc, db db.IDatabase, mf meta_fetcher.IMetaFetcher) *Repository {
+func NewRepository(blkBufferSize uint, trxCacheSize uint, rpc rpc.IRpc, db db.IDatabase, mf meta_fetcher.IMetaFetcher) *Repository {
 	return &Repository{
 		rpc:              rpc,
 		db:               db,
 		metaFetcher:      mf,
 		blkBuffer:        buffer.NewBlocksBuffer(blkBufferSize),
+		trxCache:         buffer.NewTransactionsCache(trxCacheSize),
+		sfcCache:         buffer.NewSfcCache(kSfcEpochsCacheSize),
+		dlgCache:         buffer.NewDelegationsCache(kDelegationsCacheSize, kDelegationsCacheTtl),
 		numberOfAccounts: 0,
 	}
 }
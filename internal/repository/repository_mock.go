@@ -9,11 +9,14 @@ import (
 	types "ftm-explorer/internal/types"
 	big "math/big"
 	reflect "reflect"
+	time "time"
 
+	ethereum "github.com/ethereum/go-ethereum"
 	common "github.com/ethereum/go-ethereum/common"
 	hexutil "github.com/ethereum/go-ethereum/common/hexutil"
 	types0 "github.com/ethereum/go-ethereum/core/types"
 	gomock "github.com/golang/mock/gomock"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MockRepository is a mock of IRepository interface.
@@ -68,6 +71,62 @@ func (mr *MockRepositoryMockRecorder) AddAccounts(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccounts", reflect.TypeOf((*MockRepository)(nil).AddAccounts), arg0, arg1)
 }
 
+// AddGasUsage mocks base method.
+func (m *MockRepository) AddGasUsage(arg0 int64, arg1 []types.GasUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGasUsage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGasUsage indicates an expected call of AddGasUsage.
+func (mr *MockRepositoryMockRecorder) AddGasUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGasUsage", reflect.TypeOf((*MockRepository)(nil).AddGasUsage), arg0, arg1)
+}
+
+// AddMazeChallenge mocks base method.
+func (m *MockRepository) AddMazeChallenge(arg0 *types.MazeChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMazeChallenge", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMazeChallenge indicates an expected call of AddMazeChallenge.
+func (mr *MockRepositoryMockRecorder) AddMazeChallenge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMazeChallenge", reflect.TypeOf((*MockRepository)(nil).AddMazeChallenge), arg0)
+}
+
+// AddSiweNonce mocks base method.
+func (m *MockRepository) AddSiweNonce(arg0 *types.SiweNonce) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSiweNonce", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSiweNonce indicates an expected call of AddSiweNonce.
+func (mr *MockRepositoryMockRecorder) AddSiweNonce(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSiweNonce", reflect.TypeOf((*MockRepository)(nil).AddSiweNonce), arg0)
+}
+
+// AddSiweSession mocks base method.
+func (m *MockRepository) AddSiweSession(arg0 *types.SiweSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSiweSession", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSiweSession indicates an expected call of AddSiweSession.
+func (mr *MockRepositoryMockRecorder) AddSiweSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSiweSession", reflect.TypeOf((*MockRepository)(nil).AddSiweSession), arg0)
+}
+
 // AddTimeToFinality mocks base method.
 func (m *MockRepository) AddTimeToFinality(arg0 *types.Ttf) error {
 	m.ctrl.T.Helper()
@@ -110,6 +169,66 @@ func (mr *MockRepositoryMockRecorder) AddTransactions(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransactions", reflect.TypeOf((*MockRepository)(nil).AddTransactions), arg0)
 }
 
+// BaseFee mocks base method.
+func (m *MockRepository) BaseFee() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseFee")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BaseFee indicates an expected call of BaseFee.
+func (mr *MockRepositoryMockRecorder) BaseFee() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseFee", reflect.TypeOf((*MockRepository)(nil).BaseFee))
+}
+
+// Erc20Decimals mocks base method.
+func (m *MockRepository) Erc20Decimals(arg0 common.Address) (uint8, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Erc20Decimals", arg0)
+	ret0, _ := ret[0].(uint8)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Erc20Decimals indicates an expected call of Erc20Decimals.
+func (mr *MockRepositoryMockRecorder) Erc20Decimals(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Erc20Decimals", reflect.TypeOf((*MockRepository)(nil).Erc20Decimals), arg0)
+}
+
+// EstimateGas mocks base method.
+func (m *MockRepository) EstimateGas(arg0 ethereum.CallMsg) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateGas", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas.
+func (mr *MockRepositoryMockRecorder) EstimateGas(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockRepository)(nil).EstimateGas), arg0)
+}
+
+// FeeHistory mocks base method.
+func (m *MockRepository) FeeHistory(arg0 uint64, arg1 []float64) (*ethereum.FeeHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeeHistory", arg0, arg1)
+	ret0, _ := ret[0].(*ethereum.FeeHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FeeHistory indicates an expected call of FeeHistory.
+func (mr *MockRepositoryMockRecorder) FeeHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockRepository)(nil).FeeHistory), arg0, arg1)
+}
+
 // FetchDiskSizePer100MTxs mocks base method.
 func (m *MockRepository) FetchDiskSizePer100MTxs() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -185,6 +304,21 @@ func (mr *MockRepositoryMockRecorder) FetchTimeToFinality() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTimeToFinality", reflect.TypeOf((*MockRepository)(nil).FetchTimeToFinality))
 }
 
+// FetchTransactionByHash mocks base method.
+func (m *MockRepository) FetchTransactionByHash(arg0 common.Hash) (*types.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTransactionByHash", arg0)
+	ret0, _ := ret[0].(*types.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTransactionByHash indicates an expected call of FetchTransactionByHash.
+func (mr *MockRepositoryMockRecorder) FetchTransactionByHash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTransactionByHash", reflect.TypeOf((*MockRepository)(nil).FetchTransactionByHash), arg0)
+}
+
 // GetBlockByNumber mocks base method.
 func (m *MockRepository) GetBlockByNumber(arg0 uint64) (*types.Block, error) {
 	m.ctrl.T.Helper()
@@ -200,6 +334,21 @@ func (mr *MockRepositoryMockRecorder) GetBlockByNumber(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByNumber", reflect.TypeOf((*MockRepository)(nil).GetBlockByNumber), arg0)
 }
 
+// GetDelegations mocks base method.
+func (m *MockRepository) GetDelegations(arg0 common.Address) ([]types.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegations", arg0)
+	ret0, _ := ret[0].([]types.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegations indicates an expected call of GetDelegations.
+func (mr *MockRepositoryMockRecorder) GetDelegations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegations", reflect.TypeOf((*MockRepository)(nil).GetDelegations), arg0)
+}
+
 // GetDiskSizePer100MTxs mocks base method.
 func (m *MockRepository) GetDiskSizePer100MTxs() uint64 {
 	m.ctrl.T.Helper()
@@ -228,6 +377,51 @@ func (mr *MockRepositoryMockRecorder) GetDiskSizePrunedPer100MTxs() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiskSizePrunedPer100MTxs", reflect.TypeOf((*MockRepository)(nil).GetDiskSizePrunedPer100MTxs))
 }
 
+// GetEpoch mocks base method.
+func (m *MockRepository) GetEpoch(arg0 *uint64) (*types.Epoch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpoch", arg0)
+	ret0, _ := ret[0].(*types.Epoch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpoch indicates an expected call of GetEpoch.
+func (mr *MockRepositoryMockRecorder) GetEpoch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpoch", reflect.TypeOf((*MockRepository)(nil).GetEpoch), arg0)
+}
+
+// GetEpochStats mocks base method.
+func (m *MockRepository) GetEpochStats(arg0 *uint64, arg1 uint) ([]types.EpochStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpochStats", arg0, arg1)
+	ret0, _ := ret[0].([]types.EpochStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpochStats indicates an expected call of GetEpochStats.
+func (mr *MockRepositoryMockRecorder) GetEpochStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpochStats", reflect.TypeOf((*MockRepository)(nil).GetEpochStats), arg0, arg1)
+}
+
+// GetGasPrice mocks base method.
+func (m *MockRepository) GetGasPrice() (*types.GasPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGasPrice")
+	ret0, _ := ret[0].(*types.GasPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGasPrice indicates an expected call of GetGasPrice.
+func (mr *MockRepositoryMockRecorder) GetGasPrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGasPrice", reflect.TypeOf((*MockRepository)(nil).GetGasPrice))
+}
+
 // GetGasUsedAggByTimestamp mocks base method.
 func (m *MockRepository) GetGasUsedAggByTimestamp(arg0 types.AggResolution, arg1 uint, arg2 *uint64) ([]types.HexUintTick, error) {
 	m.ctrl.T.Helper()
@@ -388,6 +582,51 @@ func (mr *MockRepositoryMockRecorder) GetNumberOfValidators() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNumberOfValidators", reflect.TypeOf((*MockRepository)(nil).GetNumberOfValidators))
 }
 
+// GetPendingTokensRequests mocks base method.
+func (m *MockRepository) GetPendingTokensRequests() ([]types.TokensRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingTokensRequests")
+	ret0, _ := ret[0].([]types.TokensRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingTokensRequests indicates an expected call of GetPendingTokensRequests.
+func (mr *MockRepositoryMockRecorder) GetPendingTokensRequests() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingTokensRequests", reflect.TypeOf((*MockRepository)(nil).GetPendingTokensRequests))
+}
+
+// GetSiweSession mocks base method.
+func (m *MockRepository) GetSiweSession(arg0 string) (*types.SiweSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiweSession", arg0)
+	ret0, _ := ret[0].(*types.SiweSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiweSession indicates an expected call of GetSiweSession.
+func (mr *MockRepositoryMockRecorder) GetSiweSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiweSession", reflect.TypeOf((*MockRepository)(nil).GetSiweSession), arg0)
+}
+
+// GetStakingRewards mocks base method.
+func (m *MockRepository) GetStakingRewards(arg0 common.Address) (*types.StakingRewards, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakingRewards", arg0)
+	ret0, _ := ret[0].(*types.StakingRewards)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakingRewards indicates an expected call of GetStakingRewards.
+func (mr *MockRepositoryMockRecorder) GetStakingRewards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakingRewards", reflect.TypeOf((*MockRepository)(nil).GetStakingRewards), arg0)
+}
+
 // GetTimeToBlock mocks base method.
 func (m *MockRepository) GetTimeToBlock() float64 {
 	m.ctrl.T.Helper()
@@ -430,6 +669,66 @@ func (mr *MockRepositoryMockRecorder) GetTimeToFinalityPer10Secs() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeToFinalityPer10Secs", reflect.TypeOf((*MockRepository)(nil).GetTimeToFinalityPer10Secs))
 }
 
+// GetTokensRequest mocks base method.
+func (m *MockRepository) GetTokensRequest(arg0 primitive.ObjectID) (*types.TokensRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokensRequest", arg0)
+	ret0, _ := ret[0].(*types.TokensRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokensRequest indicates an expected call of GetTokensRequest.
+func (mr *MockRepositoryMockRecorder) GetTokensRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokensRequest", reflect.TypeOf((*MockRepository)(nil).GetTokensRequest), arg0)
+}
+
+// GetTokensRequestClaimTimes mocks base method.
+func (m *MockRepository) GetTokensRequestClaimTimes(receiver, erc20 *common.Address, from int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokensRequestClaimTimes", receiver, erc20, from)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokensRequestClaimTimes indicates an expected call of GetTokensRequestClaimTimes.
+func (mr *MockRepositoryMockRecorder) GetTokensRequestClaimTimes(receiver, erc20, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokensRequestClaimTimes", reflect.TypeOf((*MockRepository)(nil).GetTokensRequestClaimTimes), receiver, erc20, from)
+}
+
+// GetTokensRequestClaims mocks base method.
+func (m *MockRepository) GetTokensRequestClaims(arg0 string, arg1 int64) ([]types.TokensRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokensRequestClaims", arg0, arg1)
+	ret0, _ := ret[0].([]types.TokensRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokensRequestClaims indicates an expected call of GetTokensRequestClaims.
+func (mr *MockRepositoryMockRecorder) GetTokensRequestClaims(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokensRequestClaims", reflect.TypeOf((*MockRepository)(nil).GetTokensRequestClaims), arg0, arg1)
+}
+
+// GetTopGasConsumers mocks base method.
+func (m *MockRepository) GetTopGasConsumers(arg0 types.AggResolution, arg1, arg2 uint) ([]types.GasUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopGasConsumers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.GasUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopGasConsumers indicates an expected call of GetTopGasConsumers.
+func (mr *MockRepositoryMockRecorder) GetTopGasConsumers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopGasConsumers", reflect.TypeOf((*MockRepository)(nil).GetTopGasConsumers), arg0, arg1, arg2)
+}
+
 // GetTransactionByHash mocks base method.
 func (m *MockRepository) GetTransactionByHash(arg0 common.Hash) (*types.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -445,6 +744,20 @@ func (mr *MockRepositoryMockRecorder) GetTransactionByHash(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByHash", reflect.TypeOf((*MockRepository)(nil).GetTransactionByHash), arg0)
 }
 
+// GetTransactionCacheStats mocks base method.
+func (m *MockRepository) GetTransactionCacheStats() types.CacheStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionCacheStats")
+	ret0, _ := ret[0].(types.CacheStats)
+	return ret0
+}
+
+// GetTransactionCacheStats indicates an expected call of GetTransactionCacheStats.
+func (mr *MockRepositoryMockRecorder) GetTransactionCacheStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionCacheStats", reflect.TypeOf((*MockRepository)(nil).GetTransactionCacheStats))
+}
+
 // GetTrxCount mocks base method.
 func (m *MockRepository) GetTrxCount() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -504,6 +817,36 @@ func (mr *MockRepositoryMockRecorder) GetTxCountPer10Secs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxCountPer10Secs", reflect.TypeOf((*MockRepository)(nil).GetTxCountPer10Secs))
 }
 
+// GetValidator mocks base method.
+func (m *MockRepository) GetValidator(arg0 uint64) (*types.Validator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidator", arg0)
+	ret0, _ := ret[0].(*types.Validator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidator indicates an expected call of GetValidator.
+func (mr *MockRepositoryMockRecorder) GetValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidator", reflect.TypeOf((*MockRepository)(nil).GetValidator), arg0)
+}
+
+// GetValidators mocks base method.
+func (m *MockRepository) GetValidators() ([]types.Validator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidators")
+	ret0, _ := ret[0].([]types.Validator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidators indicates an expected call of GetValidators.
+func (mr *MockRepositoryMockRecorder) GetValidators() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidators", reflect.TypeOf((*MockRepository)(nil).GetValidators))
+}
+
 // IncrementTrxCount mocks base method.
 func (m *MockRepository) IncrementTrxCount(arg0 uint) error {
 	m.ctrl.T.Helper()
@@ -546,6 +889,21 @@ func (mr *MockRepositoryMockRecorder) IsIdleOverride() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIdleOverride", reflect.TypeOf((*MockRepository)(nil).IsIdleOverride))
 }
 
+// IsValidSignature mocks base method.
+func (m *MockRepository) IsValidSignature(arg0 common.Address, arg1 common.Hash, arg2 []byte) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsValidSignature", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsValidSignature indicates an expected call of IsValidSignature.
+func (mr *MockRepositoryMockRecorder) IsValidSignature(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsValidSignature", reflect.TypeOf((*MockRepository)(nil).IsValidSignature), arg0, arg1, arg2)
+}
+
 // MazePlayerPosition mocks base method.
 func (m *MockRepository) MazePlayerPosition(arg0, arg1 common.Address) (uint16, error) {
 	m.ctrl.T.Helper()
@@ -576,6 +934,21 @@ func (mr *MockRepositoryMockRecorder) NetworkID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkID", reflect.TypeOf((*MockRepository)(nil).NetworkID))
 }
 
+// NonceAt mocks base method.
+func (m *MockRepository) NonceAt(arg0 common.Address) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NonceAt", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NonceAt indicates an expected call of NonceAt.
+func (mr *MockRepositoryMockRecorder) NonceAt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NonceAt", reflect.TypeOf((*MockRepository)(nil).NonceAt), arg0)
+}
+
 // PendingNonceAt mocks base method.
 func (m *MockRepository) PendingNonceAt(arg0 common.Address) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -591,6 +964,34 @@ func (mr *MockRepositoryMockRecorder) PendingNonceAt(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingNonceAt", reflect.TypeOf((*MockRepository)(nil).PendingNonceAt), arg0)
 }
 
+// ReplaceTokensRequestTxHash mocks base method.
+func (m *MockRepository) ReplaceTokensRequestTxHash(arg0, arg1 common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTokensRequestTxHash", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTokensRequestTxHash indicates an expected call of ReplaceTokensRequestTxHash.
+func (mr *MockRepositoryMockRecorder) ReplaceTokensRequestTxHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTokensRequestTxHash", reflect.TypeOf((*MockRepository)(nil).ReplaceTokensRequestTxHash), arg0, arg1)
+}
+
+// SealEpoch mocks base method.
+func (m *MockRepository) SealEpoch(arg0 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SealEpoch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SealEpoch indicates an expected call of SealEpoch.
+func (mr *MockRepositoryMockRecorder) SealEpoch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SealEpoch", reflect.TypeOf((*MockRepository)(nil).SealEpoch), arg0)
+}
+
 // SendSignedTransaction mocks base method.
 func (m *MockRepository) SendSignedTransaction(arg0 *types0.Transaction) error {
 	m.ctrl.T.Helper()
@@ -629,6 +1030,18 @@ func (mr *MockRepositoryMockRecorder) SetDiskSizePrunedPer100MTxs(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDiskSizePrunedPer100MTxs", reflect.TypeOf((*MockRepository)(nil).SetDiskSizePrunedPer100MTxs), arg0)
 }
 
+// SetGasPrice mocks base method.
+func (m *MockRepository) SetGasPrice(arg0 *types.GasPrice) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGasPrice", arg0)
+}
+
+// SetGasPrice indicates an expected call of SetGasPrice.
+func (mr *MockRepositoryMockRecorder) SetGasPrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGasPrice", reflect.TypeOf((*MockRepository)(nil).SetGasPrice), arg0)
+}
+
 // SetGasUsedPer10Secs mocks base method.
 func (m *MockRepository) SetGasUsedPer10Secs(arg0 []types.HexUintTick) {
 	m.ctrl.T.Helper()
@@ -701,6 +1114,20 @@ func (mr *MockRepositoryMockRecorder) SetTxCountPer10Secs(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTxCountPer10Secs", reflect.TypeOf((*MockRepository)(nil).SetTxCountPer10Secs), arg0)
 }
 
+// ShrinkGasUsage mocks base method.
+func (m *MockRepository) ShrinkGasUsage(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShrinkGasUsage", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShrinkGasUsage indicates an expected call of ShrinkGasUsage.
+func (mr *MockRepositoryMockRecorder) ShrinkGasUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShrinkGasUsage", reflect.TypeOf((*MockRepository)(nil).ShrinkGasUsage), arg0)
+}
+
 // ShrinkTransactions mocks base method.
 func (m *MockRepository) ShrinkTransactions(arg0 int64) error {
 	m.ctrl.T.Helper()
@@ -744,6 +1171,36 @@ func (mr *MockRepositoryMockRecorder) SuggestGasPrice() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestGasPrice", reflect.TypeOf((*MockRepository)(nil).SuggestGasPrice))
 }
 
+// TakeRateLimitToken mocks base method.
+func (m *MockRepository) TakeRateLimitToken(arg0 string, arg1 types.RateLimit) (types.RateLimitResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeRateLimitToken", arg0, arg1)
+	ret0, _ := ret[0].(types.RateLimitResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeRateLimitToken indicates an expected call of TakeRateLimitToken.
+func (mr *MockRepositoryMockRecorder) TakeRateLimitToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeRateLimitToken", reflect.TypeOf((*MockRepository)(nil).TakeRateLimitToken), arg0, arg1)
+}
+
+// TransactionReceipt mocks base method.
+func (m *MockRepository) TransactionReceipt(arg0 common.Hash) (*types0.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactionReceipt", arg0)
+	ret0, _ := ret[0].(*types0.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransactionReceipt indicates an expected call of TransactionReceipt.
+func (mr *MockRepositoryMockRecorder) TransactionReceipt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionReceipt", reflect.TypeOf((*MockRepository)(nil).TransactionReceipt), arg0)
+}
+
 // UpdateLatestObservedBlock mocks base method.
 func (m *MockRepository) UpdateLatestObservedBlock(arg0 *types.Block) error {
 	m.ctrl.T.Helper()
@@ -771,3 +1228,63 @@ func (mr *MockRepositoryMockRecorder) UpdateTokensRequest(arg0 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTokensRequest", reflect.TypeOf((*MockRepository)(nil).UpdateTokensRequest), arg0)
 }
+
+// UseApiKey mocks base method.
+func (m *MockRepository) UseApiKey(arg0 string) (*types.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseApiKey", arg0)
+	ret0, _ := ret[0].(*types.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseApiKey indicates an expected call of UseApiKey.
+func (mr *MockRepositoryMockRecorder) UseApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseApiKey", reflect.TypeOf((*MockRepository)(nil).UseApiKey), arg0)
+}
+
+// UseMazeChallenge mocks base method.
+func (m *MockRepository) UseMazeChallenge(arg0 string, arg1 common.Address) (*types.MazeChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseMazeChallenge", arg0, arg1)
+	ret0, _ := ret[0].(*types.MazeChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseMazeChallenge indicates an expected call of UseMazeChallenge.
+func (mr *MockRepositoryMockRecorder) UseMazeChallenge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseMazeChallenge", reflect.TypeOf((*MockRepository)(nil).UseMazeChallenge), arg0, arg1)
+}
+
+// UsePowStamp mocks base method.
+func (m *MockRepository) UsePowStamp(arg0 string, arg1 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePowStamp", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePowStamp indicates an expected call of UsePowStamp.
+func (mr *MockRepositoryMockRecorder) UsePowStamp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePowStamp", reflect.TypeOf((*MockRepository)(nil).UsePowStamp), arg0, arg1)
+}
+
+// UseSiweNonce mocks base method.
+func (m *MockRepository) UseSiweNonce(arg0 string, arg1 common.Address) (*types.SiweNonce, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseSiweNonce", arg0, arg1)
+	ret0, _ := ret[0].(*types.SiweNonce)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseSiweNonce indicates an expected call of UseSiweNonce.
+func (mr *MockRepositoryMockRecorder) UseSiweNonce(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseSiweNonce", reflect.TypeOf((*MockRepository)(nil).UseSiweNonce), arg0, arg1)
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"fmt"
 	"ftm-explorer/internal/repository/db"
 	"ftm-explorer/internal/repository/meta_fetcher"
 	"ftm-explorer/internal/repository/rpc"
@@ -80,6 +81,47 @@ func TestRepository_GetTransactionByHash(t *testing.T) {
 	if returnedTrx.Hash != trx.Hash {
 		t.Errorf("expected %v, got %v", trx.Hash, returnedTrx.Hash)
 	}
+
+	// pending transaction is not cached, so it should be fetched again
+	mockRpc.EXPECT().TransactionByHash(gomock.Any(), gomock.Eq(trx.Hash)).Return(&trx, nil)
+	if _, err = repository.GetTransactionByHash(trx.Hash); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// Test that repository caches fetched transactions included in a block.
+func TestRepository_TransactionCache(t *testing.T) {
+	repository, mockRpc, _, _ := createRepository(t)
+
+	// fetched transaction should be cached
+	blockNumber := hexutil.Uint64(100)
+	trx := types.Transaction{Hash: common.HexToHash("0x123"), BlockNumber: &blockNumber}
+	mockRpc.EXPECT().TransactionByHash(gomock.Any(), gomock.Eq(trx.Hash)).Return(&trx, nil)
+	if _, err := repository.FetchTransactionByHash(trx.Hash); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// transaction should be returned from cache without calling rpc
+	returnedTrx, err := repository.GetTransactionByHash(trx.Hash)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if returnedTrx.Hash != trx.Hash {
+		t.Errorf("expected %v, got %v", trx.Hash, returnedTrx.Hash)
+	}
+
+	// unknown transaction should be fetched from rpc
+	unknownHash := common.HexToHash("0x456")
+	mockRpc.EXPECT().TransactionByHash(gomock.Any(), gomock.Eq(unknownHash)).Return(nil, fmt.Errorf("not found"))
+	if _, err = repository.GetTransactionByHash(unknownHash); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	// check cache stats
+	stats := repository.GetTransactionCacheStats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Size != 1 {
+		t.Errorf("expected 1 hit, 1 miss and size 1, got %+v", stats)
+	}
 }
 
 // Test that repository returns transaction by hash.
@@ -202,6 +244,183 @@ func TestRepository_GetNumberOfValidators(t *testing.T) {
 	}
 }
 
+// Test that repository reads validators once per sealed epoch.
+func TestRepository_GetValidators(t *testing.T) {
+	repository, mockRpc, _, _ := createRepository(t)
+	validators := []types.Validator{{Id: 1, Auth: common.HexToAddress("0x1")}, {Id: 2, Auth: common.HexToAddress("0x2")}}
+
+	// validators are read from rpc only once for the sealed epoch
+	mockRpc.EXPECT().CurrentSealedEpoch(gomock.Any()).Return(uint64(10), nil).Times(3)
+	mockRpc.EXPECT().Validators(gomock.Any(), gomock.Eq(uint64(10))).Return(validators, nil).Times(1)
+	for i := 0; i < 2; i++ {
+		returned, err := repository.GetValidators()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(returned) != len(validators) {
+			t.Errorf("expected %d validators, got %d", len(validators), len(returned))
+		}
+	}
+
+	// validator of the epoch is returned from the cache
+	validator, err := repository.GetValidator(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if validator == nil || validator.Auth != validators[1].Auth {
+		t.Errorf("expected validator %v, got %v", validators[1], validator)
+	}
+
+	// validator outside the epoch validators is read from rpc
+	mockRpc.EXPECT().CurrentSealedEpoch(gomock.Any()).Return(uint64(10), nil)
+	mockRpc.EXPECT().Validator(gomock.Any(), gomock.Eq(uint64(3)), gomock.Eq(uint64(10))).Return(nil, nil)
+	validator, err = repository.GetValidator(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if validator != nil {
+		t.Errorf("expected nil, got %v", validator)
+	}
+
+	// validators are read again once a new epoch is sealed
+	mockRpc.EXPECT().CurrentSealedEpoch(gomock.Any()).Return(uint64(11), nil)
+	mockRpc.EXPECT().Validators(gomock.Any(), gomock.Eq(uint64(11))).Return(validators[:1], nil)
+	returned, err := repository.GetValidators()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(returned) != 1 {
+		t.Errorf("expected 1 validator, got %d", len(returned))
+	}
+}
+
+// Test that the latest sealed epoch is derived from the epoch of the latest observed block.
+func TestRepository_GetValidatorsOfObservedEpoch(t *testing.T) {
+	repository, mockRpc, _, _ := createRepository(t)
+	validators := []types.Validator{{Id: 1, Auth: common.HexToAddress("0x1")}}
+	repository.blkBuffer.Add(&types.Block{Number: 100, Epoch: 12})
+
+	// the sealed epoch is not read from rpc
+	mockRpc.EXPECT().Validators(gomock.Any(), gomock.Eq(uint64(11))).Return(validators, nil)
+	for i := 0; i < 2; i++ {
+		if returned, err := repository.GetValidators(); err != nil || len(returned) != 1 {
+			t.Fatalf("expected 1 validator, got %v; %v", returned, err)
+		}
+	}
+	if validator, err := repository.GetValidator(1); err != nil || validator == nil {
+		t.Fatalf("expected validator, got %v; %v", validator, err)
+	}
+
+	// the epoch is read once a block of a new epoch is observed
+	epoch := types.Epoch{Number: 12, EndTime: 1_700_000_000}
+	repository.blkBuffer.Add(&types.Block{Number: 101, Epoch: 13})
+	mockRpc.EXPECT().Epoch(gomock.Any(), gomock.Eq(uint64(12))).Return(&epoch, nil)
+	if returned, err := repository.GetEpoch(nil); err != nil || returned == nil || returned.Number != 12 {
+		t.Fatalf("expected epoch 12, got %v; %v", returned, err)
+	}
+}
+
+// Test that repository caches sealed epochs only.
+func TestRepository_GetEpoch(t *testing.T) {
+	repository, mockRpc, _, _ := createRepository(t)
+	epoch := types.Epoch{Number: 10, EndTime: 1_700_000_000, Duration: 300}
+
+	// the latest sealed epoch is read from rpc only once
+	mockRpc.EXPECT().CurrentSealedEpoch(gomock.Any()).Return(uint64(10), nil)
+	mockRpc.EXPECT().Epoch(gomock.Any(), gomock.Eq(uint64(10))).Return(&epoch, nil).Times(1)
+	returned, err := repository.GetEpoch(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if returned == nil || returned.Number != epoch.Number {
+		t.Errorf("expected epoch %v, got %v", epoch, returned)
+	}
+	number := uint64(10)
+	if returned, err = repository.GetEpoch(&number); err != nil || returned == nil || returned.EndTime != epoch.EndTime {
+		t.Errorf("expected epoch %v, got %v, %v", epoch, returned, err)
+	}
+
+	// epoch which is not sealed is not cached
+	number = 11
+	mockRpc.EXPECT().Epoch(gomock.Any(), gomock.Eq(uint64(11))).Return(nil, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		if returned, err = repository.GetEpoch(&number); err != nil || returned != nil {
+			t.Errorf("expected nil, got %v, %v", returned, err)
+		}
+	}
+}
+
+// Test that repository sums the rewards of all the delegations of the account.
+func TestRepository_GetStakingRewards(t *testing.T) {
+	repository, mockRpc, _, _ := createRepository(t)
+	addr := common.HexToAddress("0x1")
+
+	repository.blkBuffer.Add(&types.Block{Number: 100, Epoch: 10})
+	mockRpc.EXPECT().Delegations(gomock.Any(), gomock.Eq(addr)).Return([]types.Delegation{
+		{ValidatorId: 1, PendingRewards: hexutil.Big(*big.NewInt(100)), StashedRewards: hexutil.Big(*big.NewInt(40))},
+		{ValidatorId: 5, PendingRewards: hexutil.Big(*big.NewInt(20))},
+	}, nil)
+	rewards, err := repository.GetStakingRewards(addr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rewards.Pending.ToInt().Int64() != 120 || rewards.Stashed.ToInt().Int64() != 40 {
+		t.Errorf("expected 120 pending and 40 stashed, got %s and %s", rewards.Pending.String(), rewards.Stashed.String())
+	}
+}
+
+// Test that repository caches delegations of the account for the latest sealed epoch.
+func TestRepository_GetDelegationsCached(t *testing.T) {
+	repository, mockRpc, _, _ := createRepository(t)
+	addr := common.HexToAddress("0x1")
+	delegations := []types.Delegation{
+		{ValidatorId: 1, Amount: hexutil.Big(*big.NewInt(1000)), PendingRewards: hexutil.Big(*big.NewInt(100))},
+	}
+	repository.blkBuffer.Add(&types.Block{Number: 100, Epoch: 10})
+
+	// delegations and staking rewards of the same epoch share a single scan
+	mockRpc.EXPECT().Delegations(gomock.Any(), gomock.Eq(addr)).Return(delegations, nil)
+	if res, err := repository.GetDelegations(addr); err != nil || len(res) != 1 {
+		t.Fatalf("expected 1 delegation, got %v; %v", res, err)
+	}
+	rewards, err := repository.GetStakingRewards(addr)
+	if err != nil || rewards.Pending.ToInt().Int64() != 100 {
+		t.Fatalf("expected 100 pending rewards, got %v; %v", rewards, err)
+	}
+
+	// the delegations are not read again for new blocks of the same epoch
+	repository.blkBuffer.Add(&types.Block{Number: 101, Epoch: 10})
+	if res, err := repository.GetDelegations(addr); err != nil || len(res) != 1 {
+		t.Fatalf("expected 1 cached delegation, got %v; %v", res, err)
+	}
+
+	// the delegations are read again once a new epoch is sealed
+	repository.blkBuffer.Add(&types.Block{Number: 102, Epoch: 11})
+	mockRpc.EXPECT().Delegations(gomock.Any(), gomock.Eq(addr)).Return([]types.Delegation{}, nil)
+	if res, err := repository.GetDelegations(addr); err != nil || len(res) != 0 {
+		t.Fatalf("expected no delegations, got %v; %v", res, err)
+	}
+}
+
+// Test that repository persists stats of sealed epochs with stored blocks only.
+func TestRepository_SealEpoch(t *testing.T) {
+	repository, _, mockDb, _ := createRepository(t)
+	stats := types.EpochStats{Epoch: 10, Blocks: 120}
+
+	// stats of the epoch are aggregated and stored
+	mockDb.EXPECT().EpochStatsFromBlocks(gomock.Any(), gomock.Eq(uint64(10))).Return(&stats, nil)
+	mockDb.EXPECT().AddEpochStats(gomock.Any(), gomock.Eq(&stats)).Return(nil)
+	if err := repository.SealEpoch(10); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// nothing is stored without blocks of the epoch
+	mockDb.EXPECT().EpochStatsFromBlocks(gomock.Any(), gomock.Eq(uint64(11))).Return(nil, nil)
+	if err := repository.SealEpoch(11); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 // Test that repository fetches number of accounts.
 func TestRepository_FetchNumberOfAccounts(t *testing.T) {
 	repository, _, _, mockFetcher := createRepository(t)
@@ -415,6 +634,6 @@ func createRepository(t *testing.T) (*Repository, *rpc.MockRpc, *db.MockDatabase
 	mockRpc := rpc.NewMockRpc(ctrl)
 	mockDb := db.NewMockDatabase(ctrl)
 	mockFetcher := meta_fetcher.NewMockMetaFetcher(ctrl)
-	repository := NewRepository(10_000, mockRpc, mockDb, mockFetcher)
+	repository := NewRepository(10_000, 10_000, mockRpc, mockDb, mockFetcher)
 	return repository, mockRpc, mockDb, mockFetcher
 }
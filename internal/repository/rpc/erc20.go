@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	abi2 "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// kErc20DecimalsAbi is the abi definition of the erc20 decimals function.
+const kErc20DecimalsAbi = `[{
+    "inputs": [],
+    "name": "decimals",
+    "outputs": [
+      {
+        "internalType": "uint8",
+        "name": "",
+        "type": "uint8"
+      }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  }]`
+
+// Erc20Decimals returns the number of decimals of the erc20 token.
+func (rpc *OperaRpc) Erc20Decimals(ctx context.Context, token common.Address) (uint8, error) {
+	abi, err := abi2.JSON(strings.NewReader(kErc20DecimalsAbi))
+	if err != nil {
+		return 0, err
+	}
+	data, err := abi.Pack("decimals")
+	if err != nil {
+		return 0, err
+	}
+
+	output, err := ethclient.NewClient(rpc.ftm).CallContract(ctx, ethereum.CallMsg{
+		To:   &token,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	decimals, err := abi.Unpack("decimals", output)
+	if err != nil {
+		return 0, err
+	}
+	return decimals[0].(uint8), nil
+}
@@ -7,6 +7,7 @@ import (
 	"ftm-explorer/internal/types"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth "github.com/ethereum/go-ethereum/core/types"
@@ -22,18 +23,42 @@ type IRpc interface {
 	ObservedHeadProxy() <-chan *eth.Header
 	// NumberOfValidators returns the number of validators.
 	NumberOfValidators(context.Context) (uint64, error)
+	// CurrentSealedEpoch returns the number of the latest sealed epoch.
+	CurrentSealedEpoch(context.Context) (uint64, error)
+	// Validators returns the validators of the given sealed epoch.
+	Validators(context.Context, uint64) ([]types.Validator, error)
+	// Validator returns the validator with the given id and its uptime in the given sealed epoch.
+	Validator(context.Context, uint64, uint64) (*types.Validator, error)
+	// Epoch returns the snapshot of the given epoch, nil if the epoch is not sealed yet.
+	Epoch(context.Context, uint64) (*types.Epoch, error)
+	// Delegations returns the delegations of the account to all the validators.
+	Delegations(context.Context, common.Address) ([]types.Delegation, error)
 	// SendSignedTransaction sends the signed transaction.
 	SendSignedTransaction(context.Context, *eth.Transaction) error
+	// TransactionReceipt returns the receipt of the transaction, or nil if it is not mined yet.
+	TransactionReceipt(context.Context, common.Hash) (*eth.Receipt, error)
 	// PendingNonceAt returns the nonce of the account at the given block.
 	PendingNonceAt(context.Context, common.Address) (uint64, error)
+	// NonceAt returns the nonce of the account at the latest block.
+	NonceAt(context.Context, common.Address) (uint64, error)
 	// SuggestGasPrice suggests a gas price.
 	SuggestGasPrice(context.Context) (*big.Int, error)
+	// BaseFee returns the base fee of the latest block, nil if the chain does not support London.
+	BaseFee(context.Context) (*big.Int, error)
+	// FeeHistory returns the base fees and the priority fee percentiles of the latest blocks.
+	FeeHistory(context.Context, uint64, []float64) (*ethereum.FeeHistory, error)
+	// EstimateGas estimates the gas needed to execute the given call.
+	EstimateGas(context.Context, ethereum.CallMsg) (uint64, error)
+	// Erc20Decimals returns the number of decimals of the erc20 token.
+	Erc20Decimals(context.Context, common.Address) (uint8, error)
 	// NetworkID returns the network ID.
 	NetworkID(context.Context) (*big.Int, error)
 	// AccountBalance returns the balance of the account.
 	AccountBalance(context.Context, common.Address) (*hexutil.Big, error)
 	// MazePlayerPosition returns the position of the player in the maze.
 	MazePlayerPosition(context.Context, common.Address, common.Address) (uint16, error)
+	// IsValidSignature verifies the signature of the hash by the EIP-1271 contract call.
+	IsValidSignature(context.Context, common.Address, common.Hash, []byte) (bool, error)
 	// Close closes the RPC client.
 	Close()
 }
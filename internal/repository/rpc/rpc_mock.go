@@ -10,6 +10,7 @@ import (
 	big "math/big"
 	reflect "reflect"
 
+	ethereum "github.com/ethereum/go-ethereum"
 	common "github.com/ethereum/go-ethereum/common"
 	hexutil "github.com/ethereum/go-ethereum/common/hexutil"
 	types0 "github.com/ethereum/go-ethereum/core/types"
@@ -54,6 +55,21 @@ func (mr *MockRpcMockRecorder) AccountBalance(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountBalance", reflect.TypeOf((*MockRpc)(nil).AccountBalance), arg0, arg1)
 }
 
+// BaseFee mocks base method.
+func (m *MockRpc) BaseFee(arg0 context.Context) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseFee", arg0)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BaseFee indicates an expected call of BaseFee.
+func (mr *MockRpcMockRecorder) BaseFee(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseFee", reflect.TypeOf((*MockRpc)(nil).BaseFee), arg0)
+}
+
 // BlockByNumber mocks base method.
 func (m *MockRpc) BlockByNumber(arg0 context.Context, arg1 uint64) (*types.Block, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +97,111 @@ func (mr *MockRpcMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRpc)(nil).Close))
 }
 
+// CurrentSealedEpoch mocks base method.
+func (m *MockRpc) CurrentSealedEpoch(arg0 context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentSealedEpoch", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CurrentSealedEpoch indicates an expected call of CurrentSealedEpoch.
+func (mr *MockRpcMockRecorder) CurrentSealedEpoch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentSealedEpoch", reflect.TypeOf((*MockRpc)(nil).CurrentSealedEpoch), arg0)
+}
+
+// Delegations mocks base method.
+func (m *MockRpc) Delegations(arg0 context.Context, arg1 common.Address) ([]types.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delegations", arg0, arg1)
+	ret0, _ := ret[0].([]types.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delegations indicates an expected call of Delegations.
+func (mr *MockRpcMockRecorder) Delegations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delegations", reflect.TypeOf((*MockRpc)(nil).Delegations), arg0, arg1)
+}
+
+// Epoch mocks base method.
+func (m *MockRpc) Epoch(arg0 context.Context, arg1 uint64) (*types.Epoch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Epoch", arg0, arg1)
+	ret0, _ := ret[0].(*types.Epoch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Epoch indicates an expected call of Epoch.
+func (mr *MockRpcMockRecorder) Epoch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Epoch", reflect.TypeOf((*MockRpc)(nil).Epoch), arg0, arg1)
+}
+
+// Erc20Decimals mocks base method.
+func (m *MockRpc) Erc20Decimals(arg0 context.Context, arg1 common.Address) (uint8, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Erc20Decimals", arg0, arg1)
+	ret0, _ := ret[0].(uint8)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Erc20Decimals indicates an expected call of Erc20Decimals.
+func (mr *MockRpcMockRecorder) Erc20Decimals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Erc20Decimals", reflect.TypeOf((*MockRpc)(nil).Erc20Decimals), arg0, arg1)
+}
+
+// EstimateGas mocks base method.
+func (m *MockRpc) EstimateGas(arg0 context.Context, arg1 ethereum.CallMsg) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateGas", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas.
+func (mr *MockRpcMockRecorder) EstimateGas(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockRpc)(nil).EstimateGas), arg0, arg1)
+}
+
+// FeeHistory mocks base method.
+func (m *MockRpc) FeeHistory(arg0 context.Context, arg1 uint64, arg2 []float64) (*ethereum.FeeHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeeHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*ethereum.FeeHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FeeHistory indicates an expected call of FeeHistory.
+func (mr *MockRpcMockRecorder) FeeHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockRpc)(nil).FeeHistory), arg0, arg1, arg2)
+}
+
+// IsValidSignature mocks base method.
+func (m *MockRpc) IsValidSignature(arg0 context.Context, arg1 common.Address, arg2 common.Hash, arg3 []byte) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsValidSignature", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsValidSignature indicates an expected call of IsValidSignature.
+func (mr *MockRpcMockRecorder) IsValidSignature(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsValidSignature", reflect.TypeOf((*MockRpc)(nil).IsValidSignature), arg0, arg1, arg2, arg3)
+}
+
 // MazePlayerPosition mocks base method.
 func (m *MockRpc) MazePlayerPosition(arg0 context.Context, arg1, arg2 common.Address) (uint16, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +232,21 @@ func (mr *MockRpcMockRecorder) NetworkID(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkID", reflect.TypeOf((*MockRpc)(nil).NetworkID), arg0)
 }
 
+// NonceAt mocks base method.
+func (m *MockRpc) NonceAt(arg0 context.Context, arg1 common.Address) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NonceAt", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NonceAt indicates an expected call of NonceAt.
+func (mr *MockRpcMockRecorder) NonceAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NonceAt", reflect.TypeOf((*MockRpc)(nil).NonceAt), arg0, arg1)
+}
+
 // NumberOfValidators mocks base method.
 func (m *MockRpc) NumberOfValidators(arg0 context.Context) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -198,3 +334,48 @@ func (mr *MockRpcMockRecorder) TransactionByHash(arg0, arg1 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionByHash", reflect.TypeOf((*MockRpc)(nil).TransactionByHash), arg0, arg1)
 }
+
+// TransactionReceipt mocks base method.
+func (m *MockRpc) TransactionReceipt(arg0 context.Context, arg1 common.Hash) (*types0.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactionReceipt", arg0, arg1)
+	ret0, _ := ret[0].(*types0.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransactionReceipt indicates an expected call of TransactionReceipt.
+func (mr *MockRpcMockRecorder) TransactionReceipt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionReceipt", reflect.TypeOf((*MockRpc)(nil).TransactionReceipt), arg0, arg1)
+}
+
+// Validator mocks base method.
+func (m *MockRpc) Validator(arg0 context.Context, arg1, arg2 uint64) (*types.Validator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validator", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.Validator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Validator indicates an expected call of Validator.
+func (mr *MockRpcMockRecorder) Validator(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validator", reflect.TypeOf((*MockRpc)(nil).Validator), arg0, arg1, arg2)
+}
+
+// Validators mocks base method.
+func (m *MockRpc) Validators(arg0 context.Context, arg1 uint64) ([]types.Validator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validators", arg0, arg1)
+	ret0, _ := ret[0].([]types.Validator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Validators indicates an expected call of Validators.
+func (mr *MockRpcMockRecorder) Validators(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validators", reflect.TypeOf((*MockRpc)(nil).Validators), arg0, arg1)
+}
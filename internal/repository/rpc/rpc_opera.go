@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"sync"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -42,11 +43,35 @@ func (rpc *OperaRpc) PendingNonceAt(ctx context.Context, address common.Address)
 	return ethclient.NewClient(rpc.ftm).PendingNonceAt(ctx, address)
 }
 
+// NonceAt returns the nonce of the account at the latest block.
+func (rpc *OperaRpc) NonceAt(ctx context.Context, address common.Address) (uint64, error) {
+	return ethclient.NewClient(rpc.ftm).NonceAt(ctx, address, nil)
+}
+
 // SuggestGasPrice suggests a gas price.
 func (rpc *OperaRpc) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	return ethclient.NewClient(rpc.ftm).SuggestGasPrice(ctx)
 }
 
+// BaseFee returns the base fee of the latest block, nil if the chain does not support London.
+func (rpc *OperaRpc) BaseFee(ctx context.Context) (*big.Int, error) {
+	header, err := ethclient.NewClient(rpc.ftm).HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return header.BaseFee, nil
+}
+
+// FeeHistory returns the base fees and the priority fee percentiles of the given number of latest blocks.
+func (rpc *OperaRpc) FeeHistory(ctx context.Context, blocks uint64, percentiles []float64) (*ethereum.FeeHistory, error) {
+	return ethclient.NewClient(rpc.ftm).FeeHistory(ctx, blocks, nil, percentiles)
+}
+
+// EstimateGas estimates the gas needed to execute the given call.
+func (rpc *OperaRpc) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return ethclient.NewClient(rpc.ftm).EstimateGas(ctx, msg)
+}
+
 // NetworkID returns the network ID.
 func (rpc *OperaRpc) NetworkID(ctx context.Context) (*big.Int, error) {
 	return ethclient.NewClient(rpc.ftm).NetworkID(ctx)
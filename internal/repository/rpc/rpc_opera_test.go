@@ -138,6 +138,95 @@ func TestOperaRpc_NumberOfValidators(t *testing.T) {
 	}
 }
 
+// Test that the validators of the latest sealed epoch are returned correctly.
+func TestOperaRpc_Validators(t *testing.T) {
+	rpc := createOperaRpc(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	epoch, err := rpc.CurrentSealedEpoch(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	validators, err := rpc.Validators(ctx, epoch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validators) == 0 {
+		t.Fatalf("no validators returned")
+	}
+
+	// the validator should be returned by its id as well
+	validator, err := rpc.Validator(ctx, uint64(validators[0].Id), epoch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if validator == nil || validator.Auth != validators[0].Auth {
+		t.Errorf("unexpected validator: %v", validator)
+	}
+}
+
+// Test that the latest sealed epoch is returned and the next one is not.
+func TestOperaRpc_Epoch(t *testing.T) {
+	rpc := createOperaRpc(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	number, err := rpc.CurrentSealedEpoch(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	epoch, err := rpc.Epoch(ctx, number)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if epoch == nil || uint64(epoch.Number) != number || epoch.Duration == 0 || len(epoch.ValidatorIds) == 0 {
+		t.Errorf("unexpected epoch: %v", epoch)
+	}
+
+	// the next epoch is not sealed yet
+	epoch, err = rpc.Epoch(ctx, number+1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if epoch != nil {
+		t.Errorf("expected nil, got %v", epoch)
+	}
+}
+
+// Test that the self stake of a validator is returned among the delegations of its auth address.
+func TestOperaRpc_Delegations(t *testing.T) {
+	rpc := createOperaRpc(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	epoch, err := rpc.CurrentSealedEpoch(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	validators, err := rpc.Validators(ctx, epoch)
+	if err != nil || len(validators) == 0 {
+		t.Fatalf("no validators returned: %v", err)
+	}
+
+	delegations, err := rpc.Delegations(ctx, validators[0].Auth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, d := range delegations {
+		if d.ValidatorId == validators[0].Id {
+			if d.Amount.ToInt().Cmp(validators[0].SelfStake.ToInt()) != 0 {
+				t.Errorf("expected self stake %s, got %s", validators[0].SelfStake.String(), d.Amount.String())
+			}
+			return
+		}
+	}
+	t.Errorf("self stake of validator %d not found in %v", validators[0].Id, delegations)
+}
+
 func createOperaRpc(t *testing.T) *OperaRpc {
 	rpc, err := NewOperaRpc(&config.Rpc{
 		OperaRpcUrl: "https://rpcapi.fantom.network", SfcAddress: "0xFC00FACE00000000000000000000000000000000"},
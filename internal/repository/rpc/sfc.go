@@ -2,34 +2,363 @@ package rpc
 
 import (
 	"context"
+	"fmt"
+	"ftm-explorer/internal/types"
 	"math/big"
+	"strings"
+	"time"
 
+	abi2 "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	client "github.com/ethereum/go-ethereum/rpc"
 )
 
+// kSfcAbi is the abi definition of the SFC contract functions read by the explorer.
+const kSfcAbi = `[
+  {"inputs":[],"name":"currentSealedEpoch","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"uint256","name":"epoch","type":"uint256"}],"name":"getEpochValidatorIDs","outputs":[{"internalType":"uint256[]","name":"","type":"uint256[]"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"uint256","name":"","type":"uint256"}],"name":"getValidator","outputs":[{"internalType":"uint256","name":"status","type":"uint256"},{"internalType":"uint256","name":"deactivatedTime","type":"uint256"},{"internalType":"uint256","name":"deactivatedEpoch","type":"uint256"},{"internalType":"uint256","name":"receivedStake","type":"uint256"},{"internalType":"uint256","name":"createdEpoch","type":"uint256"},{"internalType":"uint256","name":"createdTime","type":"uint256"},{"internalType":"address","name":"auth","type":"address"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"uint256","name":"validatorID","type":"uint256"}],"name":"getSelfStake","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"uint256","name":"epoch","type":"uint256"},{"internalType":"uint256","name":"validatorID","type":"uint256"}],"name":"getEpochAccumulatedUptime","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"uint256","name":"","type":"uint256"}],"name":"getEpochSnapshot","outputs":[{"internalType":"uint256","name":"endTime","type":"uint256"},{"internalType":"uint256","name":"epochFee","type":"uint256"},{"internalType":"uint256","name":"totalBaseRewardWeight","type":"uint256"},{"internalType":"uint256","name":"totalTxRewardWeight","type":"uint256"},{"internalType":"uint256","name":"baseRewardPerSecond","type":"uint256"},{"internalType":"uint256","name":"totalStake","type":"uint256"},{"internalType":"uint256","name":"totalSupply","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[],"name":"lastValidatorID","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"address","name":"","type":"address"},{"internalType":"uint256","name":"","type":"uint256"}],"name":"getStake","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"address","name":"delegator","type":"address"},{"internalType":"uint256","name":"toValidatorID","type":"uint256"}],"name":"pendingRewards","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"address","name":"delegator","type":"address"},{"internalType":"uint256","name":"validatorID","type":"uint256"}],"name":"rewardsStash","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"address","name":"","type":"address"},{"internalType":"uint256","name":"","type":"uint256"}],"name":"getLockupInfo","outputs":[{"internalType":"uint256","name":"lockedStake","type":"uint256"},{"internalType":"uint256","name":"fromEpoch","type":"uint256"},{"internalType":"uint256","name":"endTime","type":"uint256"},{"internalType":"uint256","name":"duration","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// sfcAbi is the parsed abi of the SFC contract, it is parsed only once as it never changes.
+var sfcAbi = mustParseAbi(kSfcAbi)
+
+// kSfcBatchSize is the maximal number of SFC contract calls sent in a single batch request.
+const kSfcBatchSize = 100
+
+// kSfcBatchTimeout is the timeout of a single batch request of SFC contract calls,
+// so the time allowed for the calls grows with the number of batches.
+const kSfcBatchTimeout = 5 * time.Second
+
+// status bits of the SFC validator, see the SFC contract
+const (
+	kSfcWithdrawnBit  = 1
+	kSfcOfflineBit    = 1 << 3
+	kSfcDoubleSignBit = 1 << 7
+)
+
+// sfcCall represents a single call of the SFC contract inside a batch.
+type sfcCall struct {
+	method string
+	args   []interface{}
+	out    hexutil.Bytes
+}
+
+// newSfcCall creates a new SFC contract call of the given method.
+func newSfcCall(method string, args ...interface{}) *sfcCall {
+	return &sfcCall{method: method, args: args}
+}
+
 // NumberOfValidators returns the number of validators.
 func (rpc *OperaRpc) NumberOfValidators(ctx context.Context) (uint64, error) {
-	// get latest sealed epoch
-	var epoch hexutil.Bytes
-	err := rpc.ftm.CallContext(ctx, &epoch, "eth_call", map[string]interface{}{
-		"to":   rpc.sfcAddress,
-		"data": hexutil.Bytes([]byte{0x7c, 0xac, 0xb1, 0xd6})}, "latest")
+	epoch, err := rpc.CurrentSealedEpoch(ctx)
 	if err != nil {
 		return 0, err
 	}
+	ids, err := rpc.epochValidatorIds(ctx, epoch)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(ids)), nil
+}
 
-	// get number of validators in the epoch
-	var out hexutil.Bytes
-	err = rpc.ftm.CallContext(ctx, &out, "eth_call", map[string]interface{}{
-		"to":   rpc.sfcAddress,
-		"data": hexutil.Bytes(append([]byte{0xb8, 0x8a, 0x37, 0xe2}, []byte(epoch)...))}, "latest")
+// CurrentSealedEpoch returns the number of the latest sealed epoch.
+func (rpc *OperaRpc) CurrentSealedEpoch(ctx context.Context) (uint64, error) {
+	call := newSfcCall("currentSealedEpoch")
+	out, err := rpc.callSfc(ctx, call)
 	if err != nil {
 		return 0, err
 	}
+	return out[0][0].(*big.Int).Uint64(), nil
+}
+
+// Validators returns the validators of the given sealed epoch.
+func (rpc *OperaRpc) Validators(ctx context.Context, epoch uint64) ([]types.Validator, error) {
+	ids, err := rpc.epochValidatorIds(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	return rpc.validators(ctx, epoch, ids)
+}
 
-	// the result is offset: 32 bytes, length: 32 bytes
-	// so we need to extract the last 32 bytes
-	val := new(big.Int).SetBytes(out[32:64])
+// Validator returns the validator with the given id and its uptime in the given sealed epoch,
+// nil if the validator does not exist.
+func (rpc *OperaRpc) Validator(ctx context.Context, id uint64, epoch uint64) (*types.Validator, error) {
+	validators, err := rpc.validators(ctx, epoch, []uint64{id})
+	if err != nil {
+		return nil, err
+	}
+	// validators which were never created have no auth address
+	if validators[0].Auth == (common.Address{}) {
+		return nil, nil
+	}
+	return &validators[0], nil
+}
+
+// Epoch returns the snapshot of the given epoch, nil if the epoch is not sealed yet.
+func (rpc *OperaRpc) Epoch(ctx context.Context, number uint64) (*types.Epoch, error) {
+	calls := []*sfcCall{
+		newSfcCall("getEpochSnapshot", new(big.Int).SetUint64(number)),
+		newSfcCall("getEpochValidatorIDs", new(big.Int).SetUint64(number)),
+	}
+	// the end of the previous epoch is needed for the duration of the epoch
+	if number > 0 {
+		calls = append(calls, newSfcCall("getEpochSnapshot", new(big.Int).SetUint64(number-1)))
+	}
+	out, err := rpc.callSfc(ctx, calls...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the snapshot of an epoch is stored when the epoch is sealed
+	snapshot := out[0]
+	endTime := snapshot[0].(*big.Int).Uint64()
+	if endTime == 0 {
+		return nil, nil
+	}
+
+	ids := out[1][0].([]*big.Int)
+	e := types.Epoch{
+		Number:              hexutil.Uint64(number),
+		EndTime:             hexutil.Uint64(endTime),
+		EpochFee:            hexutil.Big(*snapshot[1].(*big.Int)),
+		BaseRewardPerSecond: hexutil.Big(*snapshot[4].(*big.Int)),
+		TotalStake:          hexutil.Big(*snapshot[5].(*big.Int)),
+		TotalSupply:         hexutil.Big(*snapshot[6].(*big.Int)),
+		ValidatorIds:        make([]hexutil.Uint64, len(ids)),
+	}
+	for i, id := range ids {
+		e.ValidatorIds[i] = hexutil.Uint64(id.Uint64())
+	}
+	if number > 0 {
+		if prevEndTime := out[2][0].(*big.Int).Uint64(); prevEndTime > 0 && prevEndTime < endTime {
+			e.Duration = hexutil.Uint64(endTime - prevEndTime)
+		}
+	}
+	return &e, nil
+}
+
+// Delegations returns the delegations of the given account to all the validators,
+// including the delegations with no stake left, but with rewards to be claimed.
+func (rpc *OperaRpc) Delegations(ctx context.Context, delegator common.Address) ([]types.Delegation, error) {
+	out, err := rpc.callSfc(ctx, newSfcCall("lastValidatorID"))
+	if err != nil {
+		return nil, err
+	}
+	last := out[0][0].(*big.Int).Uint64()
+	if last == 0 {
+		return []types.Delegation{}, nil
+	}
 
-	return val.Uint64(), nil
+	// the SFC does not keep the list of delegations of an account,
+	// so the stake and the rewards are read for all the validators
+	const callsPerValidator = 3
+	calls := make([]*sfcCall, 0, last*callsPerValidator)
+	for id := uint64(1); id <= last; id++ {
+		vid := new(big.Int).SetUint64(id)
+		calls = append(calls,
+			newSfcCall("getStake", delegator, vid),
+			newSfcCall("pendingRewards", delegator, vid),
+			newSfcCall("rewardsStash", delegator, vid),
+		)
+	}
+	if out, err = rpc.callSfc(ctx, calls...); err != nil {
+		return nil, err
+	}
+
+	rv := make([]types.Delegation, 0)
+	for i := uint64(0); i < last; i++ {
+		stake := out[i*callsPerValidator][0].(*big.Int)
+		pending := out[i*callsPerValidator+1][0].(*big.Int)
+		stashed := out[i*callsPerValidator+2][0].(*big.Int)
+		if stake.Sign() == 0 && pending.Sign() == 0 && stashed.Sign() == 0 {
+			continue
+		}
+		rv = append(rv, types.Delegation{
+			Delegator:      delegator,
+			ValidatorId:    hexutil.Uint64(i + 1),
+			Amount:         hexutil.Big(*stake),
+			PendingRewards: hexutil.Big(*pending),
+			StashedRewards: hexutil.Big(*stashed),
+		})
+	}
+
+	// only the delegations with stake can be locked
+	calls = calls[:0]
+	staked := make([]*types.Delegation, 0, len(rv))
+	for i := range rv {
+		if rv[i].Amount.ToInt().Sign() > 0 {
+			calls = append(calls, newSfcCall("getLockupInfo", delegator, new(big.Int).SetUint64(uint64(rv[i].ValidatorId))))
+			staked = append(staked, &rv[i])
+		}
+	}
+	if len(calls) == 0 {
+		return rv, nil
+	}
+	if out, err = rpc.callSfc(ctx, calls...); err != nil {
+		return nil, err
+	}
+	for i, d := range staked {
+		lockup := out[i]
+		if locked := lockup[0].(*big.Int); locked.Sign() > 0 {
+			d.Lockup = &types.Lockup{
+				LockedStake: hexutil.Big(*locked),
+				FromEpoch:   hexutil.Uint64(lockup[1].(*big.Int).Uint64()),
+				EndTime:     hexutil.Uint64(lockup[2].(*big.Int).Uint64()),
+				Duration:    hexutil.Uint64(lockup[3].(*big.Int).Uint64()),
+			}
+		}
+	}
+	return rv, nil
+}
+
+// epochValidatorIds returns the ids of the validators of the given sealed epoch.
+func (rpc *OperaRpc) epochValidatorIds(ctx context.Context, epoch uint64) ([]uint64, error) {
+	out, err := rpc.callSfc(ctx, newSfcCall("getEpochValidatorIDs", new(big.Int).SetUint64(epoch)))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := out[0][0].([]*big.Int)
+	rv := make([]uint64, len(ids))
+	for i, id := range ids {
+		rv[i] = id.Uint64()
+	}
+	return rv, nil
+}
+
+// validators returns the validators with the given ids and their uptime in the given sealed epoch.
+// All the validators are read in a single series of batched calls.
+func (rpc *OperaRpc) validators(ctx context.Context, epoch uint64, ids []uint64) ([]types.Validator, error) {
+	if len(ids) == 0 {
+		return []types.Validator{}, nil
+	}
+
+	// each validator needs its info, self stake and the accumulated uptime
+	// at the end of the epoch and at the end of the previous epoch
+	const callsPerValidator = 4
+	calls := make([]*sfcCall, 0, len(ids)*callsPerValidator)
+	for _, id := range ids {
+		vid := new(big.Int).SetUint64(id)
+		prev := new(big.Int)
+		if epoch > 0 {
+			prev.SetUint64(epoch - 1)
+		}
+		calls = append(calls,
+			newSfcCall("getValidator", vid),
+			newSfcCall("getSelfStake", vid),
+			newSfcCall("getEpochAccumulatedUptime", new(big.Int).SetUint64(epoch), vid),
+			newSfcCall("getEpochAccumulatedUptime", prev, vid),
+		)
+	}
+	out, err := rpc.callSfc(ctx, calls...)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := make([]types.Validator, len(ids))
+	for i, id := range ids {
+		info := out[i*callsPerValidator]
+		selfStake := out[i*callsPerValidator+1][0].(*big.Int)
+		uptime := out[i*callsPerValidator+2][0].(*big.Int)
+		prevUptime := out[i*callsPerValidator+3][0].(*big.Int)
+
+		// the received stake includes the self stake
+		delegated := new(big.Int).Sub(info[3].(*big.Int), selfStake)
+		if delegated.Sign() < 0 {
+			delegated.SetInt64(0)
+		}
+		rv[i] = types.Validator{
+			Id:               hexutil.Uint64(id),
+			Auth:             info[6].(common.Address),
+			Status:           validatorStatus(info[0].(*big.Int).Uint64()),
+			SelfStake:        hexutil.Big(*selfStake),
+			DelegatedStake:   hexutil.Big(*delegated),
+			CreatedEpoch:     hexutil.Uint64(info[4].(*big.Int).Uint64()),
+			CreatedTime:      hexutil.Uint64(info[5].(*big.Int).Uint64()),
+			DeactivatedEpoch: hexutil.Uint64(info[2].(*big.Int).Uint64()),
+			DeactivatedTime:  hexutil.Uint64(info[1].(*big.Int).Uint64()),
+		}
+		if uptime.Cmp(prevUptime) > 0 {
+			rv[i].Uptime = hexutil.Uint64(new(big.Int).Sub(uptime, prevUptime).Uint64())
+		}
+	}
+	return rv, nil
+}
+
+// callSfc executes the given calls of the SFC contract in batches of up to kSfcBatchSize calls
+// and returns the unpacked outputs of the calls. Each batch is limited by kSfcBatchTimeout.
+func (rpc *OperaRpc) callSfc(ctx context.Context, calls ...*sfcCall) ([][]interface{}, error) {
+	batch := make([]client.BatchElem, len(calls))
+	for i, call := range calls {
+		data, err := sfcAbi.Pack(call.method, call.args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack %s call: %v", call.method, err)
+		}
+		batch[i] = client.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{map[string]interface{}{
+				"to":   rpc.sfcAddress,
+				"data": hexutil.Bytes(data),
+			}, "latest"},
+			Result: &call.out,
+		}
+	}
+	for from := 0; from < len(batch); from += kSfcBatchSize {
+		to := from + kSfcBatchSize
+		if to > len(batch) {
+			to = len(batch)
+		}
+		batchCtx, cancel := context.WithTimeout(ctx, kSfcBatchTimeout)
+		err := rpc.ftm.BatchCallContext(batchCtx, batch[from:to])
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rv := make([][]interface{}, len(calls))
+	for i, call := range calls {
+		if batch[i].Error != nil {
+			return nil, fmt.Errorf("failed to call %s: %v", call.method, batch[i].Error)
+		}
+		out, err := sfcAbi.Unpack(call.method, call.out)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack %s output: %v", call.method, err)
+		}
+		rv[i] = out
+	}
+	return rv, nil
+}
+
+// mustParseAbi parses the given abi definition, it panics if the definition is invalid.
+func mustParseAbi(definition string) abi2.ABI {
+	abi, err := abi2.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid abi definition; %v", err))
+	}
+	return abi
+}
+
+// validatorStatus returns the status of the validator from its SFC status bits.
+func validatorStatus(status uint64) types.ValidatorStatus {
+	switch {
+	case status&kSfcDoubleSignBit != 0:
+		return types.ValidatorStatusCheater
+	case status&kSfcWithdrawnBit != 0:
+		return types.ValidatorStatusWithdrawn
+	case status&kSfcOfflineBit != 0:
+		return types.ValidatorStatusOffline
+	default:
+		return types.ValidatorStatusActive
+	}
 }
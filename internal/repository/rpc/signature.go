@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	abi2 "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	client "github.com/ethereum/go-ethereum/rpc"
+)
+
+// kEip1271MagicValue is the value returned by isValidSignature for valid signatures.
+var kEip1271MagicValue = []byte{0x16, 0x26, 0xba, 0x7e}
+
+// kEip1271Abi is the abi definition of the EIP-1271 isValidSignature function.
+const kEip1271Abi = `[{
+    "inputs": [
+      {
+        "internalType": "bytes32",
+        "name": "hash",
+        "type": "bytes32"
+      },
+      {
+        "internalType": "bytes",
+        "name": "signature",
+        "type": "bytes"
+      }
+    ],
+    "name": "isValidSignature",
+    "outputs": [
+      {
+        "internalType": "bytes4",
+        "name": "magicValue",
+        "type": "bytes4"
+      }
+    ],
+    "stateMutability": "view",
+    "type": "function"
+  }]`
+
+// IsValidSignature returns true if the given contract accepts the signature of the given hash
+// by the EIP-1271 isValidSignature call. Accounts without code never accept any signature.
+func (rpc *OperaRpc) IsValidSignature(ctx context.Context, contract common.Address, hash common.Hash, signature []byte) (bool, error) {
+	abi, err := abi2.JSON(strings.NewReader(kEip1271Abi))
+	if err != nil {
+		return false, err
+	}
+	data, err := abi.Pack("isValidSignature", hash, signature)
+	if err != nil {
+		return false, err
+	}
+
+	output, err := ethclient.NewClient(rpc.ftm).CallContract(ctx, ethereum.CallMsg{
+		To:   &contract,
+		Data: data,
+	}, nil)
+	if err != nil {
+		// contracts usually revert on invalid signatures
+		var de client.DataError
+		if errors.As(err, &de) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// the bytes4 value is padded to 32 bytes, accounts without code return nothing
+	return len(output) == 32 && bytes.Equal(output[:4], kEip1271MagicValue), nil
+}
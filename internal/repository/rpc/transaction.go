@@ -2,9 +2,11 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"ftm-explorer/internal/types"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth "github.com/ethereum/go-ethereum/core/types"
@@ -48,6 +50,18 @@ func (rpc *OperaRpc) TransactionByHash(ctx context.Context, hash common.Hash) (*
 	return &trx, nil
 }
 
+// TransactionReceipt returns the receipt of the transaction, or nil if it is not mined yet.
+func (rpc *OperaRpc) TransactionReceipt(ctx context.Context, hash common.Hash) (*eth.Receipt, error) {
+	rec, err := ethclient.NewClient(rpc.ftm).TransactionReceipt(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rec, nil
+}
+
 // SendSignedTransaction sends the signed transaction.
 func (rpc *OperaRpc) SendSignedTransaction(ctx context.Context, tx *eth.Transaction) error {
 	if err := ethclient.NewClient(rpc.ftm).SendTransaction(ctx, tx); err != nil {
@@ -1,6 +1,14 @@
 package repository
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"ftm-explorer/internal/types"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
 
 // GetNumberOfValidators returns the number of validators.
 func (r *Repository) GetNumberOfValidators() (uint64, error) {
@@ -8,3 +16,152 @@ func (r *Repository) GetNumberOfValidators() (uint64, error) {
 	defer cancel()
 	return r.rpc.NumberOfValidators(ctx)
 }
+
+// GetValidators returns the validators of the latest sealed epoch.
+// The validators are read from the SFC contract once per sealed epoch.
+func (r *Repository) GetValidators() ([]types.Validator, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+
+	epoch, err := r.sealedEpoch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.getEpochValidators(ctx, epoch)
+}
+
+// GetValidator returns the validator with the given id, nil if it does not exist.
+// The uptime of the validator is reported for the latest sealed epoch.
+func (r *Repository) GetValidator(id uint64) (*types.Validator, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+
+	epoch, err := r.sealedEpoch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := r.getEpochValidators(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	for i := range validators {
+		if uint64(validators[i].Id) == id {
+			return &validators[i], nil
+		}
+	}
+
+	// the validator is not in the validator set of the epoch, e.g. it has been deactivated
+	return r.rpc.Validator(ctx, id, epoch)
+}
+
+// GetEpoch returns the sealed epoch with the given number, or the latest sealed epoch
+// if the number is nil. It returns nil if the epoch is not sealed yet.
+func (r *Repository) GetEpoch(number *uint64) (*types.Epoch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+
+	var epoch uint64
+	if number != nil {
+		epoch = *number
+	} else {
+		sealed, err := r.sealedEpoch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		epoch = sealed
+	}
+
+	// sealed epochs never change, so they can be cached
+	if e, ok := r.sfcCache.Epoch(epoch); ok {
+		return e, nil
+	}
+	e, err := r.rpc.Epoch(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		r.sfcCache.AddEpoch(e)
+	}
+	return e, nil
+}
+
+// GetDelegations returns the delegations of the account to all the validators.
+// The delegations are cached for the latest sealed epoch for up to kDelegationsCacheTtl,
+// so all the validators are scanned at most once per the ttl for the account,
+// e.g. for both delegations and staking rewards.
+func (r *Repository) GetDelegations(addr common.Address) ([]types.Delegation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	epoch, err := r.sealedEpoch(ctx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	if delegations, ok := r.dlgCache.Get(addr, epoch); ok {
+		return delegations, nil
+	}
+
+	// concurrent reads of the same account share a single scan
+	res, err, _ := r.dlgGroup.Do(fmt.Sprintf("%s_%d", addr.Hex(), epoch), func() (interface{}, error) {
+		delegations, err := r.fetchDelegations(addr)
+		if err != nil {
+			return nil, err
+		}
+		r.dlgCache.Add(addr, epoch, delegations)
+		return delegations, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]types.Delegation), nil
+}
+
+// GetStakingRewards returns the rewards of all the delegations of the account.
+// The rewards are summed from the cached delegations of the account.
+func (r *Repository) GetStakingRewards(addr common.Address) (*types.StakingRewards, error) {
+	delegations, err := r.GetDelegations(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, stashed := new(big.Int), new(big.Int)
+	for _, d := range delegations {
+		pending.Add(pending, d.PendingRewards.ToInt())
+		stashed.Add(stashed, d.StashedRewards.ToInt())
+	}
+	return &types.StakingRewards{
+		Pending: hexutil.Big(*pending),
+		Stashed: hexutil.Big(*stashed),
+	}, nil
+}
+
+// fetchDelegations reads the delegations of the account from the SFC contract.
+// The scan is not limited by kRpcTimeout, each batch of its calls has its own timeout instead,
+// so the time allowed grows with the number of validators.
+func (r *Repository) fetchDelegations(addr common.Address) ([]types.Delegation, error) {
+	return r.rpc.Delegations(context.Background(), addr)
+}
+
+// sealedEpoch returns the number of the latest sealed epoch. The epoch preceding the epoch
+// of the latest observed block is the latest sealed one, so the SFC contract is asked
+// only if no block with known epoch was observed yet.
+func (r *Repository) sealedEpoch(ctx context.Context) (uint64, error) {
+	if blk := r.GetLatestObservedBlock(); blk != nil && blk.Epoch > 0 {
+		return uint64(blk.Epoch) - 1, nil
+	}
+	return r.rpc.CurrentSealedEpoch(ctx)
+}
+
+// getEpochValidators returns the validators of the given sealed epoch,
+// from the cache if they were already read for the epoch.
+func (r *Repository) getEpochValidators(ctx context.Context, epoch uint64) ([]types.Validator, error) {
+	if validators, ok := r.sfcCache.Validators(epoch); ok {
+		return validators, nil
+	}
+	validators, err := r.rpc.Validators(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	r.sfcCache.SetValidators(epoch, validators)
+	return validators, nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// IsValidSignature returns true if the given contract accepts the signature of the given hash
+// by the EIP-1271 isValidSignature call.
+func (r *Repository) IsValidSignature(contract common.Address, hash common.Hash, signature []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+	return r.rpc.IsValidSignature(ctx, contract, hash, signature)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AddSiweNonce adds a nonce of an issued sign-in message to the database.
+func (r *Repository) AddSiweNonce(nonce *types.SiweNonce) error {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.AddSiweNonce(ctx, nonce)
+}
+
+// UseSiweNonce marks the given nonce issued for the given address as used.
+// It returns nil if the nonce is unknown, expired or has already been used.
+func (r *Repository) UseSiweNonce(nonce string, address common.Address) (*types.SiweNonce, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.UseSiweNonce(ctx, nonce, address)
+}
+
+// AddSiweSession adds a new sign-in session to the database.
+func (r *Repository) AddSiweSession(session *types.SiweSession) error {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.AddSiweSession(ctx, session)
+}
+
+// GetSiweSession returns the active sign-in session with the given token hash.
+// It returns nil if there is no such session or the session has expired.
+func (r *Repository) GetSiweSession(tokenHash string) (*types.SiweSession, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.SiweSession(ctx, tokenHash)
+}
@@ -3,6 +3,9 @@ package repository
 import (
 	"context"
 	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // AddTokensRequest adds a new tokens request to the database.
@@ -32,3 +35,39 @@ func (r *Repository) GetLatestClaimedTokensRequests(ip string, from uint64) ([]t
 	defer cancel()
 	return r.db.LatestClaimedTokensRequests(ctx, ip, from)
 }
+
+// GetTokensRequest returns the tokens request with the given id.
+func (r *Repository) GetTokensRequest(id primitive.ObjectID) (*types.TokensRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.TokensRequest(ctx, id)
+}
+
+// GetTokensRequestClaims returns the latest claims of the given ip address including the failed ones.
+func (r *Repository) GetTokensRequestClaims(ip string, limit int64) ([]types.TokensRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.TokensRequestClaims(ctx, ip, limit)
+}
+
+// GetTokensRequestClaimTimes returns the times of claims of the given token made since the given time, the oldest first.
+// The erc20 is nil for the native token, the receiver is nil for claims to any receiver.
+func (r *Repository) GetTokensRequestClaimTimes(receiver *common.Address, erc20 *common.Address, from int64) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.TokensRequestClaimTimes(ctx, receiver, erc20, from)
+}
+
+// GetPendingTokensRequests returns the claims waiting for their transactions to be mined.
+func (r *Repository) GetPendingTokensRequests() ([]types.TokensRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.PendingTokensRequests(ctx)
+}
+
+// ReplaceTokensRequestTxHash updates the transaction hash of the claims whose transaction has been replaced.
+func (r *Repository) ReplaceTokensRequestTxHash(old common.Hash, new common.Hash) error {
+	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
+	defer cancel()
+	return r.db.ReplaceTokensRequestTxHash(ctx, old, new)
+}
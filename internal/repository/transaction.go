@@ -11,11 +11,39 @@ import (
 )
 
 // GetTransactionByHash returns the transaction identified by hash.
-// It will always fetch the transaction from the RPC.
+// If the transaction is not in the cache, it will be fetched from the RPC.
 func (r *Repository) GetTransactionByHash(hash common.Hash) (*types.Transaction, error) {
+	// try to get transaction from cache
+	trx, exists := r.trxCache.Get(hash)
+	if exists {
+		return trx, nil
+	}
+
+	return r.FetchTransactionByHash(hash)
+}
+
+// FetchTransactionByHash returns the transaction identified by hash.
+// This method will always fetch the transaction from the RPC. Transactions
+// already included in a block are added to the cache.
+func (r *Repository) FetchTransactionByHash(hash common.Hash) (*types.Transaction, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
 	defer cancel()
-	return r.rpc.TransactionByHash(ctx, hash)
+	trx, err := r.rpc.TransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// pending transactions are not cached, their receipt data are not final yet
+	if trx != nil && trx.BlockNumber != nil {
+		r.trxCache.Add(trx)
+	}
+
+	return trx, nil
+}
+
+// GetTransactionCacheStats returns usage statistics of the transactions cache.
+func (r *Repository) GetTransactionCacheStats() types.CacheStats {
+	return r.trxCache.Stats()
 }
 
 // GetTrxCount returns the number of transactions in the blockchain.
@@ -47,6 +75,14 @@ func (r *Repository) SendSignedTransaction(tx *eth.Transaction) error {
 	return r.rpc.SendSignedTransaction(ctx, tx)
 }
 
+// TransactionReceipt returns the receipt of the transaction, or nil if it is not mined yet.
+func (r *Repository) TransactionReceipt(hash common.Hash) (*eth.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kRpcTimeout)
+	defer cancel()
+
+	return r.rpc.TransactionReceipt(ctx, hash)
+}
+
 // AddTransactions adds transactions to the database.
 func (r *Repository) AddTransactions(txs []db_types.Transaction) error {
 	ctx, cancel := context.WithTimeout(context.Background(), kDbTimeout)
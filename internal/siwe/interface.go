@@ -0,0 +1,22 @@
+package siwe
+
+//go:generate mockgen -source=interface.go -destination=siwe_mock.go -package=siwe -mock_names=ISiwe=MockSiwe
+
+import (
+	"ftm-explorer/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ISiwe represents a Sign-In with Ethereum interface. It issues sign-in messages
+// and establishes sessions of the accounts which signed them.
+type ISiwe interface {
+	// Challenge returns a new sign-in message to be signed by the given address.
+	Challenge(common.Address) (string, error)
+
+	// SignIn verifies the signed sign-in message and returns the token of the established session.
+	SignIn(message string, signature []byte) (string, error)
+
+	// Session returns the active session identified by the given token.
+	Session(string) (*types.SiweSession, error)
+}
@@ -0,0 +1,227 @@
+package siwe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// kMessageVersion is the version of the EIP-4361 message format.
+	kMessageVersion = "1"
+
+	// kMessageHeaderSuffix is the suffix of the first line of the message following the domain.
+	kMessageHeaderSuffix = " wants you to sign in with your Ethereum account:"
+
+	// kMessageMinNonceLength is the minimal length of the message nonce.
+	kMessageMinNonceLength = 8
+)
+
+// Message represents an EIP-4361 Sign-In with Ethereum message.
+// See https://eips.ethereum.org/EIPS/eip-4361 for the message format.
+type Message struct {
+	Domain         string
+	Address        common.Address
+	Statement      string
+	Uri            string
+	Version        string
+	ChainId        uint64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestId      string
+	Resources      []string
+}
+
+// String formats the message to be signed by the user.
+func (m *Message) String() string {
+	lines := []string{
+		m.Domain + kMessageHeaderSuffix,
+		m.Address.Hex(),
+		"",
+	}
+	if m.Statement != "" {
+		lines = append(lines, m.Statement)
+	}
+	lines = append(lines,
+		"",
+		"URI: "+m.Uri,
+		"Version: "+m.Version,
+		"Chain ID: "+strconv.FormatUint(m.ChainId, 10),
+		"Nonce: "+m.Nonce,
+		"Issued At: "+m.IssuedAt.UTC().Format(time.RFC3339),
+	)
+	if m.ExpirationTime != nil {
+		lines = append(lines, "Expiration Time: "+m.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+	if m.NotBefore != nil {
+		lines = append(lines, "Not Before: "+m.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if m.RequestId != "" {
+		lines = append(lines, "Request ID: "+m.RequestId)
+	}
+	if len(m.Resources) > 0 {
+		lines = append(lines, "Resources:")
+		for _, r := range m.Resources {
+			lines = append(lines, "- "+r)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseMessage parses the given EIP-4361 message.
+func ParseMessage(text string) (*Message, error) {
+	p := messageParser{lines: strings.Split(text, "\n")}
+	m := &Message{}
+
+	// header and address
+	header := p.next()
+	if !strings.HasSuffix(header, kMessageHeaderSuffix) {
+		return nil, fmt.Errorf("invalid message header")
+	}
+	m.Domain = strings.TrimSuffix(header, kMessageHeaderSuffix)
+	if m.Domain == "" {
+		return nil, fmt.Errorf("missing domain")
+	}
+	address := p.next()
+	if !common.IsHexAddress(address) || common.HexToAddress(address).Hex() != address {
+		return nil, fmt.Errorf("address must be EIP-55 checksummed")
+	}
+	m.Address = common.HexToAddress(address)
+
+	// optional statement wrapped by empty lines
+	if p.next() != "" {
+		return nil, fmt.Errorf("missing empty line after address")
+	}
+	if statement := p.next(); statement != "" {
+		m.Statement = statement
+		if p.next() != "" {
+			return nil, fmt.Errorf("missing empty line after statement")
+		}
+	}
+
+	// required fields
+	var err error
+	if m.Uri, err = p.field("URI"); err != nil {
+		return nil, err
+	}
+	if m.Version, err = p.field("Version"); err != nil {
+		return nil, err
+	}
+	chainId, err := p.field("Chain ID")
+	if err != nil {
+		return nil, err
+	}
+	if m.ChainId, err = strconv.ParseUint(chainId, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid chain id; %v", err)
+	}
+	if m.Nonce, err = p.field("Nonce"); err != nil {
+		return nil, err
+	}
+	if !isValidNonce(m.Nonce) {
+		return nil, fmt.Errorf("nonce must be at least %d alphanumeric characters", kMessageMinNonceLength)
+	}
+	issuedAt, err := p.field("Issued At")
+	if err != nil {
+		return nil, err
+	}
+	if m.IssuedAt, err = time.Parse(time.RFC3339, issuedAt); err != nil {
+		return nil, fmt.Errorf("invalid issued at; %v", err)
+	}
+
+	// optional fields
+	if value, ok := p.optionalField("Expiration Time"); ok {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiration time; %v", err)
+		}
+		m.ExpirationTime = &t
+	}
+	if value, ok := p.optionalField("Not Before"); ok {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid not before; %v", err)
+		}
+		m.NotBefore = &t
+	}
+	if value, ok := p.optionalField("Request ID"); ok {
+		m.RequestId = value
+	}
+	if p.peek() == "Resources:" {
+		p.next()
+		for p.hasNext() {
+			resource, ok := strings.CutPrefix(p.next(), "- ")
+			if !ok {
+				return nil, fmt.Errorf("invalid resource")
+			}
+			m.Resources = append(m.Resources, resource)
+		}
+	}
+
+	if p.hasNext() {
+		return nil, fmt.Errorf("unexpected line: %s", p.peek())
+	}
+	return m, nil
+}
+
+// messageParser represents a state of a message parsing.
+type messageParser struct {
+	lines []string
+	pos   int
+}
+
+// hasNext returns true if there are lines left to be parsed.
+func (p *messageParser) hasNext() bool {
+	return p.pos < len(p.lines)
+}
+
+// peek returns the next line without consuming it, or an empty string at the end of the message.
+func (p *messageParser) peek() string {
+	if !p.hasNext() {
+		return ""
+	}
+	return p.lines[p.pos]
+}
+
+// next consumes the next line, or returns an empty string at the end of the message.
+func (p *messageParser) next() string {
+	line := p.peek()
+	p.pos++
+	return line
+}
+
+// field consumes the next line, which must be the field of the given name.
+func (p *messageParser) field(name string) (string, error) {
+	value, ok := p.optionalField(name)
+	if !ok {
+		return "", fmt.Errorf("missing %s", strings.ToLower(name))
+	}
+	return value, nil
+}
+
+// optionalField consumes the next line if it is the field of the given name.
+func (p *messageParser) optionalField(name string) (string, bool) {
+	value, ok := strings.CutPrefix(p.peek(), name+": ")
+	if !ok {
+		return "", false
+	}
+	p.pos++
+	return value, true
+}
+
+// isValidNonce returns true if the given nonce is long enough and alphanumeric.
+func isValidNonce(nonce string) bool {
+	if len(nonce) < kMessageMinNonceLength {
+		return false
+	}
+	for _, c := range nonce {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
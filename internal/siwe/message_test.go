@@ -0,0 +1,118 @@
+package siwe
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// kTestMessage is a sign-in message with all the fields.
+const kTestMessage = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Expiration Time: 2021-09-30T16:35:24Z
+Not Before: 2021-09-30T16:20:24Z
+Request ID: some-request
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+// Test that messages are parsed and formatted back.
+func TestMessage_ParseAndString(t *testing.T) {
+	msg, err := ParseMessage(kTestMessage)
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+
+	if msg.Domain != "service.invalid" || msg.Address != common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2") {
+		t.Errorf("unexpected domain or address: %s, %s", msg.Domain, msg.Address.Hex())
+	}
+	if msg.Uri != "https://service.invalid/login" || msg.Version != "1" || msg.ChainId != 1 || msg.Nonce != "32891756" {
+		t.Errorf("unexpected fields: %+v", msg)
+	}
+	if !msg.IssuedAt.Equal(time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC)) {
+		t.Errorf("unexpected issued at: %v", msg.IssuedAt)
+	}
+	if msg.ExpirationTime == nil || msg.NotBefore == nil || msg.RequestId != "some-request" || len(msg.Resources) != 2 {
+		t.Errorf("unexpected optional fields: %+v", msg)
+	}
+	if msg.String() != kTestMessage {
+		t.Errorf("expected formatted message to match, got:\n%s", msg.String())
+	}
+}
+
+// Test that messages without optional fields are parsed and formatted back.
+func TestMessage_ParseAndStringMinimal(t *testing.T) {
+	text := strings.Join([]string{
+		"localhost:16761 wants you to sign in with your Ethereum account:",
+		"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2",
+		"",
+		"",
+		"URI: http://localhost:16761",
+		"Version: 1",
+		"Chain ID: 4002",
+		"Nonce: abcdef0123456789",
+		"Issued At: 2023-01-01T00:00:00Z",
+	}, "\n")
+
+	msg, err := ParseMessage(text)
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	if msg.Statement != "" || msg.ExpirationTime != nil || msg.NotBefore != nil || msg.ChainId != 4002 {
+		t.Errorf("unexpected fields: %+v", msg)
+	}
+	if msg.String() != text {
+		t.Errorf("expected formatted message to match, got:\n%s", msg.String())
+	}
+}
+
+// Test that invalid messages are rejected.
+func TestMessage_ParseInvalid(t *testing.T) {
+	testCases := map[string]func(string) string{
+		"Header": func(s string) string {
+			return strings.Replace(s, "wants you to sign in", "wants you to log in", 1)
+		},
+		"NotChecksummedAddress": func(s string) string {
+			return strings.Replace(s, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", 1)
+		},
+		"ShortNonce": func(s string) string {
+			return strings.Replace(s, "Nonce: 32891756", "Nonce: 1234", 1)
+		},
+		"NonAlphanumericNonce": func(s string) string {
+			return strings.Replace(s, "Nonce: 32891756", "Nonce: 3289-1756", 1)
+		},
+		"MissingVersion": func(s string) string {
+			return strings.Replace(s, "Version: 1\n", "", 1)
+		},
+		"InvalidChainId": func(s string) string {
+			return strings.Replace(s, "Chain ID: 1", "Chain ID: one", 1)
+		},
+		"InvalidIssuedAt": func(s string) string {
+			return strings.Replace(s, "Issued At: 2021-09-30T16:25:24Z", "Issued At: yesterday", 1)
+		},
+		"InvalidResource": func(s string) string {
+			return s + "\nnot-a-resource"
+		},
+		"TrailingLine": func(s string) string {
+			return strings.Replace(s, "\nResources:", "\nUnknown: field\nResources:", 1)
+		},
+	}
+
+	for name, mutate := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseMessage(mutate(kTestMessage)); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
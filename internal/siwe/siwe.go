@@ -0,0 +1,212 @@
+package siwe
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"ftm-explorer/internal/auth"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/repository"
+	"ftm-explorer/internal/types"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// kNonceLength is the number of random bytes of message nonces.
+	kNonceLength = 16
+
+	// kSessionTokenLength is the number of random bytes of session tokens.
+	kSessionTokenLength = 32
+
+	// kClockSkew is the tolerated difference of clocks of the explorer instances.
+	kClockSkew = time.Minute
+)
+
+// Siwe represents a Sign-In with Ethereum service. Issued messages are bound to the address
+// by the nonce stored in the database, each of them can be used to sign in only once.
+type Siwe struct {
+	cfg  *config.Siwe
+	repo repository.IRepository
+
+	// verifier verifies signatures of both externally owned accounts and contract wallets.
+	verifier *auth.Verifier
+
+	// chainId caches the network id used when the chain id is not configured.
+	chainId atomic.Uint64
+
+	// now returns the current time, it is replaced in tests.
+	now func() time.Time
+}
+
+// NewSiwe creates a new Sign-In with Ethereum service.
+func NewSiwe(cfg *config.Siwe, repo repository.IRepository) *Siwe {
+	return &Siwe{
+		cfg:      cfg,
+		repo:     repo,
+		verifier: auth.NewVerifier(repo),
+		now:      time.Now,
+	}
+}
+
+// Challenge returns a new sign-in message to be signed by the given address.
+func (s *Siwe) Challenge(address common.Address) (string, error) {
+	chainId, err := s.chainID()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomHex(kNonceLength)
+	if err != nil {
+		return "", err
+	}
+
+	issuedAt := s.now().UTC().Truncate(time.Second)
+	expiresAt := issuedAt.Add(time.Duration(s.cfg.ChallengeTtl) * time.Second)
+	msg := Message{
+		Domain:         s.cfg.Domain,
+		Address:        address,
+		Statement:      s.cfg.Statement,
+		Uri:            s.cfg.Uri,
+		Version:        kMessageVersion,
+		ChainId:        chainId,
+		Nonce:          nonce,
+		IssuedAt:       issuedAt,
+		ExpirationTime: &expiresAt,
+	}
+
+	if err := s.repo.AddSiweNonce(&types.SiweNonce{
+		Nonce:     nonce,
+		Address:   address,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return "", fmt.Errorf("error storing sign-in nonce: %v", err)
+	}
+
+	return msg.String(), nil
+}
+
+// SignIn verifies the signed sign-in message and returns the token of the established session.
+func (s *Siwe) SignIn(message string, signature []byte) (string, error) {
+	msg, err := ParseMessage(message)
+	if err != nil {
+		return "", fmt.Errorf("invalid sign-in message; %v", err)
+	}
+	if err := s.validate(msg); err != nil {
+		return "", err
+	}
+
+	ok, err := s.verifier.VerifyMessage(message, msg.Address, signature)
+	if err != nil {
+		return "", fmt.Errorf("signature verification failed; %v", err)
+	}
+	if !ok {
+		return "", fmt.Errorf("signature does not match the address")
+	}
+
+	// the nonce is consumed only after the signature is verified, so it can not be burnt by others
+	nonce, err := s.repo.UseSiweNonce(msg.Nonce, msg.Address)
+	if err != nil {
+		return "", fmt.Errorf("error using sign-in nonce: %v", err)
+	}
+	if nonce == nil {
+		return "", fmt.Errorf("sign-in message is unknown, expired or already used")
+	}
+
+	token, err := randomHex(kSessionTokenLength)
+	if err != nil {
+		return "", err
+	}
+	now := s.now().UTC()
+	if err := s.repo.AddSiweSession(&types.SiweSession{
+		TokenHash: hashToken(token),
+		Address:   msg.Address,
+		ChainId:   msg.ChainId,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Duration(s.cfg.SessionTtl) * time.Second),
+	}); err != nil {
+		return "", fmt.Errorf("error storing session: %v", err)
+	}
+
+	return token, nil
+}
+
+// Session returns the active session identified by the given token.
+func (s *Siwe) Session(token string) (*types.SiweSession, error) {
+	session, err := s.repo.GetSiweSession(hashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("error getting session: %v", err)
+	}
+	if session == nil || !s.now().Before(session.ExpiresAt) {
+		return nil, fmt.Errorf("invalid or expired session")
+	}
+	return session, nil
+}
+
+// validate checks the given message was issued by this service and is valid at the moment.
+func (s *Siwe) validate(msg *Message) error {
+	if msg.Domain != s.cfg.Domain {
+		return fmt.Errorf("invalid domain %s", msg.Domain)
+	}
+	if msg.Uri != s.cfg.Uri {
+		return fmt.Errorf("invalid uri %s", msg.Uri)
+	}
+	if msg.Version != kMessageVersion {
+		return fmt.Errorf("unsupported version %s", msg.Version)
+	}
+
+	chainId, err := s.chainID()
+	if err != nil {
+		return err
+	}
+	if msg.ChainId != chainId {
+		return fmt.Errorf("invalid chain id %d", msg.ChainId)
+	}
+
+	now := s.now()
+	if msg.IssuedAt.After(now.Add(kClockSkew)) {
+		return fmt.Errorf("sign-in message issued in the future")
+	}
+	if msg.ExpirationTime == nil || !now.Before(*msg.ExpirationTime) {
+		return fmt.Errorf("sign-in message expired")
+	}
+	if msg.NotBefore != nil && now.Before(*msg.NotBefore) {
+		return fmt.Errorf("sign-in message not valid yet")
+	}
+	return nil
+}
+
+// chainID returns the chain id of sign-in messages. If it is not configured,
+// the network id of the connected node is used.
+func (s *Siwe) chainID() (uint64, error) {
+	if s.cfg.ChainId != 0 {
+		return s.cfg.ChainId, nil
+	}
+	if id := s.chainId.Load(); id != 0 {
+		return id, nil
+	}
+
+	id, err := s.repo.NetworkID()
+	if err != nil {
+		return 0, fmt.Errorf("error getting network id: %v", err)
+	}
+	s.chainId.Store(id.Uint64())
+	return id.Uint64(), nil
+}
+
+// randomHex returns hex encoded random bytes of the given length.
+func randomHex(length int) (string, error) {
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("error generating random bytes: %v", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashToken returns the hash of the given session token, which is stored instead of the token.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
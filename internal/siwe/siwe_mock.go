@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package siwe is a generated GoMock package.
+package siwe
+
+import (
+	types "ftm-explorer/internal/types"
+	reflect "reflect"
+
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSiwe is a mock of ISiwe interface.
+type MockSiwe struct {
+	ctrl     *gomock.Controller
+	recorder *MockSiweMockRecorder
+}
+
+// MockSiweMockRecorder is the mock recorder for MockSiwe.
+type MockSiweMockRecorder struct {
+	mock *MockSiwe
+}
+
+// NewMockSiwe creates a new mock instance.
+func NewMockSiwe(ctrl *gomock.Controller) *MockSiwe {
+	mock := &MockSiwe{ctrl: ctrl}
+	mock.recorder = &MockSiweMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSiwe) EXPECT() *MockSiweMockRecorder {
+	return m.recorder
+}
+
+// Challenge mocks base method.
+func (m *MockSiwe) Challenge(arg0 common.Address) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Challenge", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Challenge indicates an expected call of Challenge.
+func (mr *MockSiweMockRecorder) Challenge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Challenge", reflect.TypeOf((*MockSiwe)(nil).Challenge), arg0)
+}
+
+// Session mocks base method.
+func (m *MockSiwe) Session(arg0 string) (*types.SiweSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Session", arg0)
+	ret0, _ := ret[0].(*types.SiweSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Session indicates an expected call of Session.
+func (mr *MockSiweMockRecorder) Session(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Session", reflect.TypeOf((*MockSiwe)(nil).Session), arg0)
+}
+
+// SignIn mocks base method.
+func (m *MockSiwe) SignIn(message string, signature []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", message, signature)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockSiweMockRecorder) SignIn(message, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockSiwe)(nil).SignIn), message, signature)
+}
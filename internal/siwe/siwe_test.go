@@ -0,0 +1,223 @@
+package siwe
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"ftm-explorer/internal/auth"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/repository"
+	"ftm-explorer/internal/types"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/mock/gomock"
+)
+
+// kTestPrivateKey is the private key of the test account.
+const kTestPrivateKey = "bb39aa88008bc6260ff9ebc816178c47a01c44efe55810ea1f271c00f5878812"
+
+// Test that the issued message can be used to sign in once.
+func TestSiwe_SignIn(t *testing.T) {
+	s, repo := createSiwe(t)
+	key, address := testAccount(t)
+
+	// issue the message
+	var nonce *types.SiweNonce
+	repo.EXPECT().AddSiweNonce(gomock.Any()).DoAndReturn(func(n *types.SiweNonce) error {
+		nonce = n
+		return nil
+	})
+	message, err := s.Challenge(address)
+	if err != nil {
+		t.Fatalf("failed to issue message: %v", err)
+	}
+	msg, err := ParseMessage(message)
+	if err != nil {
+		t.Fatalf("failed to parse issued message: %v", err)
+	}
+	if msg.Address != address || msg.Nonce != nonce.Nonce || msg.ChainId != 4002 || msg.Domain != "explorer.test" {
+		t.Fatalf("unexpected issued message: %+v", msg)
+	}
+	if !msg.ExpirationTime.Equal(nonce.ExpiresAt) || nonce.Address != address {
+		t.Fatalf("unexpected stored nonce: %+v", nonce)
+	}
+
+	// sign in, the nonce is consumed
+	var session *types.SiweSession
+	repo.EXPECT().UseSiweNonce(nonce.Nonce, address).Return(nonce, nil)
+	repo.EXPECT().AddSiweSession(gomock.Any()).DoAndReturn(func(ss *types.SiweSession) error {
+		session = ss
+		return nil
+	})
+	token, err := s.SignIn(message, sign(t, message, key))
+	if err != nil {
+		t.Fatalf("failed to sign in: %v", err)
+	}
+	if session.Address != address || session.TokenHash != hashToken(token) || session.TokenHash == token {
+		t.Fatalf("unexpected stored session: %+v", session)
+	}
+
+	// the session can be used
+	repo.EXPECT().GetSiweSession(hashToken(token)).Return(session, nil)
+	if active, err := s.Session(token); err != nil || active.Address != address {
+		t.Fatalf("expected active session, got %+v; %v", active, err)
+	}
+
+	// the message can not be used again
+	repo.EXPECT().UseSiweNonce(nonce.Nonce, address).Return(nil, nil)
+	if _, err := s.SignIn(message, sign(t, message, key)); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Fatalf("expected error for reused message, got %v", err)
+	}
+}
+
+// Test that contract wallets sign in by the EIP-1271 signature verification.
+func TestSiwe_SignInContractWallet(t *testing.T) {
+	s, repo := createSiwe(t)
+	ownerKey, _ := testAccount(t)
+	wallet := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+	var nonce *types.SiweNonce
+	repo.EXPECT().AddSiweNonce(gomock.Any()).DoAndReturn(func(n *types.SiweNonce) error {
+		nonce = n
+		return nil
+	})
+	message, err := s.Challenge(wallet)
+	if err != nil {
+		t.Fatalf("failed to issue message: %v", err)
+	}
+
+	signature := sign(t, message, ownerKey)
+	repo.EXPECT().IsValidSignature(wallet, gomock.Any(), signature).Return(true, nil)
+	repo.EXPECT().UseSiweNonce(nonce.Nonce, wallet).Return(nonce, nil)
+	repo.EXPECT().AddSiweSession(gomock.Any()).Return(nil)
+	if _, err := s.SignIn(message, signature); err != nil {
+		t.Fatalf("failed to sign in: %v", err)
+	}
+}
+
+// Test that invalid messages are rejected before the nonce is consumed.
+func TestSiwe_SignInInvalid(t *testing.T) {
+	s, repo := createSiwe(t)
+	key, address := testAccount(t)
+	otherKey, _ := crypto.GenerateKey()
+
+	now := s.now()
+	expires := now.Add(time.Minute)
+	valid := Message{
+		Domain:         "explorer.test",
+		Address:        address,
+		Uri:            "https://explorer.test",
+		Version:        "1",
+		ChainId:        4002,
+		Nonce:          "0123456789abcdef",
+		IssuedAt:       now,
+		ExpirationTime: &expires,
+	}
+
+	expired := now.Add(-time.Second)
+	testCases := []struct {
+		name   string
+		mutate func(*Message)
+		key    *ecdsa.PrivateKey
+	}{
+		{"Domain", func(m *Message) { m.Domain = "phishing.test" }, key},
+		{"Uri", func(m *Message) { m.Uri = "https://phishing.test" }, key},
+		{"ChainId", func(m *Message) { m.ChainId = 250 }, key},
+		{"Expired", func(m *Message) { m.ExpirationTime = &expired }, key},
+		{"NoExpiration", func(m *Message) { m.ExpirationTime = nil }, key},
+		{"IssuedInFuture", func(m *Message) { m.IssuedAt = now.Add(time.Hour) }, key},
+		{"NotBefore", func(m *Message) { m.NotBefore = &expires }, key},
+		{"OtherSigner", func(m *Message) {}, otherKey},
+	}
+
+	// the test account is not a contract wallet, signatures of other keys are rejected by EIP-1271 call
+	repo.EXPECT().IsValidSignature(address, gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := valid
+			tc.mutate(&msg)
+			text := msg.String()
+			if _, err := s.SignIn(text, sign(t, text, tc.key)); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+// Test that unknown and expired sessions are rejected.
+func TestSiwe_SessionInvalid(t *testing.T) {
+	s, repo := createSiwe(t)
+
+	repo.EXPECT().GetSiweSession(hashToken("unknown")).Return(nil, nil)
+	if _, err := s.Session("unknown"); err == nil {
+		t.Errorf("expected error for unknown session")
+	}
+
+	repo.EXPECT().GetSiweSession(hashToken("expired")).Return(&types.SiweSession{ExpiresAt: s.now().Add(-time.Second)}, nil)
+	if _, err := s.Session("expired"); err == nil {
+		t.Errorf("expected error for expired session")
+	}
+
+	repo.EXPECT().GetSiweSession(hashToken("failing")).Return(nil, fmt.Errorf("db down"))
+	if _, err := s.Session("failing"); err == nil {
+		t.Errorf("expected error for failing repository")
+	}
+}
+
+// Test that the network id is used when the chain id is not configured.
+func TestSiwe_ChainIdFromNetwork(t *testing.T) {
+	s, repo := createSiwe(t)
+	s.cfg.ChainId = 0
+
+	// the network id is resolved only once
+	repo.EXPECT().NetworkID().Return(big.NewInt(250), nil).Times(1)
+	for i := 0; i < 2; i++ {
+		if id, err := s.chainID(); err != nil || id != 250 {
+			t.Fatalf("expected chain id 250, got %d; %v", id, err)
+		}
+	}
+}
+
+// createSiwe creates a new sign-in service for testing.
+func createSiwe(t *testing.T) (*Siwe, *repository.MockRepository) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := repository.NewMockRepository(ctrl)
+	s := NewSiwe(&config.Siwe{
+		Domain:       "explorer.test",
+		Uri:          "https://explorer.test",
+		Statement:    "Sign in to the test explorer.",
+		ChainId:      4002,
+		ChallengeTtl: 300,
+		SessionTtl:   3600,
+	}, repo)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	s.now = func() time.Time { return now }
+	return s, repo
+}
+
+// testAccount returns the private key and the address of the test account.
+func testAccount(t *testing.T) (*ecdsa.PrivateKey, common.Address) {
+	t.Helper()
+	key, err := crypto.HexToECDSA(kTestPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+// sign signs the given message using the given private key.
+func sign(t *testing.T, message string, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	signature, err := auth.SignMessage(message, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature
+}
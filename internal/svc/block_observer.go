@@ -7,16 +7,54 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // kObserverChainTimeOutDuration represents the timeout duration of the observer chain.
 const kObserverChainTimeOutDuration = 5 * time.Second
 
+// kObserverBlocksInFlightPerWorker represents the number of blocks per fetch worker
+// which may be in flight between their dispatch and commit.
+const kObserverBlocksInFlightPerWorker = 4
+
+// kObserverSealQueueSize represents the number of epochs waiting to be sealed.
+const kObserverSealQueueSize = 4
+
+// observedBlock represents a block travelling through the observer pipeline.
+type observedBlock struct {
+	// seq is the sequence number of the block, it is used to commit blocks in order.
+	seq uint64
+	// block is the observed block.
+	block *types.Block
+	// txs are the fetched transactions of the block.
+	txs []*types.Transaction
+	// dbTxs are the transactions prepared to be stored in the database.
+	dbTxs []db_types.Transaction
+	// accounts are the accounts involved in the block transactions.
+	accounts []common.Address
+	// gasUsage is the gas used by the block transactions per called contract method.
+	gasUsage []types.GasUsage
+}
+
 // blockObserver represents an observer of blockchain blocks.
+// Observed blocks are processed by a staged pipeline. Transactions are fetched
+// by a pool of workers, the fetched blocks are enriched with the data to be persisted
+// and finally committed into the repository strictly in the order they were observed.
 type blockObserver struct {
 	service
 	inBlocks <-chan *types.Block
 	sigClose chan struct{}
+	done     chan struct{}
+
+	// fetchWorkers is the number of workers fetching block transactions.
+	fetchWorkers int
+
+	// enrichWorkers is the number of workers enriching fetched blocks.
+	enrichWorkers int
+
+	// inFlight bounds the number of blocks dispatched into the pipeline and not committed yet.
+	// Blocks waiting for their predecessors to be committed hold their slot as well.
+	inFlight chan struct{}
 
 	// lastAggTime is the last time the aggregator was run.
 	lastAggTime uint64
@@ -24,16 +62,33 @@ type blockObserver struct {
 	// lastBlkTime is the last time a block was processed.
 	lastBlkTime uint64
 
+	// epoch is the epoch of the last committed block.
+	epoch uint64
+
+	// epochComplete indicates that the first block of the epoch was committed.
+	// The first observed epoch is usually observed only partially, so it is not sealed.
+	epochComplete bool
+
+	// sealQueue holds the epochs to be sealed. The epochs are sealed outside the commit stage,
+	// so the aggregations of the epoch blocks do not hold back the commits of new blocks.
+	sealQueue chan uint64
+
 	// timeOutDuration is the timeout duration of the observer chain.
 	timeOutDuration time.Duration
-
-	// aggMtx is a mutex used to synchronize access to the aggregator
-	aggMtx sync.Mutex
 }
 
 // newBlockObserver creates a new block observer.
 // It observes new blocks which are sent to the channel. It then processes them.
 func newBlockObserver(mgr *Manager, inBlocks <-chan *types.Block) *blockObserver {
+	// the defaults are applied by the configuration, every stage needs at least one worker
+	fetchWorkers, enrichWorkers := 1, 1
+	if mgr.cfg.Explorer.FetchWorkers > 0 {
+		fetchWorkers = int(mgr.cfg.Explorer.FetchWorkers)
+	}
+	if mgr.cfg.Explorer.EnrichWorkers > 0 {
+		enrichWorkers = int(mgr.cfg.Explorer.EnrichWorkers)
+	}
+
 	return &blockObserver{
 		service: service{
 			mgr:  mgr,
@@ -42,6 +97,11 @@ func newBlockObserver(mgr *Manager, inBlocks <-chan *types.Block) *blockObserver
 		},
 		inBlocks:        inBlocks,
 		sigClose:        make(chan struct{}, 1),
+		done:            make(chan struct{}),
+		fetchWorkers:    fetchWorkers,
+		enrichWorkers:   enrichWorkers,
+		inFlight:        make(chan struct{}, fetchWorkers*kObserverBlocksInFlightPerWorker),
+		sealQueue:       make(chan uint64, kObserverSealQueueSize),
 		timeOutDuration: kObserverChainTimeOutDuration,
 	}
 }
@@ -53,8 +113,10 @@ func (bs *blockObserver) start() {
 }
 
 // close stops the block observer.
+// It waits until all blocks already accepted by the pipeline are committed.
 func (bs *blockObserver) close() {
 	bs.sigClose <- struct{}{}
+	<-bs.done
 	bs.mgr.finished(bs)
 }
 
@@ -64,16 +126,37 @@ func (bs *blockObserver) name() string {
 }
 
 // execute executes the block observer.
+// It feeds observed blocks into the pipeline. The number of blocks in flight is bounded,
+// so a slow or stalled pipeline applies back-pressure onto the input blocks channel.
 func (bs *blockObserver) execute() {
-	wg := sync.WaitGroup{}
+	defer close(bs.done)
+
+	fetchQueue := make(chan *observedBlock, bs.fetchWorkers)
+	enrichQueue := make(chan *observedBlock, bs.enrichWorkers)
+	commitQueue := make(chan *observedBlock, bs.fetchWorkers+bs.enrichWorkers)
+
+	// start the pipeline stages
+	committed, sealed := make(chan struct{}), make(chan struct{})
+	bs.runStage(bs.fetchWorkers, fetchQueue, enrichQueue, bs.fetchTransactions)
+	bs.runStage(bs.enrichWorkers, enrichQueue, commitQueue, bs.enrichBlock)
+	go bs.commitBlocks(commitQueue, committed)
+	go bs.sealEpochs(sealed)
+
+	// closing the fetch queue drains the whole pipeline, the epochs are sealed after the last commit
+	defer func() {
+		close(fetchQueue)
+		<-committed
+		close(bs.sealQueue)
+		<-sealed
+	}()
+
 	ticker := time.NewTicker(bs.timeOutDuration)
 	defer ticker.Stop()
 
+	var seq uint64
 	for {
 		select {
 		case <-bs.sigClose:
-			// wait for all goroutines to finish, then return
-			wg.Wait()
 			return
 		case <-ticker.C:
 			// if the last block time is older than the timeout duration, set the idle flag
@@ -90,85 +173,76 @@ func (bs *blockObserver) execute() {
 			if bs.repo.IsIdle() {
 				bs.repo.SetIsIdle(false)
 			}
-			wg.Add(1)
-			go bs.processBlock(block, &wg)
+
+			// push the block into the pipeline, this blocks while too many blocks are in flight
+			bs.inFlight <- struct{}{}
+			fetchQueue <- &observedBlock{seq: seq, block: block}
+			seq++
 		}
 	}
 }
 
-// processBlock processes a block.
-func (bs *blockObserver) processBlock(block *types.Block, wg *sync.WaitGroup) {
-	defer wg.Done()
-
-	bs.log.Noticef("block observer processing block %d", block.Number)
-
-	// update latest observed block
-	if err := bs.repo.UpdateLatestObservedBlock(block); err != nil {
-		bs.log.Errorf("error updating latest observed block: %v", err)
-		return
+// runStage starts the given number of workers processing blocks from the input queue
+// and forwarding them into the output queue. The output queue is closed once
+// the input queue is closed and all the workers are done.
+func (bs *blockObserver) runStage(workers int, in <-chan *observedBlock, out chan<- *observedBlock, process func(*observedBlock)) {
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for ob := range in {
+				process(ob)
+				out <- ob
+			}
+		}()
 	}
 
-	// increment transaction count
-	if err := bs.repo.IncrementTrxCount(uint(len(block.Transactions))); err != nil {
-		bs.log.Errorf("error incrementing transaction count: %v", err)
-		return
-	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+}
 
-	// get block time rounded to nearest seconds
-	// in case it is slowing down the whole process too much, we can move it to a separate goroutine
-	resolution := types.AggResolutionSeconds
-	seconds := uint64(resolution.ToDuration())
-	aggTime := (uint64(block.Timestamp) / seconds) * seconds
+// commitBlocks commits processed blocks strictly in the order of their sequence numbers.
+// Blocks arriving out of order are held back until all their predecessors are committed.
+func (bs *blockObserver) commitBlocks(in <-chan *observedBlock, committed chan<- struct{}) {
+	defer close(committed)
 
-	// if the time is different from the last time the aggregator was run, run the aggregations
-	// we also get last 60 ticks (10 seconds each) to be used by the chart
-	// also wait for the latest block to be at least 1 second older than the aggregation time
-	// so that we don't miss any blocks
-	if aggTime != bs.lastAggTime && uint64(block.Timestamp) > aggTime+1 {
-		// lock the aggregator, so that only one goroutine can run it at a time
-		updateAggs := bs.aggMtx.TryLock()
-		if updateAggs {
-			// unlock the aggregator
-			defer bs.aggMtx.Unlock()
-
-			bs.lastAggTime = aggTime
-			txAggs, err := bs.repo.GetTrxCountAggByTimestamp(resolution, 60, &aggTime)
-			if err != nil {
-				bs.log.Errorf("error getting transaction count aggregation by timestamp: %v", err)
-				return
-			}
-			gasUsedAggs, err := bs.repo.GetGasUsedAggByTimestamp(resolution, 60, &aggTime)
-			if err != nil {
-				bs.log.Errorf("error getting gas used aggregation by timestamp: %v", err)
-				return
+	var next uint64
+	pending := make(map[uint64]*observedBlock)
+
+	for ob := range in {
+		pending[ob.seq] = ob
+		for {
+			blk, ok := pending[next]
+			if !ok {
+				break
 			}
-			bs.repo.SetTxCountPer10Secs(txAggs)
-			bs.repo.SetGasUsedPer10Secs(gasUsedAggs)
-			bs.log.Notice("aggregation data updated successfully")
+			delete(pending, next)
+			bs.commitBlock(blk)
+			<-bs.inFlight
+			next++
 		}
 	}
 
-	// store transactions
-	if bs.mgr.cfg.Explorer.IsPersisted {
-		bs.storeTransactions(block)
+	if len(pending) > 0 {
+		bs.log.Errorf("block observer stopped with %d uncommitted blocks", len(pending))
 	}
 }
 
-// storeTransactions stores transactions in the database.
-func (bs *blockObserver) storeTransactions(block *types.Block) {
-	var txs []db_types.Transaction
-	accounts := make(map[common.Address]bool)
-
-	if len(block.Transactions) == 0 {
-		bs.log.Noticef("no transactions to store in block %d", block.Number)
+// fetchTransactions fetches transactions of the observed block.
+// Fetched transactions populate the repository transactions cache. If the explorer data
+// is not persisted, they are fetched only if caching of observed transactions is enabled.
+func (bs *blockObserver) fetchTransactions(ob *observedBlock) {
+	explorer := bs.mgr.cfg.Explorer
+	if !explorer.IsPersisted && (!explorer.CacheObservedTrxs || explorer.TrxCacheSize == 0) {
 		return
 	}
 
-	for _, hash := range block.Transactions {
-		txAccounts := make(map[common.Address]bool)
-
-		// get transaction
-		tx, err := bs.repo.GetTransactionByHash(hash)
+	ob.txs = make([]*types.Transaction, 0, len(ob.block.Transactions))
+	for _, hash := range ob.block.Transactions {
+		tx, err := bs.repo.FetchTransactionByHash(hash)
 		if err != nil {
 			bs.log.Errorf("error getting transaction %s: %v", hash, err)
 			continue
@@ -177,9 +251,24 @@ func (bs *blockObserver) storeTransactions(block *types.Block) {
 			bs.log.Errorf("transaction %s not found", hash)
 			continue
 		}
+		ob.txs = append(ob.txs, tx)
+	}
+}
+
+// enrichBlock prepares transactions and accounts of the observed block to be stored in the database.
+func (bs *blockObserver) enrichBlock(ob *observedBlock) {
+	if !bs.mgr.cfg.Explorer.IsPersisted {
+		return
+	}
+
+	accounts := make(map[common.Address]bool)
+
+	for _, tx := range ob.txs {
+		txAccounts := make(map[common.Address]bool)
+
 		dbTx := db_types.Transaction{
 			Hash:      tx.Hash,
-			Timestamp: int64(block.Timestamp),
+			Timestamp: int64(ob.block.Timestamp),
 		}
 		// append sender address
 		txAccounts[tx.From] = true
@@ -227,23 +316,175 @@ func (bs *blockObserver) storeTransactions(block *types.Block) {
 		}
 
 		// append transaction to the list
-		txs = append(txs, dbTx)
+		ob.dbTxs = append(ob.dbTxs, dbTx)
+	}
+
+	for addr := range accounts {
+		ob.accounts = append(ob.accounts, addr)
+	}
+
+	ob.gasUsage = aggregateGasUsage(ob.txs)
+}
+
+// aggregateGasUsage returns the gas used by the given transactions per called contract method.
+// Contract creations and transactions without receipt are not included.
+func aggregateGasUsage(txs []*types.Transaction) []types.GasUsage {
+	type method struct {
+		contract common.Address
+		selector string
+	}
+	usage := make(map[method]*types.GasUsage)
+	keys := make([]method, 0)
+
+	for _, tx := range txs {
+		if tx.To == nil || tx.GasUsed == nil {
+			continue
+		}
+
+		selector := "0x"
+		if len(tx.Input) >= 4 {
+			selector = hexutil.Encode(tx.Input[:4])
+		}
+		key := method{contract: *tx.To, selector: selector}
+		u, ok := usage[key]
+		if !ok {
+			u = &types.GasUsage{Contract: key.contract, Selector: key.selector}
+			usage[key] = u
+			keys = append(keys, key)
+		}
+		u.GasUsed += uint64(*tx.GasUsed)
+		u.Calls++
+	}
+
+	res := make([]types.GasUsage, len(keys))
+	for i, key := range keys {
+		res[i] = *usage[key]
+	}
+	return res
+}
+
+// commitBlock commits the processed block into the repository.
+func (bs *blockObserver) commitBlock(ob *observedBlock) {
+	block := ob.block
+	bs.log.Noticef("block observer processing block %d", block.Number)
+
+	// update latest observed block
+	if err := bs.repo.UpdateLatestObservedBlock(block); err != nil {
+		bs.log.Errorf("error updating latest observed block: %v", err)
+		return
 	}
 
+	// increment transaction count
+	if err := bs.repo.IncrementTrxCount(uint(len(block.Transactions))); err != nil {
+		bs.log.Errorf("error incrementing transaction count: %v", err)
+		return
+	}
+
+	// update aggregations
+	bs.updateAggregations(block)
+
+	// seal the previous epoch once the first block of a new epoch is committed
+	bs.updateEpoch(block)
+
 	// store transactions
-	if err := bs.repo.AddTransactions(txs); err != nil {
+	if bs.mgr.cfg.Explorer.IsPersisted {
+		bs.storeTransactions(ob)
+		bs.storeGasUsage(ob)
+	}
+}
+
+// updateAggregations updates the aggregations if the given block crossed the aggregation time.
+func (bs *blockObserver) updateAggregations(block *types.Block) {
+	// get block time rounded to nearest seconds
+	resolution := types.AggResolutionSeconds
+	seconds := uint64(resolution.ToDuration())
+	aggTime := (uint64(block.Timestamp) / seconds) * seconds
+
+	// if the time is different from the last time the aggregator was run, run the aggregations
+	// we also get last 60 ticks (10 seconds each) to be used by the chart
+	// also wait for the latest block to be at least 1 second older than the aggregation time
+	// so that we don't miss any blocks
+	if aggTime == bs.lastAggTime || uint64(block.Timestamp) <= aggTime+1 {
+		return
+	}
+
+	bs.lastAggTime = aggTime
+	txAggs, err := bs.repo.GetTrxCountAggByTimestamp(resolution, 60, &aggTime)
+	if err != nil {
+		bs.log.Errorf("error getting transaction count aggregation by timestamp: %v", err)
+		return
+	}
+	gasUsedAggs, err := bs.repo.GetGasUsedAggByTimestamp(resolution, 60, &aggTime)
+	if err != nil {
+		bs.log.Errorf("error getting gas used aggregation by timestamp: %v", err)
+		return
+	}
+	bs.repo.SetTxCountPer10Secs(txAggs)
+	bs.repo.SetGasUsedPer10Secs(gasUsedAggs)
+	bs.log.Notice("aggregation data updated successfully")
+}
+
+// updateEpoch queues the epoch of the previously committed blocks to be sealed if the given block starts a new epoch.
+// The commit stage is never blocked by sealing, the epoch is skipped if too many epochs wait to be sealed.
+func (bs *blockObserver) updateEpoch(block *types.Block) {
+	epoch := uint64(block.Epoch)
+	if epoch <= bs.epoch {
+		return
+	}
+
+	if bs.epochComplete {
+		select {
+		case bs.sealQueue <- bs.epoch:
+		default:
+			bs.log.Errorf("too many epochs waiting to be sealed, epoch %d skipped", bs.epoch)
+		}
+	}
+
+	// the new epoch is complete if the previous epoch was observed as well
+	bs.epochComplete = bs.epoch != 0
+	bs.epoch = epoch
+}
+
+// sealEpochs seals the queued epochs until the seal queue is closed.
+func (bs *blockObserver) sealEpochs(sealed chan<- struct{}) {
+	defer close(sealed)
+
+	for epoch := range bs.sealQueue {
+		if err := bs.repo.SealEpoch(epoch); err != nil {
+			bs.log.Errorf("error sealing epoch %d: %v", epoch, err)
+			continue
+		}
+		bs.log.Noticef("epoch %d sealed", epoch)
+	}
+}
+
+// storeGasUsage stores the gas usage of the processed block in the database.
+func (bs *blockObserver) storeGasUsage(ob *observedBlock) {
+	if len(ob.gasUsage) == 0 {
+		return
+	}
+	if err := bs.repo.AddGasUsage(int64(ob.block.Timestamp), ob.gasUsage); err != nil {
+		bs.log.Errorf("error storing gas usage of block %d: %v", ob.block.Number, err)
+	}
+}
+
+// storeTransactions stores transactions and accounts of the processed block in the database.
+func (bs *blockObserver) storeTransactions(ob *observedBlock) {
+	if len(ob.dbTxs) == 0 {
+		bs.log.Noticef("no transactions to store in block %d", ob.block.Number)
+		return
+	}
+
+	// store transactions
+	if err := bs.repo.AddTransactions(ob.dbTxs); err != nil {
 		bs.log.Criticalf("error storing transactions: %v", err)
 		return
 	}
 
-	bs.log.Noticef("stored %d transactions for block %d", len(txs), block.Number)
+	bs.log.Noticef("stored %d transactions for block %d", len(ob.dbTxs), ob.block.Number)
 
 	// store accounts
-	var accountsList []common.Address
-	for addr := range accounts {
-		accountsList = append(accountsList, addr)
-	}
-	if err := bs.repo.AddAccounts(accountsList, int64(block.Timestamp)); err != nil {
+	if err := bs.repo.AddAccounts(ob.accounts, int64(ob.block.Timestamp)); err != nil {
 		bs.log.Criticalf("error storing accounts: %v", err)
 		return
 	}
@@ -5,6 +5,7 @@ import (
 	"ftm-explorer/internal/logger"
 	"ftm-explorer/internal/repository"
 	"ftm-explorer/internal/types"
+	"math/big"
 	"testing"
 	"time"
 
@@ -76,3 +77,214 @@ func TestBlockObserver_IdleIsSet(t *testing.T) {
 	// wait for 1.5 second
 	time.Sleep(1500 * time.Millisecond)
 }
+
+// TestBlockObserver_CommitsInOrder tests that blocks are committed in the order they were observed,
+// even if their transactions are fetched out of order.
+func TestBlockObserver_CommitsInOrder(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	// create a channel, which will be used by the observer
+	blocks := make(chan *types.Block)
+
+	// start observer with persisted data, so that transactions are fetched by workers
+	cfg := &config.Config{Explorer: config.Explorer{IsPersisted: true, FetchWorkers: 4, EnrichWorkers: 2}}
+	observer := newBlockObserver(&Manager{repo: mockRepository, log: mockLogger, cfg: cfg}, blocks)
+
+	count := 20
+	committed := make([]uint64, 0, count)
+
+	// transactions of older blocks are fetched slower, so they finish out of order
+	mockRepository.EXPECT().IsIdle().Return(false).AnyTimes()
+	mockRepository.EXPECT().FetchTransactionByHash(gomock.Any()).DoAndReturn(func(hash common.Hash) (*types.Transaction, error) {
+		time.Sleep(time.Duration(count-int(hash.Big().Int64())) * time.Millisecond)
+		return &types.Transaction{Hash: hash, From: common.Address{0x01}}, nil
+	}).Times(count)
+	mockRepository.EXPECT().UpdateLatestObservedBlock(gomock.Any()).DoAndReturn(func(blk *types.Block) error {
+		committed = append(committed, uint64(blk.Number))
+		return nil
+	}).Times(count)
+	mockRepository.EXPECT().IncrementTrxCount(gomock.Eq(uint(1))).Times(count)
+	mockRepository.EXPECT().AddTransactions(gomock.Any()).Times(count)
+	mockRepository.EXPECT().AddAccounts(gomock.Any(), gomock.Any()).Times(count)
+
+	observer.start()
+	for i := 0; i < count; i++ {
+		blocks <- &types.Block{
+			Number:       hexutil.Uint64(i),
+			Transactions: []common.Hash{common.BigToHash(big.NewInt(int64(i)))},
+		}
+	}
+
+	// closing the observer drains the pipeline
+	observer.close()
+
+	if len(committed) != count {
+		t.Fatalf("expected %d committed blocks, got %d", count, len(committed))
+	}
+	for i, number := range committed {
+		if number != uint64(i) {
+			t.Fatalf("expected block %d to be committed at position %d, got %d", i, i, number)
+		}
+	}
+}
+
+// TestBlockObserver_BoundsBlocksInFlight tests that a stalled block stops the observer
+// from taking new blocks once the limit of blocks in flight is reached.
+func TestBlockObserver_BoundsBlocksInFlight(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	// create a channel, which will be used by the observer
+	blocks := make(chan *types.Block)
+
+	cfg := &config.Config{Explorer: config.Explorer{IsPersisted: true, FetchWorkers: 2, EnrichWorkers: 1}}
+	observer := newBlockObserver(&Manager{repo: mockRepository, log: mockLogger, cfg: cfg}, blocks)
+
+	// transactions of the first block are not fetched until released
+	release := make(chan struct{})
+	mockRepository.EXPECT().IsIdle().Return(false).AnyTimes()
+	mockRepository.EXPECT().FetchTransactionByHash(gomock.Any()).DoAndReturn(func(hash common.Hash) (*types.Transaction, error) {
+		if hash.Big().Int64() == 0 {
+			<-release
+		}
+		return &types.Transaction{Hash: hash, From: common.Address{0x01}}, nil
+	}).AnyTimes()
+	mockRepository.EXPECT().UpdateLatestObservedBlock(gomock.Any()).AnyTimes()
+	mockRepository.EXPECT().IncrementTrxCount(gomock.Any()).AnyTimes()
+	mockRepository.EXPECT().AddTransactions(gomock.Any()).AnyTimes()
+	mockRepository.EXPECT().AddAccounts(gomock.Any(), gomock.Any()).AnyTimes()
+
+	observer.start()
+	accepted := 0
+	for i := 0; i < 20; i++ {
+		select {
+		case blocks <- &types.Block{Number: hexutil.Uint64(i), Transactions: []common.Hash{common.BigToHash(big.NewInt(int64(i)))}}:
+			accepted++
+			continue
+		case <-time.After(100 * time.Millisecond):
+		}
+		break
+	}
+
+	// let the stalled block through and drain the pipeline
+	close(release)
+	observer.close()
+
+	// the observer holds one more block while waiting for a free slot
+	if expected := 2*kObserverBlocksInFlightPerWorker + 1; accepted != expected {
+		t.Fatalf("expected %d blocks to be accepted, got %d", expected, accepted)
+	}
+}
+
+// TestBlockObserver_FetchesTransactionsIntoCache tests that transactions are fetched to populate
+// the transactions cache if the explorer data is not persisted, but caching of observed transactions is enabled.
+func TestBlockObserver_FetchesTransactionsIntoCache(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	// create a channel, which will be used by the observer
+	blocks := make(chan *types.Block)
+
+	cfg := &config.Config{Explorer: config.Explorer{TrxCacheSize: 100, CacheObservedTrxs: true}}
+	observer := newBlockObserver(&Manager{repo: mockRepository, log: mockLogger, cfg: cfg}, blocks)
+
+	blk := &types.Block{
+		Number:       hexutil.Uint64(1),
+		Transactions: []common.Hash{common.HexToHash("0xabcd"), common.HexToHash("0x1234")},
+	}
+
+	// expect the transactions to be fetched, but not stored
+	mockRepository.EXPECT().IsIdle().Return(false).AnyTimes()
+	mockRepository.EXPECT().FetchTransactionByHash(gomock.Eq(blk.Transactions[0])).Return(&types.Transaction{Hash: blk.Transactions[0]}, nil)
+	mockRepository.EXPECT().FetchTransactionByHash(gomock.Eq(blk.Transactions[1])).Return(&types.Transaction{Hash: blk.Transactions[1]}, nil)
+	mockRepository.EXPECT().UpdateLatestObservedBlock(gomock.Eq(blk))
+	mockRepository.EXPECT().IncrementTrxCount(gomock.Eq(uint(2)))
+
+	observer.start()
+	blocks <- blk
+
+	// closing the observer drains the pipeline
+	observer.close()
+}
+
+// Test that the gas used by transactions is aggregated per called contract method.
+func TestBlockObserver_AggregateGasUsage(t *testing.T) {
+	contract, other := common.Address{0x01}, common.Address{0x02}
+	gas := func(g uint64) *hexutil.Uint64 {
+		return (*hexutil.Uint64)(&g)
+	}
+	txs := []*types.Transaction{
+		{To: &contract, Input: hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb, 0x01}, GasUsed: gas(50_000)},
+		{To: &contract, Input: hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb, 0x02}, GasUsed: gas(30_000)},
+		{To: &contract, Input: hexutil.Bytes{0x40, 0xc1, 0x0f, 0x19}, GasUsed: gas(70_000)},
+		{To: &other, GasUsed: gas(21_000)},
+		// contract creations and transactions without receipt are skipped
+		{ContractAddress: &other, GasUsed: gas(1_000_000)},
+		{To: &other},
+	}
+
+	usage := aggregateGasUsage(txs)
+	expected := []types.GasUsage{
+		{Contract: contract, Selector: "0xa9059cbb", GasUsed: 80_000, Calls: 2},
+		{Contract: contract, Selector: "0x40c10f19", GasUsed: 70_000, Calls: 1},
+		{Contract: other, Selector: "0x", GasUsed: 21_000, Calls: 1},
+	}
+	if len(usage) != len(expected) {
+		t.Fatalf("expected %d methods, got %d", len(expected), len(usage))
+	}
+	for i := range expected {
+		if usage[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], usage[i])
+		}
+	}
+}
+
+// TestBlockObserver_SealsEpochs tests that the observed epochs are sealed, except the first partially observed one.
+func TestBlockObserver_SealsEpochs(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	observer := newBlockObserver(&Manager{repo: mockRepository, log: mockLogger, cfg: &config.Config{}}, nil)
+
+	// the first epoch 5 is not sealed, the following epochs are sealed once the next one starts
+	gomock.InOrder(
+		mockRepository.EXPECT().SealEpoch(gomock.Eq(uint64(6))).Return(nil),
+		mockRepository.EXPECT().SealEpoch(gomock.Eq(uint64(7))).Return(nil),
+	)
+	for i, epoch := range []uint64{5, 5, 6, 6, 6, 7, 8, 8} {
+		observer.updateEpoch(&types.Block{Number: hexutil.Uint64(i), Epoch: hexutil.Uint64(epoch)})
+	}
+
+	// the epochs are sealed outside the commit stage
+	sealed := make(chan struct{})
+	close(observer.sealQueue)
+	observer.sealEpochs(sealed)
+	<-sealed
+}
+
+// TestBlockObserver_SealDoesNotBlockCommits tests that a slow epoch sealing does not hold back committed blocks.
+func TestBlockObserver_SealDoesNotBlockCommits(t *testing.T) {
+	// initialize stubs
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	mockLogger := logger.NewMockLogger()
+
+	observer := newBlockObserver(&Manager{repo: mockRepository, log: mockLogger, cfg: &config.Config{}}, nil)
+
+	// the sealing is stalled, more epochs than the queue holds are started
+	for i := uint64(1); i <= kObserverSealQueueSize+3; i++ {
+		observer.updateEpoch(&types.Block{Number: hexutil.Uint64(i), Epoch: hexutil.Uint64(i)})
+	}
+	if len(observer.sealQueue) != kObserverSealQueueSize {
+		t.Fatalf("expected %d epochs queued, got %d", kObserverSealQueueSize, len(observer.sealQueue))
+	}
+}
@@ -0,0 +1,123 @@
+package svc
+
+import (
+	"ftm-explorer/internal/types"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum/core/types"
+)
+
+// kClaimWatchTickDuration represents the frequency of the claim watcher progress.
+const kClaimWatchTickDuration = 10 * time.Second
+
+// kClaimWatchTimeout represents the time after which a claim without receipt is considered failed.
+const kClaimWatchTimeout = 30 * time.Minute
+
+// claimWatcher represents a watcher confirming receipts of faucet claim transactions.
+// Claims with failed transactions are marked failed, so they do not count into the claims limit.
+type claimWatcher struct {
+	service
+	sigClose     chan struct{}
+	tickDuration time.Duration
+}
+
+// newClaimWatcher creates a new claim watcher.
+func newClaimWatcher(mgr *Manager) *claimWatcher {
+	return &claimWatcher{
+		service: service{
+			mgr:  mgr,
+			repo: mgr.repo,
+			log:  mgr.log.ModuleLogger("claim_watcher"),
+		},
+		sigClose:     make(chan struct{}, 1),
+		tickDuration: kClaimWatchTickDuration,
+	}
+}
+
+// start starts the claim watcher.
+func (cw *claimWatcher) start() {
+	cw.mgr.started(cw)
+	go cw.execute()
+}
+
+// close stops the claim watcher.
+func (cw *claimWatcher) close() {
+	cw.sigClose <- struct{}{}
+	cw.mgr.finished(cw)
+}
+
+// name returns the name of the claim watcher.
+func (cw *claimWatcher) name() string {
+	return "claim_watcher"
+}
+
+// execute executes the claim watcher.
+func (cw *claimWatcher) execute() {
+	ticker := time.NewTicker(cw.tickDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cw.sigClose:
+			return
+		case <-ticker.C:
+			cw.checkPendingClaims()
+		}
+	}
+}
+
+// checkPendingClaims updates the status of the pending claims by receipts of their transactions.
+func (cw *claimWatcher) checkPendingClaims() {
+	claims, err := cw.repo.GetPendingTokensRequests()
+	if err != nil {
+		cw.log.Errorf("failed to get pending claims: %s", err.Error())
+		return
+	}
+
+	for i := range claims {
+		tr := &claims[i]
+		status := cw.claimStatus(tr)
+		if status == types.TokensRequestPending {
+			continue
+		}
+
+		tr.Status = status
+		if err := cw.repo.UpdateTokensRequest(tr); err != nil {
+			cw.log.Errorf("failed to update claim %s: %s", tr.Id.Hex(), err.Error())
+			continue
+		}
+		if status == types.TokensRequestFailed {
+			cw.log.Warningf("claim %s of %s failed", tr.Id.Hex(), tr.IpAddress)
+		}
+	}
+}
+
+// claimStatus returns the status of the given pending claim by the receipt of its transaction.
+func (cw *claimWatcher) claimStatus(tr *types.TokensRequest) string {
+	// the transaction is dropped or has never been sent if not mined for too long
+	expired := tr.ClaimedAt != nil && time.Since(time.Unix(*tr.ClaimedAt, 0)) > kClaimWatchTimeout
+
+	// the transaction is being sent right now
+	if tr.TxHash == nil {
+		if expired {
+			return types.TokensRequestFailed
+		}
+		return types.TokensRequestPending
+	}
+
+	rec, err := cw.repo.TransactionReceipt(*tr.TxHash)
+	if err != nil {
+		cw.log.Errorf("failed to get receipt of %s: %s", tr.TxHash.Hex(), err.Error())
+		return types.TokensRequestPending
+	}
+	if rec == nil {
+		if expired {
+			return types.TokensRequestFailed
+		}
+		return types.TokensRequestPending
+	}
+	if rec.Status != eth.ReceiptStatusSuccessful {
+		return types.TokensRequestFailed
+	}
+	return types.TokensRequestMined
+}
@@ -0,0 +1,65 @@
+package svc
+
+import (
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"ftm-explorer/internal/types"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Test that pending claims are confirmed or failed by receipts of their transactions.
+func TestClaimWatcher_CheckPendingClaims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	watcher := newClaimWatcher(&Manager{repo: mockRepository, log: logger.NewMockLogger()})
+
+	now := time.Now().Unix()
+	expired := time.Now().Add(-kClaimWatchTimeout - time.Minute).Unix()
+	claim := func(hash *common.Hash, claimedAt int64) types.TokensRequest {
+		return types.TokensRequest{
+			Id:        primitive.NewObjectID(),
+			ClaimedAt: &claimedAt,
+			TxHash:    hash,
+			Status:    types.TokensRequestPending,
+		}
+	}
+	mined, reverted, unknown, dropped := common.Hash{0x01}, common.Hash{0x02}, common.Hash{0x03}, common.Hash{0x04}
+	claims := []types.TokensRequest{
+		claim(&mined, now),
+		claim(&reverted, now),
+		claim(&unknown, now),
+		claim(&dropped, expired),
+		claim(nil, now),
+	}
+
+	mockRepository.EXPECT().GetPendingTokensRequests().Return(claims, nil)
+	mockRepository.EXPECT().TransactionReceipt(mined).Return(&eth.Receipt{Status: eth.ReceiptStatusSuccessful}, nil)
+	mockRepository.EXPECT().TransactionReceipt(reverted).Return(&eth.Receipt{Status: eth.ReceiptStatusFailed}, nil)
+	mockRepository.EXPECT().TransactionReceipt(unknown).Return(nil, nil)
+	mockRepository.EXPECT().TransactionReceipt(dropped).Return(nil, nil)
+
+	updated := make(map[common.Hash]string)
+	mockRepository.EXPECT().UpdateTokensRequest(gomock.Any()).DoAndReturn(func(tr *types.TokensRequest) error {
+		updated[*tr.TxHash] = tr.Status
+		return nil
+	}).Times(3)
+
+	watcher.checkPendingClaims()
+
+	expected := map[common.Hash]string{
+		mined:    types.TokensRequestMined,
+		reverted: types.TokensRequestFailed,
+		dropped:  types.TokensRequestFailed,
+	}
+	for hash, status := range expected {
+		if updated[hash] != status {
+			t.Errorf("expected status %s of %s, got %s", status, hash.Hex(), updated[hash])
+		}
+	}
+}
@@ -8,6 +8,9 @@ const kCleanTickDuration = 5 * time.Second
 // kCleanMaxTtfCount represents the maximum number of ttfs to be persisted.
 const kCleanMaxTtfCount = 1_000
 
+// kCleanGasUsageAge represents the age of the gas usage to be persisted.
+const kCleanGasUsageAge = 30 * 24 * time.Hour
+
 // dataCleaner represents a cleaner of blockchain data.
 type dataCleaner struct {
 	service
@@ -55,6 +58,7 @@ func (dc *dataCleaner) execute() {
 		case <-ticker.C:
 			dc.cleanTransactions()
 			dc.cleanTtf()
+			dc.cleanGasUsage()
 		}
 	}
 }
@@ -76,3 +80,14 @@ func (dc *dataCleaner) cleanTtf() {
 		dc.log.Errorf("failed to shrink ttf: %s", err.Error())
 	}
 }
+
+// cleanGasUsage deletes the gas usage older than kCleanGasUsageAge.
+func (dc *dataCleaner) cleanGasUsage() {
+	// the gas usage is collected only if the explorer is persisted
+	if !dc.mgr.cfg.Explorer.IsPersisted {
+		return
+	}
+	if err := dc.repo.ShrinkGasUsage(time.Now().Add(-kCleanGasUsageAge).Unix()); err != nil {
+		dc.log.Errorf("failed to shrink gas usage: %s", err.Error())
+	}
+}
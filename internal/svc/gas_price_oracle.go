@@ -0,0 +1,192 @@
+package svc
+
+import (
+	"ftm-explorer/internal/types"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// kGasOracleTickDuration represents the frequency of the gas price oracle progress.
+const kGasOracleTickDuration = 5 * time.Second
+
+// kGasOracleDefaultBlocks represents the default number of blocks the gas price is estimated from.
+const kGasOracleDefaultBlocks = 20
+
+// Percentiles of the transaction gas prices used for the gas price estimates.
+const (
+	kGasPercentileSafeLow = 25
+	kGasPercentileAverage = 50
+	kGasPercentileFast    = 75
+	kGasPercentileFastest = 95
+)
+
+// blockGasPrices represents the gas prices paid by the transactions of an observed block.
+type blockGasPrices struct {
+	// prices are the gas prices paid by the transactions of the block.
+	prices []*big.Int
+	// tips are the priority fees paid above the base fee of the block, empty without base fee.
+	tips []*big.Int
+}
+
+// gasPriceOracle represents the oracle estimating the gas price from the gas prices
+// paid by the transactions of the latest observed blocks.
+type gasPriceOracle struct {
+	service
+	sigClose     chan struct{}
+	tickDuration time.Duration
+
+	// blocks is the number of latest observed blocks the gas price is estimated from.
+	blocks uint
+
+	// samples are the complete gas prices of the latest observed blocks by the block number.
+	samples map[hexutil.Uint64]*blockGasPrices
+}
+
+// newGasPriceOracle creates a new gas price oracle.
+func newGasPriceOracle(mgr *Manager) *gasPriceOracle {
+	blocks := uint(kGasOracleDefaultBlocks)
+	if mgr.cfg.Explorer.GasOracleBlocks > 0 {
+		blocks = mgr.cfg.Explorer.GasOracleBlocks
+	}
+
+	return &gasPriceOracle{
+		service: service{
+			mgr:  mgr,
+			repo: mgr.repo,
+			log:  mgr.log.ModuleLogger("gas_price_oracle"),
+		},
+		sigClose:     make(chan struct{}, 1),
+		tickDuration: kGasOracleTickDuration,
+		blocks:       blocks,
+		samples:      make(map[hexutil.Uint64]*blockGasPrices),
+	}
+}
+
+// start starts the gas price oracle.
+func (gpo *gasPriceOracle) start() {
+	gpo.mgr.started(gpo)
+	go gpo.execute()
+}
+
+// close stops the gas price oracle.
+func (gpo *gasPriceOracle) close() {
+	gpo.sigClose <- struct{}{}
+	gpo.mgr.finished(gpo)
+}
+
+// name returns the name of the gas price oracle.
+func (gpo *gasPriceOracle) name() string {
+	return "gas_price_oracle"
+}
+
+// execute executes the gas price oracle.
+func (gpo *gasPriceOracle) execute() {
+	ticker := time.NewTicker(gpo.tickDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-gpo.sigClose:
+			return
+		case <-ticker.C:
+			gpo.update()
+		}
+	}
+}
+
+// update samples the gas prices of the newly observed blocks and updates the gas price estimate.
+func (gpo *gasPriceOracle) update() {
+	blocks := gpo.repo.GetLatestObservedBlocks(gpo.blocks)
+	if len(blocks) == 0 {
+		return
+	}
+
+	// sample the new blocks and forget the blocks out of the window;
+	// incomplete samples are used for this estimate only, the block is sampled again later
+	var prices, tips []*big.Int
+	samples := make(map[hexutil.Uint64]*blockGasPrices, len(blocks))
+	for _, block := range blocks {
+		sample, ok := gpo.samples[block.Number]
+		if !ok {
+			var complete bool
+			sample, complete = gpo.sample(block)
+			if complete {
+				samples[block.Number] = sample
+			}
+		} else {
+			samples[block.Number] = sample
+		}
+		prices = append(prices, sample.prices...)
+		tips = append(tips, sample.tips...)
+	}
+	gpo.samples = samples
+
+	// the chain is idle, keep the last estimate or the node suggestion
+	if len(prices) == 0 {
+		return
+	}
+
+	latest := blocks[0]
+	for _, block := range blocks {
+		if block.Number > latest.Number {
+			latest = block
+		}
+	}
+
+	gp := &types.GasPrice{
+		SafeLow:     hexutil.Big(*percentile(prices, kGasPercentileSafeLow)),
+		Average:     hexutil.Big(*percentile(prices, kGasPercentileAverage)),
+		Fast:        hexutil.Big(*percentile(prices, kGasPercentileFast)),
+		Fastest:     hexutil.Big(*percentile(prices, kGasPercentileFastest)),
+		BaseFee:     latest.BaseFee,
+		BlockNumber: latest.Number,
+	}
+	if latest.BaseFee != nil && len(tips) > 0 {
+		gp.PriorityFee = (*hexutil.Big)(percentile(tips, kGasPercentileAverage))
+	}
+	gpo.repo.SetGasPrice(gp)
+}
+
+// sample returns the gas prices paid by the transactions of the given block
+// and whether all the transactions of the block were sampled.
+// The transactions are usually served from the cache populated by the block observer.
+func (gpo *gasPriceOracle) sample(block *types.Block) (*blockGasPrices, bool) {
+	sample := &blockGasPrices{
+		prices: make([]*big.Int, 0, len(block.Transactions)),
+	}
+	complete := true
+	for _, hash := range block.Transactions {
+		tx, err := gpo.repo.GetTransactionByHash(hash)
+		if err != nil {
+			gpo.log.Errorf("error getting transaction %s: %v", hash.Hex(), err)
+			complete = false
+			continue
+		}
+		if tx == nil {
+			continue
+		}
+
+		price := tx.GasPrice.ToInt()
+		sample.prices = append(sample.prices, price)
+
+		// the gas price of a mined transaction is the base fee and the priority fee paid
+		if block.BaseFee != nil {
+			tip := new(big.Int).Sub(price, block.BaseFee.ToInt())
+			if tip.Sign() < 0 {
+				tip.SetUint64(0)
+			}
+			sample.tips = append(sample.tips, tip)
+		}
+	}
+	return sample, complete
+}
+
+// percentile returns the given percentile of the values. The values are sorted in place.
+func percentile(values []*big.Int, p int) *big.Int {
+	sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+	idx := (len(values) - 1) * p / 100
+	return new(big.Int).Set(values[idx])
+}
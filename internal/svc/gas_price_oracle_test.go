@@ -0,0 +1,89 @@
+package svc
+
+import (
+	"errors"
+	"ftm-explorer/internal/config"
+	"ftm-explorer/internal/logger"
+	"ftm-explorer/internal/repository"
+	"ftm-explorer/internal/types"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+)
+
+// Test that the gas price is estimated by percentiles of the transaction gas prices of the observed blocks.
+func TestGasPriceOracle_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	oracle := newGasPriceOracle(&Manager{cfg: &config.Config{Explorer: config.Explorer{GasOracleBlocks: 2}}, repo: mockRepository, log: logger.NewMockLogger()})
+
+	// two blocks with gas prices 1..4 and 5..8, the base fee is known only for the latest one
+	blocks := []*types.Block{
+		{Number: 11, BaseFee: (*hexutil.Big)(big.NewInt(5)), Transactions: []common.Hash{{0x05}, {0x06}, {0x07}, {0x08}}},
+		{Number: 10, Transactions: []common.Hash{{0x01}, {0x02}, {0x03}, {0x04}}},
+	}
+	mockRepository.EXPECT().GetLatestObservedBlocks(uint(2)).Return(blocks).Times(2)
+	for i := byte(1); i <= 8; i++ {
+		// the transactions are fetched only once
+		mockRepository.EXPECT().GetTransactionByHash(common.Hash{i}).Return(&types.Transaction{GasPrice: hexutil.Big(*big.NewInt(int64(i)))}, nil)
+	}
+
+	var gp *types.GasPrice
+	mockRepository.EXPECT().SetGasPrice(gomock.Any()).Do(func(p *types.GasPrice) { gp = p }).Times(2)
+
+	oracle.update()
+	oracle.update()
+
+	if gp == nil {
+		t.Fatalf("expected gas price to be estimated")
+	}
+	expected := map[string]int64{"safeLow": 2, "average": 4, "fast": 6, "fastest": 7}
+	actual := map[string]*hexutil.Big{"safeLow": &gp.SafeLow, "average": &gp.Average, "fast": &gp.Fast, "fastest": &gp.Fastest}
+	for name, price := range expected {
+		if actual[name].ToInt().Int64() != price {
+			t.Errorf("expected %s gas price %d, got %d", name, price, actual[name].ToInt().Int64())
+		}
+	}
+	if gp.BaseFee == nil || gp.BaseFee.ToInt().Int64() != 5 || gp.BlockNumber != 11 {
+		t.Errorf("expected base fee 5 of block 11, got %v of block %d", gp.BaseFee, gp.BlockNumber)
+	}
+	// the tips of the latest block are 0, 1, 2, 3
+	if gp.PriorityFee == nil || gp.PriorityFee.ToInt().Int64() != 1 {
+		t.Errorf("expected priority fee 1, got %v", gp.PriorityFee)
+	}
+}
+
+// Test that the estimate is kept while the chain is idle.
+func TestGasPriceOracle_UpdateIdle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	oracle := newGasPriceOracle(&Manager{cfg: &config.Config{}, repo: mockRepository, log: logger.NewMockLogger()})
+
+	mockRepository.EXPECT().GetLatestObservedBlocks(uint(kGasOracleDefaultBlocks)).Return([]*types.Block{{Number: 1}})
+	oracle.update()
+}
+
+// Test that the sample of a block is not kept if some of its transactions failed to load.
+func TestGasPriceOracle_UpdateIncompleteSample(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepository := repository.NewMockRepository(ctrl)
+	oracle := newGasPriceOracle(&Manager{cfg: &config.Config{}, repo: mockRepository, log: logger.NewMockLogger()})
+
+	blocks := []*types.Block{{Number: 1, Transactions: []common.Hash{{0x01}, {0x02}}}}
+	mockRepository.EXPECT().GetLatestObservedBlocks(uint(kGasOracleDefaultBlocks)).Return(blocks).Times(3)
+	mockRepository.EXPECT().SetGasPrice(gomock.Any()).Times(3)
+
+	// the first update fails to load a transaction, the block is sampled again by the second one
+	mockRepository.EXPECT().GetTransactionByHash(common.Hash{0x01}).Return(&types.Transaction{GasPrice: hexutil.Big(*big.NewInt(1))}, nil).Times(2)
+	gomock.InOrder(
+		mockRepository.EXPECT().GetTransactionByHash(common.Hash{0x02}).Return(nil, errors.New("not available")),
+		mockRepository.EXPECT().GetTransactionByHash(common.Hash{0x02}).Return(&types.Transaction{GasPrice: hexutil.Big(*big.NewInt(2))}, nil),
+	)
+
+	oracle.update()
+	oracle.update()
+	oracle.update()
+}
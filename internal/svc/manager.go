@@ -59,6 +59,8 @@ func (mgr *Manager) init() {
 	mgr.svc = append(mgr.svc, newBlockObserver(mgr, blkScanner.scannedBlocks()))
 	mgr.svc = append(mgr.svc, newMetadataObserver(mgr))
 	mgr.svc = append(mgr.svc, newDataCleaner(mgr))
+	mgr.svc = append(mgr.svc, newClaimWatcher(mgr))
+	mgr.svc = append(mgr.svc, newGasPriceOracle(mgr))
 }
 
 // started signals to the manager that the calling service
@@ -131,6 +131,10 @@ func (mo *metadataObserver) execute() {
 				mo.repo.SetIsIdleOverride(isIdleStatus)
 				latestIsIdleStatus = isIdleStatus
 			}
+
+			// report transactions cache usage
+			stats := mo.repo.GetTransactionCacheStats()
+			mo.log.Debugf("transaction cache: %d hits, %d misses, %d entries", stats.Hits, stats.Misses, stats.Size)
 		}
 	}
 }
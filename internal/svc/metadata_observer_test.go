@@ -17,13 +17,6 @@ func TestMetadataObserver_Run(t *testing.T) {
 	mockRepository := repository.NewMockRepository(ctrl)
 	mockLogger := logger.NewMockLogger()
 
-	// start observer
-	tickDuration := 100 * time.Millisecond
-	observer := newMetadataObserver(&Manager{repo: mockRepository, log: mockLogger})
-	observer.tickDuration = tickDuration
-	observer.start()
-	defer observer.close()
-
 	// expect call to fetcher and repository
 	numberOfAccounts := uint64(100)
 	diskSizePer100MTxs := uint64(72799695667)
@@ -47,6 +40,15 @@ func TestMetadataObserver_Run(t *testing.T) {
 	mockRepository.EXPECT().FetchIsIdleStatus().Return(true, nil)
 	mockRepository.EXPECT().SetIsIdleOverride(gomock.Eq(true))
 
+	mockRepository.EXPECT().GetTransactionCacheStats().Return(types.CacheStats{})
+
+	// start observer once the expectations are set, the mock must not be modified concurrently
+	tickDuration := 100 * time.Millisecond
+	observer := newMetadataObserver(&Manager{repo: mockRepository, log: mockLogger})
+	observer.tickDuration = tickDuration
+	observer.start()
+	defer observer.close()
+
 	// wait for ticker, add some extra time to make sure the ticker has ticked
 	time.Sleep(tickDuration + tickDuration/2)
 }
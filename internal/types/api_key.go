@@ -0,0 +1,29 @@
+package types
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ApiKey represents a key granting its holder access to the API with the given roles.
+type ApiKey struct {
+	Id primitive.ObjectID `bson:"_id"`
+
+	// Name is the unique name of the key holder.
+	Name string `bson:"name"`
+
+	// Hash is the SHA-256 hash of the key, the key itself is never stored.
+	Hash string `bson:"hash"`
+
+	// Roles is the list of roles granted to the key holder.
+	Roles []string `bson:"roles"`
+
+	// CreatedAt is the time when the key was created.
+	CreatedAt int64 `bson:"created_at"`
+
+	// LastUsedAt is the time when the key was used for the last time.
+	LastUsedAt *int64 `bson:"last_used_at"`
+
+	// Usage is the number of requests made with the key.
+	Usage uint64 `bson:"usage"`
+
+	// RevokedAt is the time when the key was revoked.
+	RevokedAt *int64 `bson:"revoked_at"`
+}
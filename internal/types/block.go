@@ -28,6 +28,9 @@ type Block struct {
 	// Timestamp represents the unix timestamp for when the block was collated.
 	Timestamp hexutil.Uint64 `json:"timestamp"`
 
+	// BaseFee represents the base fee per gas of the block, nil if the chain does not support London.
+	BaseFee *hexutil.Big `json:"baseFeePerGas"`
+
 	// Transactions represents array of 32 bytes hashes of transactions included in the block.
 	Transactions []common.Hash `json:"transactions"`
 }
@@ -0,0 +1,11 @@
+package types
+
+// CacheStats represents usage statistics of an in-memory cache.
+type CacheStats struct {
+	// Hits is the number of lookups served from the cache.
+	Hits uint64
+	// Misses is the number of lookups not found in the cache.
+	Misses uint64
+	// Size is the number of entries in the cache.
+	Size uint
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Delegation represents the stake delegated by an account to a validator, as registered in the SFC contract.
+type Delegation struct {
+	// Delegator represents the address of the delegating account.
+	Delegator common.Address `json:"delegator"`
+
+	// ValidatorId represents the id of the validator the stake is delegated to.
+	ValidatorId hexutil.Uint64 `json:"validatorId"`
+
+	// Amount represents the delegated amount.
+	Amount hexutil.Big `json:"amount"`
+
+	// PendingRewards represents the rewards which can be claimed, including the stashed rewards.
+	PendingRewards hexutil.Big `json:"pendingRewards"`
+
+	// StashedRewards represents the rewards already moved to the stash, e.g. on undelegation.
+	StashedRewards hexutil.Big `json:"stashedRewards"`
+
+	// Lockup represents the lockup of the delegated stake, nil if no stake is locked.
+	Lockup *Lockup `json:"lockup"`
+}
+
+// Lockup represents the locked part of a delegation.
+type Lockup struct {
+	// LockedStake represents the locked amount.
+	LockedStake hexutil.Big `json:"lockedStake"`
+
+	// FromEpoch represents the epoch the stake was locked in.
+	FromEpoch hexutil.Uint64 `json:"fromEpoch"`
+
+	// EndTime represents the unix timestamp the lockup ends at.
+	EndTime hexutil.Uint64 `json:"endTime"`
+
+	// Duration represents the duration of the lockup in seconds.
+	Duration hexutil.Uint64 `json:"duration"`
+}
+
+// StakingRewards represents the rewards of all the delegations of an account.
+type StakingRewards struct {
+	// Pending represents the rewards which can be claimed, including the stashed rewards.
+	Pending hexutil.Big `json:"pending"`
+
+	// Stashed represents the rewards already moved to the stash.
+	Stashed hexutil.Big `json:"stashed"`
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Epoch represents the snapshot of a sealed epoch as stored in the SFC contract.
+type Epoch struct {
+	// Number represents the number of the epoch.
+	Number hexutil.Uint64 `json:"number"`
+
+	// EndTime represents the unix timestamp the epoch was sealed at.
+	EndTime hexutil.Uint64 `json:"endTime"`
+
+	// Duration represents the number of seconds between the end of the previous epoch and the end of this epoch.
+	Duration hexutil.Uint64 `json:"duration"`
+
+	// EpochFee represents the total fee collected in the epoch.
+	EpochFee hexutil.Big `json:"epochFee"`
+
+	// BaseRewardPerSecond represents the base reward per second paid to the validators in the epoch.
+	BaseRewardPerSecond hexutil.Big `json:"baseRewardPerSecond"`
+
+	// TotalStake represents the total stake of the validators of the epoch.
+	TotalStake hexutil.Big `json:"totalStake"`
+
+	// TotalSupply represents the total supply of the native tokens at the end of the epoch.
+	TotalSupply hexutil.Big `json:"totalSupply"`
+
+	// ValidatorIds represents the ids of the validators of the epoch.
+	ValidatorIds []hexutil.Uint64 `json:"validatorIds"`
+}
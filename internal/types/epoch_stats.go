@@ -0,0 +1,34 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// EpochStats represents the statistics of the observed blocks of a sealed epoch.
+type EpochStats struct {
+	// Epoch represents the number of the epoch.
+	Epoch hexutil.Uint64 `json:"epoch"`
+
+	// Blocks represents the number of blocks of the epoch.
+	Blocks hexutil.Uint64 `json:"blocks"`
+
+	// Transactions represents the number of transactions of the epoch.
+	Transactions hexutil.Uint64 `json:"transactions"`
+
+	// GasUsed represents the total gas used by the blocks of the epoch.
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+
+	// StartTime represents the unix timestamp of the first block of the epoch.
+	StartTime hexutil.Uint64 `json:"startTime"`
+
+	// EndTime represents the unix timestamp of the last block of the epoch.
+	EndTime hexutil.Uint64 `json:"endTime"`
+
+	// Duration represents the number of seconds between the last block of the previous epoch
+	// and the last block of this epoch, or between the first and the last block of this epoch
+	// if the blocks of the previous epoch are not known.
+	Duration hexutil.Uint64 `json:"duration"`
+
+	// AvgTtf represents the average time to finality in seconds measured during the epoch, zero if not measured.
+	AvgTtf float64 `json:"avgTtf"`
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// GasPrice represents the gas price estimate in wei, derived from the gas prices
+// paid by the transactions of the latest observed blocks.
+type GasPrice struct {
+	// SafeLow is the gas price likely to be accepted, but not soon.
+	SafeLow hexutil.Big `json:"safeLow"`
+
+	// Average is the gas price paid by an average transaction.
+	Average hexutil.Big `json:"average"`
+
+	// Fast is the gas price likely to be accepted within a few blocks.
+	Fast hexutil.Big `json:"fast"`
+
+	// Fastest is the gas price likely to be accepted in the next block.
+	Fastest hexutil.Big `json:"fastest"`
+
+	// BaseFee is the base fee of the latest block, nil if the chain does not support London.
+	BaseFee *hexutil.Big `json:"baseFee"`
+
+	// PriorityFee is the average priority fee paid above the base fee, nil if the chain does not support London.
+	PriorityFee *hexutil.Big `json:"priorityFee"`
+
+	// BlockNumber is the number of the latest block the estimate is derived from.
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GasUsage represents the gas used by the calls of a contract method.
+type GasUsage struct {
+	// Contract is the address of the called contract.
+	Contract common.Address
+
+	// Selector is the 0x prefixed 4-byte selector of the called method, "0x" for calls without data.
+	Selector string
+
+	// GasUsed is the total gas used by the calls.
+	GasUsed uint64
+
+	// Calls is the number of the calls.
+	Calls uint64
+}
@@ -1,6 +1,10 @@
 package types
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 // MazePathDirection represents the direction of the maze path.
 type MazePathDirection string
@@ -66,3 +70,18 @@ type MazePosition struct {
 	// Paths are the paths from the position.
 	Paths []MazePath `json:"paths"`
 }
+
+// MazeChallenge represents an issued maze position challenge.
+type MazeChallenge struct {
+	// Phrase is the random phrase of the challenge.
+	Phrase string `bson:"_id"`
+
+	// ExpiresAt is the time when the challenge expires.
+	ExpiresAt time.Time `bson:"expires_at"`
+
+	// UsedAt is the time when the challenge was used.
+	UsedAt *time.Time `bson:"used_at"`
+
+	// UsedBy is the address of the player who used the challenge.
+	UsedBy *common.Address `bson:"used_by"`
+}
@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// RateLimit represents a token bucket rate limit.
+type RateLimit struct {
+	// Rate is the number of tokens added to the bucket per second.
+	Rate float64
+	// Burst is the capacity of the bucket.
+	Burst int
+}
+
+// RateLimitResult represents the result of taking a token from a rate limit bucket.
+type RateLimitResult struct {
+	// Allowed is true if a token was taken from the bucket.
+	Allowed bool
+	// RetryAfter is the time until a token becomes available, if not allowed.
+	RetryAfter time.Duration
+}
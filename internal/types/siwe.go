@@ -0,0 +1,40 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SiweNonce represents a nonce of an issued Sign-In with Ethereum message.
+type SiweNonce struct {
+	// Nonce is the random nonce of the message.
+	Nonce string `bson:"_id"`
+
+	// Address is the address the message was issued for.
+	Address common.Address `bson:"address"`
+
+	// ExpiresAt is the time when the message expires.
+	ExpiresAt time.Time `bson:"expires_at"`
+
+	// UsedAt is the time when the message was used to sign in.
+	UsedAt *time.Time `bson:"used_at"`
+}
+
+// SiweSession represents a session established by signing a Sign-In with Ethereum message.
+type SiweSession struct {
+	// TokenHash is the SHA-256 hash of the session token, the token itself is never stored.
+	TokenHash string `bson:"_id"`
+
+	// Address is the address of the signed-in account.
+	Address common.Address `bson:"address"`
+
+	// ChainId is the chain id the account signed in for.
+	ChainId uint64 `bson:"chain_id"`
+
+	// CreatedAt is the time when the session was established.
+	CreatedAt time.Time `bson:"created_at"`
+
+	// ExpiresAt is the time when the session expires.
+	ExpiresAt time.Time `bson:"expires_at"`
+}
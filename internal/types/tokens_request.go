@@ -5,6 +5,23 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	// TokensRequestPending is the status of a claim waiting for its transaction to be mined.
+	TokensRequestPending = "pending"
+
+	// TokensRequestMined is the status of a claim with successfully mined transaction.
+	TokensRequestMined = "mined"
+
+	// TokensRequestFailed is the status of a claim with reverted or dropped transaction.
+	// Failed claims do not count into the claims limit.
+	TokensRequestFailed = "failed"
+
+	// TokensRequestReset is the status of a mined claim reset by the faucet administrator.
+	// Reset claims do not count into the claims limit of the ip address, but they still
+	// count into the faucet budgets and the limits of the receiver.
+	TokensRequestReset = "reset"
+)
+
 // TokensRequest represents a request for tokens.
 type TokensRequest struct {
 	Id primitive.ObjectID `bson:"_id"`
@@ -20,4 +37,13 @@ type TokensRequest struct {
 
 	// ClaimedAt is the time when the tokens were claimed.
 	ClaimedAt *int64 `bson:"claimed_at"`
+
+	// Erc20 is the address of the claimed erc20 token, nil for native tokens.
+	Erc20 *common.Address `bson:"erc20,omitempty"`
+
+	// TxHash is the hash of the transaction sending the claimed tokens.
+	TxHash *common.Hash `bson:"tx_hash,omitempty"`
+
+	// Status is the status of the claim transaction, empty if not claimed.
+	Status string `bson:"status,omitempty"`
 }
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ValidatorStatus represents the status of a validator.
+type ValidatorStatus string
+
+const (
+	// ValidatorStatusActive represents a validator with no status flags set.
+	ValidatorStatusActive ValidatorStatus = "ACTIVE"
+
+	// ValidatorStatusOffline represents a validator deactivated for being offline.
+	ValidatorStatusOffline ValidatorStatus = "OFFLINE"
+
+	// ValidatorStatusWithdrawn represents a validator deactivated by withdrawing its stake.
+	ValidatorStatusWithdrawn ValidatorStatus = "WITHDRAWN"
+
+	// ValidatorStatusCheater represents a validator deactivated for double signing.
+	ValidatorStatusCheater ValidatorStatus = "CHEATER"
+)
+
+// Validator represents a validator of the Opera network as registered in the SFC contract.
+type Validator struct {
+	// Id represents the id of the validator.
+	Id hexutil.Uint64 `json:"id"`
+
+	// Auth represents the address the validator is authorized by.
+	Auth common.Address `json:"auth"`
+
+	// Status represents the status of the validator.
+	Status ValidatorStatus `json:"status"`
+
+	// SelfStake represents the amount staked by the validator itself.
+	SelfStake hexutil.Big `json:"selfStake"`
+
+	// DelegatedStake represents the amount delegated to the validator by other accounts.
+	DelegatedStake hexutil.Big `json:"delegatedStake"`
+
+	// CreatedEpoch represents the epoch the validator was created in.
+	CreatedEpoch hexutil.Uint64 `json:"createdEpoch"`
+
+	// CreatedTime represents the unix timestamp the validator was created at.
+	CreatedTime hexutil.Uint64 `json:"createdTime"`
+
+	// DeactivatedEpoch represents the epoch the validator was deactivated in, zero if it is active.
+	DeactivatedEpoch hexutil.Uint64 `json:"deactivatedEpoch"`
+
+	// DeactivatedTime represents the unix timestamp the validator was deactivated at, zero if it is active.
+	DeactivatedTime hexutil.Uint64 `json:"deactivatedTime"`
+
+	// Uptime represents the number of seconds the validator was online in the epoch it was read for.
+	Uptime hexutil.Uint64 `json:"uptime"`
+}